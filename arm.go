@@ -13,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/sensor"
+	toggleswitch "go.viam.com/rdk/components/switch"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
@@ -46,12 +48,144 @@ type SO101ArmConfig struct {
 
 	Timeout time.Duration `json:"timeout,omitempty"`
 
+	// Bus protocol, "sts" (default) or "scs", and servos that speak SCS on an STS bus
+	Protocol    string `json:"protocol,omitempty"`
+	SCSServoIDs []int  `json:"scs_servo_ids,omitempty"`
+
+	// How serial reads, writes and pings are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Second adapter on the same bus to fail over to, see SoArm101Config
+	SecondaryPort         string `json:"secondary_port,omitempty"`
+	FailoverAfterFailures int    `json:"failover_after_failures,omitempty"`
+
 	SpeedDegsPerSec        float32 `json:"speed_degs_per_sec,omitempty"`
 	AccelerationDegsPerSec float32 `json:"acceleration_degs_per_sec_per_sec,omitempty"`
 
 	Motion string `json:"motion,omitempty"`
 
 	CalibrationFile string `json:"calibration_file,omitempty"`
+
+	// Reload calibration automatically when the file changes
+	WatchCalibrationFile bool `json:"watch_calibration_file,omitempty"`
+
+	// Directory of calibration profiles, one <profile>.json file each, used instead of
+	// calibration_file. calibration_profile picks one, default "default".
+	CalibrationProfilesDir string `json:"calibration_profiles_dir,omitempty"`
+	CalibrationProfile     string `json:"calibration_profile,omitempty"`
+
+	// Maintenance reminder thresholds, zero disables the check
+	MaintenanceTravelDegs  float64 `json:"maintenance_travel_degs,omitempty"`
+	MaintenanceTorqueHours float64 `json:"maintenance_torque_hours,omitempty"`
+
+	// Keep a local summary of moves and errors for get_usage_summary, never uploaded
+	UsageSummary bool `json:"usage_summary,omitempty"`
+
+	// What to do when a move is cancelled: "hold" (default), "retreat_to_last_waypoint" or "park"
+	OnCancel string `json:"on_cancel,omitempty"`
+	// Joint positions in degrees used by the "park" policy, defaults to a folded rest pose
+	// computed from the kinematic model
+	ParkPose []float64 `json:"park_pose,omitempty"`
+
+	// Named joint poses in degrees, one value per servo in servo_ids, for goto_pose
+	Poses map[string][]float64 `json:"poses,omitempty"`
+	// YAML file of named poses and programs, merged with poses
+	PosesFile string `json:"poses_file,omitempty"`
+
+	// What to do when the arm is closed: "hold" (default) keeps torque on, "limp" disables
+	// it, "park" moves to the park pose first and then disables it
+	ShutdownBehavior string `json:"shutdown_behavior,omitempty"`
+
+	// MoveToPosition solver: "motion" (default) plans with the motion service, "local" runs
+	// a deterministic IK solve on the arm and moves there directly
+	IKSolver string `json:"ik_solver,omitempty"`
+	// Fixed IK seed in degrees, defaults to the current joint positions
+	IKSeedDegs []float64 `json:"ik_seed_degs,omitempty"`
+	// Orientation tolerance for the local solver in degrees, zero solves for position only
+	IKOrientationToleranceDegs float64 `json:"ik_orientation_tolerance_degs,omitempty"`
+	// Preferred sign of the elbow_flex angle for the local solver, "positive" or "negative"
+	IKElbow string `json:"ik_elbow,omitempty"`
+
+	// How IsMoving is determined: "command" (default) reports moves commanded by this arm,
+	// "hardware" also reads the servos so teleop and manual moves are reported
+	IsMovingSource string `json:"is_moving_source,omitempty"`
+
+	// MoveThroughJointPositions starts toward the next waypoint once every joint is within
+	// this many degrees of the current one instead of stopping at each, zero disables blending
+	BlendRadiusDegs float64 `json:"blend_radius_degs,omitempty"`
+	// MoveThroughJointPositions skips waypoints where no joint moves more than this many
+	// degrees from the last one kept, zero keeps every waypoint
+	WaypointEpsilonDegs float64 `json:"waypoint_epsilon_degs,omitempty"`
+
+	// Log every clamp and read warning instead of rate limiting repeats
+	VerboseLogging bool `json:"verbose_logging,omitempty"`
+
+	// Per-joint limits in degrees, keyed by joint name, that narrow the calibrated range
+	JointLimits map[string]JointLimit `json:"joint_limits,omitempty"`
+
+	// Per-joint torque caps as a percentage of stall torque, keyed by joint name
+	MaxTorquePercent map[string]float64 `json:"max_torque_percent,omitempty"`
+
+	// Servo temperature above which thermal protection kicks in, default 65°C
+	MaxTemperatureC float64 `json:"max_temperature_c,omitempty"`
+	// Thermal protection for overheated joints: "reduce" (default) halves speed and the joint's
+	// torque limit, "disable" turns off the joint's torque
+	ThermalAction string `json:"thermal_action,omitempty"`
+
+	// get_power_status reports low_voltage when any servo's supply drops below this, zero disables
+	LowVoltageWarningV float64 `json:"low_voltage_warning_v,omitempty"`
+
+	// Refuse motion until a valid calibration file is loaded
+	RequireCalibrationFile bool `json:"require_calibration_file,omitempty"`
+
+	// Ramp the torque limit up over this many milliseconds when torque is enabled, zero
+	// enables at full torque
+	TorqueRampMs int `json:"torque_ramp_ms,omitempty"`
+
+	// Compare the calibration file with the servo registers at startup: "prefer_file",
+	// "prefer_servo" or "fail". Unset skips the comparison.
+	CalibrationMismatch string `json:"calibration_mismatch,omitempty"`
+
+	// Switch component that triggers the emergency stop when in any position but 0
+	EstopSwitch string `json:"estop_switch,omitempty"`
+
+	// Sensor whose readings give a target for the follow DoCommand to track
+	FollowSource string `json:"follow_source,omitempty"`
+	// Target reads per second while following, default 10
+	FollowRateHz float64 `json:"follow_rate_hz,omitempty"`
+	// Joint speed limit while following, defaults to speed_degs_per_sec
+	FollowMaxSpeedDegsPerSec float64 `json:"follow_max_speed_degs_per_sec,omitempty"`
+
+	// Serve the last known position for a servo that fails a read instead of failing JointPositions
+	DegradedReads bool `json:"degraded_reads,omitempty"`
+
+	// Read joint positions in the background this many times per second and serve reads
+	// from the cache, zero reads the servos on every call
+	PositionPollHz float64 `json:"position_poll_hz,omitempty"`
+
+	// Consecutive failed bus reads during a move before the watchdog stops the arm, default 3
+	WatchdogMaxFailures int `json:"watchdog_max_failures,omitempty"`
+
+	// Abort a move when a joint's load stays at or above this percent for collision_samples
+	// polls, zero disables. Optionally drop every joint's torque limit afterwards.
+	CollisionLoadPercent   float64 `json:"collision_load_percent,omitempty"`
+	CollisionSamples       int     `json:"collision_samples,omitempty"`
+	CollisionTorquePercent float64 `json:"collision_torque_percent,omitempty"`
+
+	// Latch possible_decalibration when a joint stops this far from its target, zero disables.
+	// While latched, refuse moves faster than decalibration_max_speed_degs_per_sec if set.
+	DecalibrationThresholdDegs      float64 `json:"decalibration_threshold_degs,omitempty"`
+	DecalibrationMaxSpeedDegsPerSec float64 `json:"decalibration_max_speed_degs_per_sec,omitempty"`
+}
+
+// ErrCalibrationRequired is returned for motion commands when require_calibration_file is set
+// and the arm is running without a calibration file
+var ErrCalibrationRequired = errors.New("CALIBRATION_REQUIRED: the arm is running on default calibration, load a valid calibration_file before moving")
+
+// JointLimit is a joint's allowed range in degrees
+type JointLimit struct {
+	MinDegs float64 `json:"min_degs"`
+	MaxDegs float64 `json:"max_degs"`
 }
 
 // Validate ensures all parts of the config are valid
@@ -60,6 +194,16 @@ func (cfg *SO101ArmConfig) Validate(path string) ([]string, []string, error) {
 		return nil, nil, fmt.Errorf("must specify port for serial communication")
 	}
 
+	if err := validateBusProtocol(cfg.Protocol, cfg.SCSServoIDs); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Retry.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := validateFailover(cfg.Port, cfg.SecondaryPort, cfg.FailoverAfterFailures); err != nil {
+		return nil, nil, err
+	}
+
 	// Default to arm servos (1-5) if not specified
 	if len(cfg.ServoIDs) == 0 {
 		cfg.ServoIDs = []int{1, 2, 3, 4, 5}
@@ -72,6 +216,132 @@ func (cfg *SO101ArmConfig) Validate(path string) ([]string, []string, error) {
 		}
 	}
 
+	if cfg.MaintenanceTravelDegs < 0 || cfg.MaintenanceTorqueHours < 0 {
+		return nil, nil, fmt.Errorf("maintenance thresholds must not be negative")
+	}
+
+	if len(cfg.ParkPose) > 0 && len(cfg.ParkPose) != len(cfg.ServoIDs) {
+		return nil, nil, fmt.Errorf("park_pose must have %d joint positions, got %d", len(cfg.ServoIDs), len(cfg.ParkPose))
+	}
+
+	switch cfg.OnCancel {
+	case "", onCancelHold, onCancelRetreat, onCancelPark:
+	default:
+		return nil, nil, fmt.Errorf("on_cancel must be one of \"hold\", \"retreat_to_last_waypoint\" or \"park\", got %q", cfg.OnCancel)
+	}
+
+	for name, pose := range cfg.Poses {
+		if name == "" {
+			return nil, nil, fmt.Errorf("pose names must not be empty")
+		}
+		if len(pose) != len(cfg.ServoIDs) {
+			return nil, nil, fmt.Errorf("pose %q must have %d joint positions, got %d", name, len(cfg.ServoIDs), len(pose))
+		}
+	}
+
+	if cfg.TorqueRampMs < 0 || cfg.TorqueRampMs > 10000 {
+		return nil, nil, fmt.Errorf("torque_ramp_ms must be between 0 and 10000, got %d", cfg.TorqueRampMs)
+	}
+
+	switch cfg.CalibrationMismatch {
+	case "", calibrationMismatchPreferFile, calibrationMismatchPreferServo, calibrationMismatchFail:
+	default:
+		return nil, nil, fmt.Errorf("calibration_mismatch must be one of \"prefer_file\", \"prefer_servo\" or \"fail\", got %q", cfg.CalibrationMismatch)
+	}
+
+	switch cfg.ShutdownBehavior {
+	case "", shutdownHold, shutdownLimp, shutdownPark:
+	default:
+		return nil, nil, fmt.Errorf("shutdown_behavior must be one of \"hold\", \"limp\" or \"park\", got %q", cfg.ShutdownBehavior)
+	}
+
+	if err := validateIKOptions(cfg.IKSolver, cfg.IKElbow, cfg.IKOrientationToleranceDegs); err != nil {
+		return nil, nil, err
+	}
+	if len(cfg.IKSeedDegs) != 0 && len(cfg.IKSeedDegs) != len(cfg.ServoIDs) {
+		return nil, nil, fmt.Errorf("ik_seed_degs must have %d joint positions, got %d", len(cfg.ServoIDs), len(cfg.IKSeedDegs))
+	}
+
+	for joint, limit := range cfg.JointLimits {
+		if !configHasJoint(cfg.ServoIDs, joint) {
+			return nil, nil, fmt.Errorf("joint_limits has unknown joint %q", joint)
+		}
+		if limit.MinDegs >= limit.MaxDegs {
+			return nil, nil, fmt.Errorf("joint_limits for %s must have min_degs below max_degs, got [%.1f, %.1f]", joint, limit.MinDegs, limit.MaxDegs)
+		}
+	}
+
+	for joint, percent := range cfg.MaxTorquePercent {
+		if !configHasJoint(cfg.ServoIDs, joint) {
+			return nil, nil, fmt.Errorf("max_torque_percent has unknown joint %q", joint)
+		}
+		if percent <= 0 || percent > 100 {
+			return nil, nil, fmt.Errorf("max_torque_percent for %s must be in (0, 100], got %.1f", joint, percent)
+		}
+	}
+
+	if cfg.BlendRadiusDegs < 0 {
+		return nil, nil, fmt.Errorf("blend_radius_degs must not be negative, got %.1f", cfg.BlendRadiusDegs)
+	}
+	if cfg.WaypointEpsilonDegs < 0 {
+		return nil, nil, fmt.Errorf("waypoint_epsilon_degs must not be negative, got %.1f", cfg.WaypointEpsilonDegs)
+	}
+
+	if cfg.MaxTemperatureC < 0 || cfg.MaxTemperatureC > 70 {
+		return nil, nil, fmt.Errorf("max_temperature_c must be between 0 and 70, got %.1f", cfg.MaxTemperatureC)
+	}
+	if err := validateCalibrationProfiles(cfg.CalibrationProfilesDir, cfg.CalibrationProfile, cfg.CalibrationFile); err != nil {
+		return nil, nil, err
+	}
+	if cfg.RequireCalibrationFile && cfg.CalibrationFile == "" && cfg.CalibrationProfilesDir == "" {
+		return nil, nil, fmt.Errorf("require_calibration_file is set but no calibration_file is configured")
+	}
+	if cfg.LowVoltageWarningV < 0 {
+		return nil, nil, fmt.Errorf("low_voltage_warning_v must not be negative, got %.1f", cfg.LowVoltageWarningV)
+	}
+	if cfg.CollisionLoadPercent < 0 || cfg.CollisionLoadPercent > 100 {
+		return nil, nil, fmt.Errorf("collision_load_percent must be between 0 and 100, got %.1f", cfg.CollisionLoadPercent)
+	}
+	if cfg.CollisionSamples < 0 {
+		return nil, nil, fmt.Errorf("collision_samples must not be negative, got %d", cfg.CollisionSamples)
+	}
+	if cfg.CollisionTorquePercent < 0 || cfg.CollisionTorquePercent > 100 {
+		return nil, nil, fmt.Errorf("collision_torque_percent must be between 0 and 100, got %.1f", cfg.CollisionTorquePercent)
+	}
+	if cfg.DecalibrationThresholdDegs < 0 {
+		return nil, nil, fmt.Errorf("decalibration_threshold_degs must not be negative, got %.1f", cfg.DecalibrationThresholdDegs)
+	}
+	if cfg.DecalibrationMaxSpeedDegsPerSec < 0 {
+		return nil, nil, fmt.Errorf("decalibration_max_speed_degs_per_sec must not be negative, got %.1f", cfg.DecalibrationMaxSpeedDegsPerSec)
+	}
+	if cfg.DecalibrationMaxSpeedDegsPerSec > 0 && cfg.DecalibrationThresholdDegs == 0 {
+		return nil, nil, fmt.Errorf("decalibration_max_speed_degs_per_sec requires decalibration_threshold_degs")
+	}
+	if cfg.WatchdogMaxFailures < 0 {
+		return nil, nil, fmt.Errorf("watchdog_max_failures must not be negative, got %d", cfg.WatchdogMaxFailures)
+	}
+	if cfg.PositionPollHz < 0 || cfg.PositionPollHz > maxPositionPollHz {
+		return nil, nil, fmt.Errorf("position_poll_hz must be between 0 and %d, got %.1f", maxPositionPollHz, cfg.PositionPollHz)
+	}
+	switch cfg.ThermalAction {
+	case "", thermalActionReduce, thermalActionDisable:
+	default:
+		return nil, nil, fmt.Errorf("thermal_action must be \"reduce\" or \"disable\", got %q", cfg.ThermalAction)
+	}
+
+	switch cfg.IsMovingSource {
+	case "", isMovingSourceCommand, isMovingSourceHardware:
+	default:
+		return nil, nil, fmt.Errorf("is_moving_source must be \"command\" or \"hardware\", got %q", cfg.IsMovingSource)
+	}
+
+	if cfg.FollowRateHz < 0 || cfg.FollowRateHz > followMaxRateHz {
+		return nil, nil, fmt.Errorf("follow_rate_hz must be between 0 and %.0f, got %.1f", followMaxRateHz, cfg.FollowRateHz)
+	}
+	if cfg.FollowMaxSpeedDegsPerSec != 0 && (cfg.FollowMaxSpeedDegsPerSec < minSpeedDegsPerSec || cfg.FollowMaxSpeedDegsPerSec > maxSpeedDegsPerSec) {
+		return nil, nil, fmt.Errorf("follow_max_speed_degs_per_sec must be between 3 and 180 degrees/second, got %.1f", cfg.FollowMaxSpeedDegsPerSec)
+	}
+
 	deps := []string{}
 
 	if cfg.Motion != "" {
@@ -80,15 +350,78 @@ func (cfg *SO101ArmConfig) Validate(path string) ([]string, []string, error) {
 		// use builtin motion service
 		deps = append(deps, motion.Named("builtin").String())
 	}
+	if cfg.EstopSwitch != "" {
+		deps = append(deps, toggleswitch.Named(cfg.EstopSwitch).String())
+	}
+	if cfg.FollowSource != "" {
+		deps = append(deps, sensor.Named(cfg.FollowSource).String())
+	}
 
 	return deps, nil, nil
 }
 
-type so101 struct {
-	resource.AlwaysRebuild
+// torqueRamp is how long enabling torque ramps the torque limit up
+func (s *so101) torqueRamp() time.Duration {
+	return time.Duration(s.config().TorqueRampMs) * time.Millisecond
+}
+
+// checkCalibrationRequired returns ErrCalibrationRequired if motion must wait for a calibration file
+func (s *so101) checkCalibrationRequired() error {
+	if s.config().RequireCalibrationFile && !s.calibrationLoaded.Load() {
+		return ErrCalibrationRequired
+	}
+	return nil
+}
+
+// configHasJoint reports whether the named joint is one of the configured servos
+func configHasJoint(servoIDs []int, joint string) bool {
+	for _, id := range servoIDs {
+		if jointNameForServo(id) == joint {
+			return true
+		}
+	}
+	return false
+}
+
+// Policies for recovering after a move is cancelled
+const (
+	onCancelHold    = "hold"
+	onCancelRetreat = "retreat_to_last_waypoint"
+	onCancelPark    = "park"
+)
+
+// Sources for IsMoving
+const (
+	isMovingSourceCommand  = "command"
+	isMovingSourceHardware = "hardware"
+)
+
+// With torque off the Moving flag stays clear, so hardware IsMoving also compares against the
+// previous sample. Samples older than isMovingSampleMaxAge are too stale to compare.
+const (
+	isMovingThresholdDegs = 0.5
+	isMovingSampleMaxAge  = time.Second
+)
+
+// Move completion polling
+const (
+	moveCompletionPollInterval  = 20 * time.Millisecond
+	moveCompletionToleranceDegs = 1.0
+	moveCompletionStoppedPolls  = 3
+	moveCompletionGrace         = time.Second
+)
+
+// Recovery moves run slowly since the arm may be in an awkward pose
+const (
+	cancelRecoverySpeedDegsPerSec = 15
+	cancelRecoveryTimeout         = 30 * time.Second
+)
 
+type so101 struct {
 	name       resource.Name
 	logger     logging.Logger
+	logs       *rateLimitedLogger
+	events     *eventLog
 	cfg        *SO101ArmConfig
 	opMgr      *operation.SingleOperationManager
 	controller *SafeSoArmController
@@ -106,9 +439,64 @@ type so101 struct {
 
 	motion motion.Service
 
+	usage *dutyCycleTracker
+
+	// Joints switched into velocity (wheel) mode, they ignore position moves
+	velocityServos map[int]bool
+
+	// Active compliance (hand teaching) mode, nil when off
+	compliance *complianceState
+
+	thermal *thermalMonitor
+
+	watchdog *commWatchdog
+
+	// Load spike detection during moves, nil when collision_load_percent is unset
+	collision *collisionDetector
+
+	// Stopped-short detection after moves, nil when decalibration_threshold_degs is unset
+	decalibration *decalibrationDetector
+
+	maintenance *maintenanceMode
+
+	// Follow mode, followSource is nil without follow_source
+	follow       *followState
+	followSource sensor.Sensor
+
+	// Rest pose computed from the model, used when park_pose isn't configured
+	restPoseOnce sync.Once
+	restPose     []float64
+	restPoseErr  error
+
+	// Last good joint positions, only set with degraded_reads
+	jointCache *jointReadCache
+
+	// Whether the calibration in use came from calibration_file
+	calibrationLoaded atomic.Bool
+
+	// Differences between the calibration file and the servo registers found at startup
+	calibrationMismatches []interface{}
+
+	waypointFilter *waypointFilterStats
+
+	// Poses from poses and poses_file, with the programs from poses_file
+	posesMu  sync.RWMutex
+	poses    map[string]namedPose
+	programs map[string][]ProgramStep
+
+	// Joint tracking measured during the last execute_trajectory
+	lastTrajectoryStats atomic.Pointer[trajectoryStats]
+
+	// Incremented by Stop so long-running playback can notice it
+	stopCount atomic.Int64
+
+	// Last joint positions read by hardware IsMoving
+	movingSampleMu   sync.Mutex
+	movingSample     []float64
+	movingSampleTime time.Time
+
 	cancelCtx  context.Context
 	cancelFunc func()
-	initCtx    context.Context // Context for initialization operations
 }
 
 func makeSO101ModelFrame() (referenceframe.Model, error) {
@@ -126,46 +514,148 @@ func makeSO101ModelFrame() (referenceframe.Model, error) {
 	return m.ParseConfig("soarm_101")
 }
 
+// solveLocalIK resolves a pose to joint positions with the arm's own IK solver, options
+// in extra override the config
+func (s *so101) solveLocalIK(ctx context.Context, pose spatialmath.Pose, extra map[string]interface{}) ([]referenceframe.Input, error) {
+	servoIDs := s.servoIDs()
+	cfg := s.config()
+	opts := ikOptions{
+		OrientationToleranceDegs: cfg.IKOrientationToleranceDegs,
+		Elbow:                    cfg.IKElbow,
+		ElbowIndex:               -1,
+	}
+	if v, ok := extra["ik_orientation_tolerance_degs"].(float64); ok {
+		opts.OrientationToleranceDegs = v
+	}
+	if v, ok := extra["ik_elbow"].(string); ok {
+		opts.Elbow = v
+	}
+	if err := validateIKOptions(ikSolverLocal, opts.Elbow, opts.OrientationToleranceDegs); err != nil {
+		return nil, err
+	}
+
+	for i, id := range servoIDs {
+		if id == 3 {
+			opts.ElbowIndex = i
+		}
+	}
+
+	seedDegs := s.config().IKSeedDegs
+	if raw, ok := extra["ik_seed_degs"]; ok {
+		var err error
+		if seedDegs, err = floatList(raw, "ik_seed_degs"); err != nil {
+			return nil, err
+		}
+	}
+	if len(seedDegs) != 0 {
+		opts.Seed = make([]float64, len(seedDegs))
+		for i, deg := range seedDegs {
+			opts.Seed[i] = DegreesToRadians(deg)
+		}
+	} else {
+		current, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read joint positions for IK seed: %w", err)
+		}
+		opts.Seed = current
+	}
+
+	if len(s.model.DoF()) != len(servoIDs) {
+		return nil, fmt.Errorf("local IK needs all %d arm joints, arm controls %d", len(s.model.DoF()), len(servoIDs))
+	}
+
+	joints, err := solveIK(s.model, s.calculateJointLimits(), pose, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve IK: %w", err)
+	}
+	return joints, nil
+}
+
+// validateIKOptions checks the IK solver settings shared by config and extra
+func validateIKOptions(solver, elbow string, orientationToleranceDegs float64) error {
+	switch solver {
+	case "", ikSolverMotion, ikSolverLocal:
+	default:
+		return fmt.Errorf("ik_solver must be %q or %q, got %q", ikSolverMotion, ikSolverLocal, solver)
+	}
+	switch elbow {
+	case "", ikElbowPositive, ikElbowNegative:
+	default:
+		return fmt.Errorf("ik_elbow must be %q or %q, got %q", ikElbowPositive, ikElbowNegative, elbow)
+	}
+	if orientationToleranceDegs < 0 {
+		return fmt.Errorf("ik_orientation_tolerance_degs must not be negative")
+	}
+	return nil
+}
+
 // calculateJointLimits dynamically calculates joint limits from calibration data
 func (s *so101) calculateJointLimits() [][2]float64 {
-	limits := make([][2]float64, len(s.armServoIDs))
+	limits := s.calibratedJointLimits()
+	overrides := s.config().JointLimits
 
-	calibration := s.controller.GetCalibration()
-
-	// Map servo IDs to calibration data
-	jointCals := []*MotorCalibration{
-		calibration.ShoulderPan,
-		calibration.ShoulderLift,
-		calibration.ElbowFlex,
-		calibration.WristFlex,
-		calibration.WristRoll,
+	// joint_limits can only narrow the calibrated range
+	for i, servoID := range s.servoIDs() {
+		override, ok := overrides[jointNameForServo(servoID)]
+		if !ok {
+			continue
+		}
+		limits[i][0] = math.Max(limits[i][0], DegreesToRadians(override.MinDegs))
+		limits[i][1] = math.Min(limits[i][1], DegreesToRadians(override.MaxDegs))
 	}
 
-	for i, cal := range jointCals {
+	return limits
+}
+
+// calibratedJointLimits returns each arm joint's calibrated range in radians
+func (s *so101) calibratedJointLimits() [][2]float64 {
+	return calibratedJointLimitsFor(s.controller.GetCalibration(), s.servoIDs())
+}
+
+func calibratedJointLimitsFor(calibration SO101FullCalibration, servoIDs []int) [][2]float64 {
+	limits := make([][2]float64, len(servoIDs))
+
+	for i, servoID := range servoIDs {
+		cal := calibration.GetMotorCalibrationByID(servoID)
 		if cal == nil {
 			// Use default limits if calibration is missing
 			limits[i] = [2]float64{-math.Pi, math.Pi}
 			continue
 		}
 
-		// Convert calibration range to radians using the same logic as before
-		center := float64(cal.RangeMin+cal.RangeMax) / 2
-		halfRange := float64(cal.RangeMax-cal.RangeMin) / 2
-
-		// Calculate min limit (RangeMin -> radians)
-		minNormalized := (float64(cal.RangeMin) - center) / halfRange
-		minRadians := minNormalized * math.Pi
+		// The calibrated range is centered on zero, so the limits are half its span either way.
+		// Earlier versions mapped every range onto ±π, so a joint calibrated over 180° reported
+		// limits of ±180° while the servo could only reach ±90°.
+		halfRangeRadians := DegreesToRadians(StepsToDegrees(float64(cal.RangeMax-cal.RangeMin) / 2))
 
-		// Calculate max limit (RangeMax -> radians)
-		maxNormalized := (float64(cal.RangeMax) - center) / halfRange
-		maxRadians := maxNormalized * math.Pi
-
-		limits[i] = [2]float64{minRadians, maxRadians}
+		limits[i] = [2]float64{-halfRangeRadians, halfRangeRadians}
 	}
 
 	return limits
 }
 
+// checkJointLimits verifies the joint_limits overrides lie inside the calibrated range
+func (s *so101) checkJointLimits() error {
+	return checkJointLimits(s.config().JointLimits, s.controller.GetCalibration(), s.servoIDs())
+}
+
+func checkJointLimits(jointLimits map[string]JointLimit, calibration SO101FullCalibration, servoIDs []int) error {
+	calibrated := calibratedJointLimitsFor(calibration, servoIDs)
+	for i, servoID := range servoIDs {
+		joint := jointNameForServo(servoID)
+		override, ok := jointLimits[joint]
+		if !ok {
+			continue
+		}
+		minDegs, maxDegs := RadiansToDegrees(calibrated[i][0]), RadiansToDegrees(calibrated[i][1])
+		if override.MinDegs < minDegs || override.MaxDegs > maxDegs {
+			return fmt.Errorf("joint_limits for %s [%.1f, %.1f] must be inside the calibrated range [%.1f, %.1f]",
+				joint, override.MinDegs, override.MaxDegs, minDegs, maxDegs)
+		}
+	}
+	return nil
+}
+
 func newso101(ctx context.Context, deps resource.Dependencies, rawConf resource.Config, logger logging.Logger) (arm.Arm, error) {
 	newConf, err := resource.NativeConfig[*SO101ArmConfig](rawConf)
 	if err != nil {
@@ -174,22 +664,23 @@ func newso101(ctx context.Context, deps resource.Dependencies, rawConf resource.
 	return NewSO101(ctx, deps, rawConf.ResourceName(), newConf, logger)
 }
 
-func NewSO101(ctx context.Context, deps resource.Dependencies, name resource.Name, conf *SO101ArmConfig, logger logging.Logger) (arm.Arm, error) {
-	// Validate and set default motion parameters
+// applyArmDefaults fills in the defaults for unset attributes and returns the default speed
+// and acceleration
+func applyArmDefaults(conf *SO101ArmConfig) (float32, float32, error) {
 	speedDegsPerSec := conf.SpeedDegsPerSec
 	if speedDegsPerSec == 0 {
 		speedDegsPerSec = 50 // Default speed in degrees per second
 	}
-	if speedDegsPerSec < 3 || speedDegsPerSec > 180 {
-		return nil, fmt.Errorf("speed_degs_per_sec must be between 3 and 180 degrees/second, got %.1f", speedDegsPerSec)
+	if speedDegsPerSec < minSpeedDegsPerSec || speedDegsPerSec > maxSpeedDegsPerSec {
+		return 0, 0, fmt.Errorf("speed_degs_per_sec must be between 3 and 180 degrees/second, got %.1f", speedDegsPerSec)
 	}
 
 	accelerationDegsPerSec := conf.AccelerationDegsPerSec
 	if accelerationDegsPerSec == 0 {
 		accelerationDegsPerSec = 100 // Default acceleration in degrees per second^2
 	}
-	if accelerationDegsPerSec < 10 || accelerationDegsPerSec > 500 {
-		return nil, fmt.Errorf("acceleration_degs_per_sec_per_sec must be between 10 and 500 degrees/second^2, got %.1f", accelerationDegsPerSec)
+	if accelerationDegsPerSec < minAccDegsPerSecPerSec || accelerationDegsPerSec > maxAccDegsPerSecPerSec {
+		return 0, 0, fmt.Errorf("acceleration_degs_per_sec_per_sec must be between 10 and 500 degrees/second^2, got %.1f", accelerationDegsPerSec)
 	}
 
 	if conf.Baudrate == 0 {
@@ -199,17 +690,38 @@ func NewSO101(ctx context.Context, deps resource.Dependencies, name resource.Nam
 	if len(conf.ServoIDs) == 0 {
 		conf.ServoIDs = []int{1, 2, 3, 4, 5}
 	}
+	applyCalibrationProfile(conf)
+	if conf.CalibrationFile == "" {
+		conf.CalibrationFile = portCalibrationFile(conf.Port)
+	}
+	return speedDegsPerSec, accelerationDegsPerSec, nil
+}
 
-	// Create controller configuration
-	controllerConfig := &SoArm101Config{
+// armControllerConfig returns the shared controller configuration for an arm
+func armControllerConfig(conf *SO101ArmConfig, logger logging.Logger) *SoArm101Config {
+	return &SoArm101Config{
 		Port:            conf.Port,
 		Baudrate:        conf.Baudrate,
 		ServoIDs:        []int{1, 2, 3, 4, 5, 6}, // Controller handles all 6, but arm only uses 1-5
 		Timeout:         conf.Timeout,
+		Protocol:        conf.Protocol,
+		SCSServoIDs:     conf.SCSServoIDs,
+		Retry:           conf.Retry,
+		SecondaryPort:   conf.SecondaryPort,
 		CalibrationFile: conf.CalibrationFile,
 		Logger:          logger,
+
+		FailoverAfterFailures: conf.FailoverAfterFailures,
+	}
+}
+
+func NewSO101(ctx context.Context, deps resource.Dependencies, name resource.Name, conf *SO101ArmConfig, logger logging.Logger) (arm.Arm, error) {
+	speedDegsPerSec, accelerationDegsPerSec, err := applyArmDefaults(conf)
+	if err != nil {
+		return nil, err
 	}
 
+	controllerConfig := armControllerConfig(conf, logger)
 	controllerConfig.Validate(conf.CalibrationFile)
 
 	// Load full calibration (includes gripper for shared controller)
@@ -224,10 +736,21 @@ func NewSO101(ctx context.Context, deps resource.Dependencies, name resource.Nam
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shared SO-ARM controller: %w", err)
 	}
+	RegisterSharedConsumer(conf.Port, ConsumerInfo{
+		Name:            name.ShortName(),
+		CalibrationFile: controllerConfig.CalibrationFile,
+		ServoIDs:        conf.ServoIDs,
+	})
+
+	if conf.WatchCalibrationFile && fromFile {
+		if err := WatchSharedCalibrationFile(conf.Port, controllerConfig.CalibrationFile, logger); err != nil {
+			logger.Warnf("Calibration file will not be reloaded automatically: %v", err)
+		}
+	}
 
 	model, err := makeSO101ModelFrame()
 	if err != nil {
-		ReleaseSharedController() // Clean up on error
+		ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
 		return nil, fmt.Errorf("failed to create kinematic model: %w", err)
 	}
 
@@ -250,31 +773,97 @@ func NewSO101(ctx context.Context, deps resource.Dependencies, name resource.Nam
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 
 	arm := &so101{
-		name:         name,
-		cfg:          conf,
-		opMgr:        operation.NewSingleOperationManager(),
-		logger:       logger,
-		controller:   controller,
-		model:        model,
-		armServoIDs:  conf.ServoIDs, // Store which servos this arm controls
-		defaultSpeed: speedDegsPerSec,
-		defaultAcc:   accelerationDegsPerSec,
-		motion:       ms,
-		cancelCtx:    cancelCtx,
-		cancelFunc:   cancelFunc,
-		initCtx:      ctx, // Store initialization context
+		name:           name,
+		cfg:            conf,
+		opMgr:          operation.NewSingleOperationManager(),
+		logger:         logger,
+		controller:     controller,
+		model:          model,
+		armServoIDs:    conf.ServoIDs, // Store which servos this arm controls
+		defaultSpeed:   speedDegsPerSec,
+		defaultAcc:     accelerationDegsPerSec,
+		motion:         ms,
+		usage:          newDutyCycleTracker(usageFilePath(conf.Port), conf.MaintenanceTravelDegs, conf.MaintenanceTorqueHours, logger),
+		velocityServos: map[int]bool{},
+		thermal:        newThermalMonitor(conf.MaxTemperatureC, conf.ThermalAction),
+		watchdog:       newCommWatchdog(conf.WatchdogMaxFailures),
+		maintenance:    controller.maintenance,
+		follow:         &followState{},
+		waypointFilter: &waypointFilterStats{},
+		collision:      newCollisionDetector(conf.CollisionLoadPercent, conf.CollisionSamples, conf.CollisionTorquePercent),
+		decalibration:  newDecalibrationDetector(conf.DecalibrationThresholdDegs, conf.DecalibrationMaxSpeedDegsPerSec),
+		logs:           newRateLimitedLogger(logger, logRateLimitInterval(conf.VerboseLogging)),
+		cancelCtx:      cancelCtx,
+		cancelFunc:     cancelFunc,
+	}
+
+	arm.usage.summary = conf.UsageSummary
+	arm.usage.trackTorque(controller.torque)
+	arm.events = &eventLog{}
+	arm.logs.events = arm.events
+	if conf.DegradedReads {
+		arm.jointCache = newJointReadCache()
+	}
+	arm.calibrationLoaded.Store(fromFile)
+	if conf.RequireCalibrationFile && !fromFile {
+		logger.Errorf("require_calibration_file is set but %s could not be loaded, motion commands will be refused", controllerConfig.CalibrationFile)
 	}
 
 	logger.Debugf("SO-101 configured with speed: %.1f deg/s, acceleration: %.1f deg/s²",
 		speedDegsPerSec, accelerationDegsPerSec)
 	logger.Debugf("Arm controlling servo IDs: %v", arm.armServoIDs)
 
+	if err := arm.checkJointLimits(); err != nil {
+		ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
+		return nil, err
+	}
+
+	if err := arm.loadPoses(); err != nil {
+		ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
+		return nil, err
+	}
+
+	var estopSwitch toggleswitch.Switch
+	if conf.EstopSwitch != "" {
+		estopSwitch, err = toggleswitch.FromProvider(deps, conf.EstopSwitch)
+		if err != nil {
+			ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
+			return nil, fmt.Errorf("failed to get estop_switch %q: %w", conf.EstopSwitch, err)
+		}
+	}
+
+	if conf.FollowSource != "" {
+		arm.followSource, err = sensor.FromProvider(deps, conf.FollowSource)
+		if err != nil {
+			ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
+			return nil, fmt.Errorf("failed to get follow_source %q: %w", conf.FollowSource, err)
+		}
+	}
+
 	// Initialize and verify servo connections
 	if err := arm.initializeServos(); err != nil {
-		ReleaseSharedController() // Clean up on error
+		ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
 		return nil, fmt.Errorf("failed to initialize servos: %w", err)
 	}
 
+	if conf.CalibrationMismatch != "" && fromFile {
+		if err := arm.checkCalibrationReadback(ctx); err != nil {
+			ReleaseSharedControllerForPort(conf.Port, controller) // Clean up on error
+			return nil, err
+		}
+	}
+
+	if conf.PositionPollHz > 0 {
+		controller.StartPositionPolling(conf.PositionPollHz, arm.armServoIDs)
+	}
+	controller.OnReconnect(name.ShortName(), arm.restoreAfterReconnect)
+
+	go arm.monitorTemperatures(cancelCtx)
+	go arm.watchCommunication(cancelCtx)
+	if estopSwitch != nil {
+		go watchEstopSwitch(cancelCtx, estopSwitch, controller, logger)
+	}
+
 	return arm, nil
 }
 
@@ -311,7 +900,31 @@ func (s *so101) EndPosition(ctx context.Context, extra map[string]interface{}) (
 // default the planner's goal metric to "position_only" so the solver matches the target point
 // and accepts whatever orientation falls out. Callers who want different planner behavior may
 // override any key by passing their own value via extra.
+//
+// With ik_solver "local" the arm instead solves IK itself and moves straight to the solution,
+// using ik_seed_degs, ik_orientation_tolerance_degs and ik_elbow from the config or extra.
 func (s *so101) MoveToPosition(ctx context.Context, pose spatialmath.Pose, extra map[string]interface{}) error {
+	ctx, cancel, err := withRequestTimeout(ctx, extra)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	return requestTimeoutError(ctx, s.moveToPose(ctx, pose, extra))
+}
+
+func (s *so101) moveToPose(ctx context.Context, pose spatialmath.Pose, extra map[string]interface{}) error {
+	solver := s.config().IKSolver
+	if v, ok := extra["ik_solver"].(string); ok {
+		solver = v
+	}
+	if solver == ikSolverLocal {
+		joints, err := s.solveLocalIK(ctx, pose, extra)
+		if err != nil {
+			return err
+		}
+		return s.MoveToJointPositions(ctx, joints, extra)
+	}
+
 	planExtra := map[string]interface{}{"goal_metric_type": "position_only"}
 	for k, v := range extra {
 		planExtra[k] = v
@@ -328,43 +941,112 @@ func (s *so101) MoveToPosition(ctx context.Context, pose spatialmath.Pose, extra
 }
 
 func (s *so101) MoveToJointPositions(ctx context.Context, positions []referenceframe.Input, extra map[string]interface{}) error {
+	params, err := resolveMotionParams(s.defaultMotionParams(), nil, extra)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := withRequestTimeout(ctx, extra)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	err = s.moveWithParams(ctx, positions, params, "move_to_joint_positions")
+	return requestTimeoutError(ctx, err)
+}
+
+// moveWithParams runs a single joint move while holding the move lock, recovering with the
+// on_cancel policy if it's interrupted
+func (s *so101) moveWithParams(ctx context.Context, positions []referenceframe.Input, params motionParams, op string) error {
 	s.moveLock.Lock()
 	defer s.moveLock.Unlock()
 
 	s.isMoving.Store(true)
 	defer s.isMoving.Store(false)
 
-	if len(positions) != len(s.armServoIDs) {
-		return fmt.Errorf("expected %d joint positions for SO-101 arm, got %d", len(s.armServoIDs), len(positions))
+	start, err := s.moveToJointPositions(ctx, positions, params)
+	if err != nil && ctx.Err() != nil {
+		s.recoverFromCancel(start)
+	}
+	s.events.recordError(op, err)
+	s.usage.recordError(err)
+	return err
+}
+
+// moveToJointPositions commands a move and waits for it to complete, returning the
+// positions the arm started from. The caller must hold moveLock.
+func (s *so101) moveToJointPositions(ctx context.Context, positions []referenceframe.Input, params motionParams) ([]float64, error) {
+	target, start, expected, err := s.startMove(ctx, positions, params)
+	if err != nil {
+		return nil, err
 	}
+	return start, s.waitForMove(ctx, target, expected)
+}
 
-	values := make([]float64, len(positions))
-	copy(values, positions)
+// commandJointPositions sends the arm joints to positions in radians, clamped to the joint
+// limits, once the arm is allowed to move at speedDegsPerSec. It returns the clamped positions.
+// Every position command for the arm's joints goes through here.
+func (s *so101) commandJointPositions(ctx context.Context, positions []float64, speedDegsPerSec, accDegsPerSecPerSec float64) ([]float64, error) {
+	servoIDs := s.servoIDs()
+	if err := s.checkMotionAllowed(); err != nil {
+		return nil, err
+	}
+	if err := s.decalibration.checkSpeed(speedDegsPerSec); err != nil {
+		return nil, err
+	}
+	if s.complianceEnabled() {
+		return nil, fmt.Errorf("compliance mode is enabled, disable it with compliance_mode before moving")
+	}
+	if ids := s.velocityModeServos(); len(ids) > 0 {
+		return nil, fmt.Errorf("servos %v are in velocity mode, disable it with set_velocity_mode before moving to positions", ids)
+	}
 
 	// Calculate joint limits dynamically from calibration
 	jointLimits := s.calculateJointLimits()
 
 	// Validate input ranges and clamp positions for the arm joints
-	clampedPositions := make([]float64, len(values))
-	for i, pos := range values {
+	clampedPositions := make([]float64, len(positions))
+	for i, pos := range positions {
 		min, max := jointLimits[i][0], jointLimits[i][1]
 
 		// Validate and clamp the position
 		if pos < min || pos > max {
-			s.logger.Warnf("Joint %d position %.3f rad (%.1f°) out of range [%.3f, %.3f] rad ([%.1f°, %.1f°]), clamping",
-				s.armServoIDs[i], pos, pos*180/math.Pi, min, max, min*180/math.Pi, max*180/math.Pi)
+			s.logs.Warnf(fmt.Sprintf("clamp-%d", servoIDs[i]), "Joint %d position %.3f rad (%.1f°) out of range [%.3f, %.3f] rad ([%.1f°, %.1f°]), clamping",
+				servoIDs[i], pos, RadiansToDegrees(pos), min, max, RadiansToDegrees(min), RadiansToDegrees(max))
 		}
 		clampedPositions[i] = math.Max(min, math.Min(max, pos))
 	}
 
-	if err := s.controller.MoveServosToPositions(ctx, s.armServoIDs, clampedPositions, 0, 0); err != nil {
-		return fmt.Errorf("failed to move SO-101 arm: %w", err)
+	speed := int(math.Round(speedDegsPerSec))
+	acc := int(math.Round(accDegsPerSecPerSec))
+	if err := s.controller.MoveServosToPositions(ctx, servoIDs, clampedPositions, speed, acc); err != nil {
+		return nil, fmt.Errorf("failed to move SO-101 arm: %w", err)
+	}
+	return clampedPositions, nil
+}
+
+// startMove clamps and commands a move without waiting for it. It returns the commanded
+// target, the positions the arm started from and the estimated move duration.
+func (s *so101) startMove(ctx context.Context, positions []referenceframe.Input, params motionParams) ([]float64, []float64, time.Duration, error) {
+	servoIDs := s.servoIDs()
+	if len(positions) != len(servoIDs) {
+		return nil, nil, 0, fmt.Errorf("expected %d joint positions for SO-101 arm, got %d", len(servoIDs), len(positions))
+	}
+
+	clampedPositions, err := s.commandJointPositions(ctx, positions, params.SpeedDegsPerSec, params.AccDegsPerSecPerSec)
+	if err != nil {
+		return nil, nil, 0, err
 	}
+	s.usage.recordMove()
 
-	currentPositions, err := s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+	var start []float64
+	currentPositions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
 	if err != nil {
-		s.logger.Warnf("Failed to get current positions for timing calculation: %v", err)
-		currentPositions = make([]float64, len(s.armServoIDs)) // Use zeros as fallback
+		s.logs.Warnf("timing-positions", "Failed to get current positions for timing calculation: %v", err)
+		currentPositions = make([]float64, len(servoIDs)) // Use zeros as fallback
+	} else {
+		start = currentPositions
+		s.usage.addTravel(servoIDs, currentPositions, clampedPositions)
 	}
 
 	maxMovement := 0.0
@@ -377,40 +1059,270 @@ func (s *so101) MoveToJointPositions(ctx context.Context, positions []referencef
 		}
 	}
 
-	speedRadPerSec := float64(s.defaultSpeed) * math.Pi / 180.0
-	moveTimeSeconds := maxMovement / speedRadPerSec
-	if moveTimeSeconds < 0.1 {
-		moveTimeSeconds = 0.1 // Minimum move time
-	}
-	if moveTimeSeconds > 10.0 {
-		moveTimeSeconds = 10.0 // Maximum move time for safety
+	expected := time.Duration(maxMovement / DegreesToRadians(params.SpeedDegsPerSec) * float64(time.Second))
+
+	return clampedPositions, start, expected, nil
+}
+
+// waitForMove polls the servos until they reach target or stop moving. expected is the
+// estimated move duration, used to bound how long to wait.
+func (s *so101) waitForMove(ctx context.Context, target []float64, expected time.Duration) error {
+	return s.waitWithin(ctx, target, DegreesToRadians(moveCompletionToleranceDegs), expected)
+}
+
+// moveCompletionTimeout is how long to wait for a move of the expected duration, scaled from
+// it so slow or long moves aren't cut short
+func moveCompletionTimeout(expected time.Duration) time.Duration {
+	return 2*expected + moveCompletionGrace
+}
+
+// waitWithin polls the servos until every joint is within tolerance radians of target or
+// the servos stop moving
+func (s *so101) waitWithin(ctx context.Context, target []float64, tolerance float64, expected time.Duration) error {
+	servoIDs := s.servoIDs()
+	timeout := moveCompletionTimeout(expected)
+	deadline := time.Now().Add(timeout)
+
+	ticker := time.NewTicker(moveCompletionPollInterval)
+	defer ticker.Stop()
+
+	if s.collision != nil {
+		s.collision.reset()
 	}
 
-	time.Sleep(time.Duration(moveTimeSeconds * float64(time.Second)))
+	stoppedPolls := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 
-	return nil
+		if s.collision != nil {
+			if err := s.checkCollision(ctx); err != nil {
+				return err
+			}
+		}
+
+		positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+		if err == nil && withinTolerance(positions, target, tolerance) {
+			return nil
+		}
+
+		// Servos that stop short (blocked or sagging under load) never reach tolerance, so
+		// also finish once the Moving flags have stayed clear for a few polls
+		moving, err := s.controller.ServosMoving(ctx, servoIDs)
+		if err == nil && !moving {
+			stoppedPolls++
+			if stoppedPolls >= moveCompletionStoppedPolls {
+				s.logger.Debugf("Servos stopped before reaching target, current positions: %v", positions)
+				s.checkDecalibration(positions, target)
+				return nil
+			}
+		} else {
+			stoppedPolls = 0
+		}
+
+		// A servo hunting around its goal keeps its Moving flag set, so the timeout always applies
+		if time.Now().After(deadline) {
+			return fmt.Errorf("move did not complete within %v", timeout)
+		}
+	}
+}
+
+func withinTolerance(positions, target []float64, tolerance float64) bool {
+	if len(positions) != len(target) {
+		return false
+	}
+	for i := range target {
+		if math.Abs(positions[i]-target[i]) > tolerance {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *so101) MoveThroughJointPositions(ctx context.Context, positions [][]referenceframe.Input, options *arm.MoveOptions, extra map[string]interface{}) error {
+	ctx, cancel, err := withRequestTimeout(ctx, extra)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	return requestTimeoutError(ctx, s.moveThroughJointPositions(ctx, positions, options, extra))
+}
+
+func (s *so101) moveThroughJointPositions(ctx context.Context, positions [][]referenceframe.Input, options *arm.MoveOptions, extra map[string]interface{}) error {
+	params, err := resolveMotionParams(s.defaultMotionParams(), options, extra)
+	if err != nil {
+		return err
+	}
+
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	s.isMoving.Store(true)
+	defer s.isMoving.Store(false)
+
+	epsilon := s.config().WaypointEpsilonDegs
+	if v, ok := extra["waypoint_epsilon_degs"].(float64); ok {
+		if v < 0 {
+			return fmt.Errorf("waypoint_epsilon_degs must not be negative, got %.1f", v)
+		}
+		epsilon = v
+	}
+	received := len(positions)
+	positions, pruned := dedupWaypoints(positions, DegreesToRadians(epsilon))
+	s.waypointFilter.record(received, pruned)
+	if pruned > 0 {
+		s.logger.Debugf("Pruned %d of %d waypoints within %.2f degrees", pruned, received, epsilon)
+	}
+
+	blendRadius := s.config().BlendRadiusDegs
+	if v, ok := extra["blend_radius_degs"].(float64); ok {
+		blendRadius = v
+	}
+	if blendRadius > 0 {
+		return s.moveThroughBlended(ctx, positions, params, DegreesToRadians(blendRadius))
+	}
+
+	var lastWaypoint []float64
 	for _, jointPositions := range positions {
-		if err := s.MoveToJointPositions(ctx, jointPositions, extra); err != nil {
+		start, err := s.moveToJointPositions(ctx, jointPositions, params)
+		if lastWaypoint == nil {
+			lastWaypoint = start
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				s.recoverFromCancel(lastWaypoint)
+			}
+			s.events.recordError("move_through_joint_positions", err)
+			s.usage.recordError(err)
 			return err
 		}
+		lastWaypoint = jointPositions
 
 		if ctx.Err() != nil {
+			s.recoverFromCancel(lastWaypoint)
 			return ctx.Err()
 		}
 	}
 	return nil
 }
 
+// moveThroughBlended runs a trajectory without stopping at intermediate waypoints. Each
+// waypoint is only approached to within blendRadius before the next is commanded, so the
+// servos carry their velocity into the next segment. The caller must hold moveLock.
+func (s *so101) moveThroughBlended(ctx context.Context, positions [][]referenceframe.Input, params motionParams, blendRadius float64) error {
+	var lastWaypoint []float64
+	for i, jointPositions := range positions {
+		target, start, expected, err := s.startMove(ctx, jointPositions, params)
+		if lastWaypoint == nil {
+			lastWaypoint = start
+		}
+		if err == nil {
+			if i == len(positions)-1 {
+				err = s.waitForMove(ctx, target, expected)
+			} else {
+				err = s.waitWithin(ctx, target, blendRadius, expected)
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				s.recoverFromCancel(lastWaypoint)
+			}
+			s.events.recordError("move_through_joint_positions", err)
+			s.usage.recordError(err)
+			return err
+		}
+		lastWaypoint = target
+	}
+	return nil
+}
+
+// defaultMotionParams returns the configured speed and acceleration, as changed by set_speed and set_acceleration
+func (s *so101) defaultMotionParams() motionParams {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	factor := s.thermal.speedFactor()
+	return motionParams{SpeedDegsPerSec: float64(s.defaultSpeed) * factor, AccDegsPerSecPerSec: float64(s.defaultAcc) * factor}
+}
+
+// recoverFromCancel applies the on_cancel policy after a move was cancelled. lastWaypoint is
+// the last position the arm fully reached, nil if unknown. The caller must hold moveLock.
+func (s *so101) recoverFromCancel(lastWaypoint []float64) {
+	servoIDs := s.servoIDs()
+	// The move's context is already done, recover on our own
+	ctx, cancel := context.WithTimeout(s.cancelCtx, cancelRecoveryTimeout)
+	defer cancel()
+
+	current, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+	if err != nil {
+		s.logger.Warnf("Move cancelled but failed to read joint positions for recovery: %v", err)
+		return
+	}
+
+	policy := s.config().OnCancel
+	if policy == "" {
+		policy = onCancelHold
+	}
+
+	target := append([]float64(nil), current...)
+	switch policy {
+	case onCancelRetreat:
+		if lastWaypoint == nil {
+			s.logger.Warn("Move cancelled before any waypoint was reached, holding position instead")
+			policy = onCancelHold
+		} else {
+			target = append([]float64(nil), lastWaypoint...)
+		}
+	case onCancelPark:
+		parkPose, err := s.parkPoseRadians()
+		if err != nil {
+			s.logger.Warnf("Failed to compute park pose, holding position instead: %v", err)
+			policy = onCancelHold
+		} else {
+			target = append([]float64(nil), parkPose...)
+		}
+	}
+
+	jointLimits := s.calculateJointLimits()
+	for i := range target {
+		target[i] = math.Max(jointLimits[i][0], math.Min(jointLimits[i][1], target[i]))
+	}
+
+	s.logger.Infof("Move cancelled, applying on_cancel policy %q", policy)
+	if err := s.controller.MoveServosToPositions(ctx, servoIDs, target, cancelRecoverySpeedDegsPerSec, 0); err != nil {
+		s.logger.Warnf("Failed to apply on_cancel policy %q: %v", policy, err)
+		return
+	}
+	if policy == onCancelHold {
+		return
+	}
+
+	maxMovement := 0.0
+	for i := range target {
+		maxMovement = math.Max(maxMovement, math.Abs(target[i]-current[i]))
+	}
+	expected := time.Duration(RadiansToDegrees(maxMovement) / cancelRecoverySpeedDegsPerSec * float64(time.Second))
+
+	if err := s.waitForMove(ctx, target, expected); err != nil {
+		s.logger.Warnf("on_cancel policy %q did not finish: %v", policy, err)
+	}
+}
+
 func (s *so101) JointPositions(ctx context.Context, extra map[string]interface{}) ([]referenceframe.Input, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	radians, err := s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+	var radians []float64
+	var err error
+	if s.jointCache != nil {
+		radians, err = s.readJointPositionsDegraded(ctx)
+	} else {
+		radians, err = s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+	}
 	if err != nil {
-		s.logger.Warnf("Failed to read joint positions: %v", err)
+		s.logs.Warnf("read-positions", "Failed to read joint positions: %v", err)
 		return nil, fmt.Errorf("failed to read joint positions: %w. Try running 'diagnose' command for more details", err)
 	}
 
@@ -425,8 +1337,12 @@ func (s *so101) JointPositions(ctx context.Context, extra map[string]interface{}
 }
 
 func (s *so101) Stop(ctx context.Context, extra map[string]interface{}) error {
+	s.stopCount.Add(1)
 	s.isMoving.Store(false)
-	return s.controller.Stop(ctx)
+	if err := s.stopVelocityServos(ctx); err != nil {
+		return err
+	}
+	return s.controller.StopServos(ctx, s.servoIDs())
 }
 
 func (s *so101) Kinematics(ctx context.Context) (referenceframe.Model, error) {
@@ -446,6 +1362,16 @@ func (s *so101) Get3DModels(ctx context.Context, extra map[string]interface{}) (
 }
 
 func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	ctx, cancel, err := withRequestTimeout(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	result, err := s.doCommand(ctx, cmd)
+	return result, requestTimeoutError(ctx, err)
+}
+
+func (s *so101) doCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	// Handle custom commands specific to SO-101
 	switch cmd["command"] {
 	case "set_torque":
@@ -453,21 +1379,35 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 		if !ok {
 			return nil, fmt.Errorf("set_torque command requires 'enable' boolean parameter")
 		}
-		err := s.controller.SetTorqueEnable(ctx, enable)
+		if enable {
+			err := s.enableTorque(ctx)
+			return map[string]interface{}{"success": err == nil}, err
+		}
+		err := s.controller.SetTorqueEnable(ctx, false)
 		return map[string]interface{}{"success": err == nil}, err
 
+	case "get_torque":
+		servoIDs := s.servoIDs()
+		states, failed := s.controller.GetTorqueStates(ctx, servoIDs)
+		return torqueReport(servoIDs, states, failed), nil
+
 	case "ping":
-		err := s.controller.Ping(ctx)
+		err := s.controller.Ping(ctx, s.servoIDs())
 		return map[string]interface{}{"success": err == nil}, err
 
 	case "controller_status":
 		refCount, hasController, configSummary := GetControllerStatus()
-		return map[string]interface{}{
+		servoIDs := s.servoIDs()
+		status := map[string]interface{}{
 			"ref_count":      refCount,
 			"has_controller": hasController,
 			"config":         configSummary,
-			"arm_servo_ids":  s.armServoIDs,
-		}, nil
+			"arm_servo_ids":  servoIDs,
+		}
+		if s.controller != nil {
+			status["servo_versions"] = servoVersionsStatus(ctx, s.controller, servoIDs)
+		}
+		return status, nil
 
 	case "diagnose":
 		err := s.diagnoseConnection()
@@ -484,7 +1424,7 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 		}, nil
 
 	case "reinitialize":
-		retries := 3 // default
+		retries, _ := s.config().Retry.initRetry()
 		if r, ok := cmd["retries"].(float64); ok {
 			retries = int(r)
 		}
@@ -496,10 +1436,11 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 		}, nil
 
 	case "test_servo_communication":
-		positions, err := s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+		servoIDs := s.servoIDs()
+		positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
 		result := map[string]interface{}{
 			"success":       err == nil,
-			"arm_servo_ids": s.armServoIDs,
+			"arm_servo_ids": servoIDs,
 		}
 		if err != nil {
 			result["error"] = fmt.Sprintf("%v", err)
@@ -509,7 +1450,8 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 		return result, nil
 
 	case "reload_calibration":
-		if s.cfg.CalibrationFile == "" {
+		cfg := s.config()
+		if cfg.CalibrationFile == "" {
 			return map[string]interface{}{
 				"success": false,
 				"error":   "No calibration file configured",
@@ -517,7 +1459,7 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 		}
 
 		// Load the new calibration
-		newCalibration, err := LoadFullCalibrationFromFile(s.cfg.CalibrationFile, s.logger)
+		newCalibration, err := LoadFullCalibrationFromFile(cfg.CalibrationFile, s.logger)
 		if err != nil {
 			return map[string]interface{}{
 				"success": false,
@@ -525,21 +1467,200 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 			}, nil
 		}
 
-		// Update the controller with the new calibration
-		if err := s.controller.SetCalibration(newCalibration); err != nil {
+		// Update every component on this port with the new calibration
+		if err := ApplySharedCalibration(cfg.Port, newCalibration); err != nil {
 			return map[string]interface{}{
 				"success": false,
 				"error":   fmt.Sprintf("Failed to update calibration: %v", err),
 			}, nil
 		}
 
-		s.logger.Debugf("Successfully reloaded calibration from %s", s.cfg.CalibrationFile)
+		s.calibrationLoaded.Store(true)
+		s.decalibration.clear()
+		s.logger.Debugf("Successfully reloaded calibration from %s", cfg.CalibrationFile)
 		return map[string]interface{}{
 			"success":          true,
-			"calibration_file": s.cfg.CalibrationFile,
+			"calibration_file": cfg.CalibrationFile,
 			"message":          "Calibration reloaded successfully",
 		}, nil
 
+	case "health":
+		return s.health(), nil
+
+	case "thermal_status":
+		return s.thermal.status(), nil
+
+	case "home":
+		return s.home(ctx, cmd)
+
+	case "set_calibration_profile":
+		return s.setCalibrationProfile(cmd)
+	case "list_calibration_profiles":
+		return s.calibrationProfiles()
+
+	case "goto_pose":
+		return s.gotoPose(ctx, cmd)
+
+	case "list_poses":
+		return s.listPoses(), nil
+	case "follow":
+		return s.setFollow(cmd)
+	case "follow_status":
+		return s.follow.status(), nil
+	case "import_poses":
+		return s.importPoses()
+	case "export_poses":
+		return s.exportPoses(cmd)
+	case "run_program":
+		return s.runProgram(ctx, cmd)
+
+	case "waypoint_filter_status":
+		return s.waypointFilter.status(s.config().WaypointEpsilonDegs), nil
+
+	case "get_park_pose":
+		return s.getParkPose(), nil
+
+	case "maintenance_mode":
+		return s.setMaintenanceMode(ctx, cmd)
+
+	case "collision_status":
+		return s.collision.status(), nil
+
+	case "clear_collision":
+		if s.collision == nil {
+			return map[string]interface{}{
+				"success": false,
+				"error":   "collision detection is not enabled",
+			}, nil
+		}
+		if err := s.clearCollision(ctx); err != nil {
+			return map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			}, nil
+		}
+		result := s.collision.status()
+		result["success"] = true
+		return result, nil
+
+	case "clear_decalibration":
+		if s.decalibration == nil {
+			return map[string]interface{}{
+				"success": false,
+				"error":   "decalibration detection is not enabled",
+			}, nil
+		}
+		s.decalibration.clear()
+		result := s.decalibration.status()
+		result["success"] = true
+		return result, nil
+
+	case "watchdog_status":
+		return s.watchdog.status(), nil
+
+	case "clear_watchdog_fault":
+		s.watchdog.clear()
+		result := s.watchdog.status()
+		result["success"] = true
+		return result, nil
+
+	case "position_cache_status":
+		return s.controller.PositionCacheStatus(), nil
+
+	case "joint_staleness":
+		if s.jointCache == nil {
+			return map[string]interface{}{
+				"success": false,
+				"error":   "degraded_reads is not enabled",
+			}, nil
+		}
+		return map[string]interface{}{
+			"success": true,
+			"joints":  s.jointCache.staleness(),
+		}, nil
+
+	case "get_joint_loads":
+		return s.jointLoads(ctx)
+
+	case "emergency_stop":
+		s.stopCount.Add(1)
+		s.isMoving.Store(false)
+		s.events.add(eventInfo, "emergency stop requested")
+		return estopCommand(ctx, s.controller, s.name.ShortName(), cmd)
+
+	case "clear_emergency_stop", "emergency_stop_status":
+		return estopCommand(ctx, s.controller, s.name.ShortName(), cmd)
+
+	case "support_bundle":
+		return s.supportBundle(ctx, cmd)
+
+	case "bus_stats":
+		if reset, _ := cmd["reset"].(bool); reset {
+			s.controller.ResetBusStats()
+		}
+		return s.controller.BusStats(), nil
+
+	case "get_power_status":
+		return s.powerStatus(ctx), nil
+
+	case "reset_usage":
+		err := s.usage.reset()
+		return map[string]interface{}{"success": err == nil}, err
+
+	case "get_usage_summary":
+		return s.usage.summaryStatus()
+
+	case "dump_servo_settings":
+		return s.dumpServoSettings(ctx, cmd)
+
+	case "dump_registers":
+		return dumpRegisters(ctx, s.controller, cmd)
+
+	case "read_register":
+		return s.readRegister(ctx, cmd)
+
+	case "write_register":
+		return s.writeRegister(ctx, cmd)
+
+	case "restore_servo_settings":
+		return s.restoreServoSettings(ctx, cmd)
+
+	case "lint_config":
+		return s.lintConfig(ctx), nil
+
+	case "snapshot":
+		return s.snapshot(ctx), nil
+
+	case "execute_trajectory":
+		return s.executeTrajectory(ctx, cmd)
+
+	case "last_trajectory_stats":
+		return s.lastTrajectoryStatsResponse(), nil
+
+	case "jog_cartesian":
+		return s.jogCartesian(ctx, cmd)
+
+	case "visualization_state":
+		return s.visualizationState(ctx)
+
+	case "get_identity":
+		return s.getIdentity()
+
+	case "set_identity":
+		return s.setIdentity(cmd)
+
+	case "compliance_mode":
+		return s.setComplianceMode(ctx, cmd)
+
+	case "set_velocity_mode":
+		return s.setVelocityMode(ctx, cmd)
+
+	case "set_joint_velocity":
+		return s.setJointVelocity(ctx, cmd)
+
+	case "client_snippets":
+		return clientSnippetsResponse(SO101Model.String(), armClientSnippets), nil
+
 	case "get_calibration":
 		calibration := s.controller.GetCalibration()
 		return map[string]interface{}{
@@ -554,7 +1675,7 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 
 		if speedVal, ok := cmd["set_speed"]; ok {
 			if speed, ok := speedVal.(float64); ok {
-				if speed < 3 || speed > 180 {
+				if speed < minSpeedDegsPerSec || speed > maxSpeedDegsPerSec {
 					return nil, fmt.Errorf("speed must be between 3 and 180 degrees/second, got %.1f", speed)
 				}
 				s.mu.Lock()
@@ -569,7 +1690,7 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 
 		if accVal, ok := cmd["set_acceleration"]; ok {
 			if acc, ok := accVal.(float64); ok {
-				if acc < 10 || acc > 500 {
+				if acc < minAccDegsPerSecPerSec || acc > maxAccDegsPerSecPerSec {
 					return nil, fmt.Errorf("acceleration must be between 10 and 500 degrees/second^2, got %.1f", acc)
 				}
 				s.mu.Lock()
@@ -602,7 +1723,38 @@ func (s *so101) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 }
 
 func (s *so101) IsMoving(ctx context.Context) (bool, error) {
-	return s.isMoving.Load(), nil
+	if s.isMoving.Load() {
+		return true, nil
+	}
+	if s.config().IsMovingSource != isMovingSourceHardware {
+		return false, nil
+	}
+	return s.hardwareIsMoving(ctx)
+}
+
+// hardwareIsMoving reports motion seen by the servos, whether or not this arm commanded it
+func (s *so101) hardwareIsMoving(ctx context.Context) (bool, error) {
+	servoIDs := s.servoIDs()
+	moving, err := s.controller.ServosMoving(ctx, servoIDs)
+	if err != nil {
+		return false, err
+	}
+
+	positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to read joint positions: %w", err)
+	}
+
+	s.movingSampleMu.Lock()
+	defer s.movingSampleMu.Unlock()
+
+	if !moving && s.movingSample != nil && time.Since(s.movingSampleTime) <= isMovingSampleMaxAge {
+		moving = !withinTolerance(positions, s.movingSample, DegreesToRadians(isMovingThresholdDegs))
+	}
+	s.movingSample = positions
+	s.movingSampleTime = time.Now()
+
+	return moving, nil
 }
 
 func (s *so101) Geometries(ctx context.Context, extra map[string]interface{}) ([]spatialmath.Geometry, error) {
@@ -617,20 +1769,76 @@ func (s *so101) Geometries(ctx context.Context, extra map[string]interface{}) ([
 	return gif.Geometries(), nil
 }
 
-func (s *so101) Close(context.Context) error {
+func (s *so101) Close(ctx context.Context) error {
+	defer s.logs.Flush()
+	s.follow.end()
+	if err := s.disableCompliance(ctx); err != nil {
+		s.logger.Warnf("Failed to disable compliance mode on close: %v", err)
+	}
+	s.restorePositionMode(ctx)
+	s.shutdown()
 	s.cancelFunc()
-	ReleaseSharedController()
+	s.controller.RemoveReconnectHandler(s.name.ShortName())
+	cfg := s.config()
+	if cfg.PositionPollHz > 0 {
+		s.controller.StopPositionPolling()
+	}
+	if err := s.usage.save(); err != nil {
+		s.logger.Warnf("Failed to save usage data on close: %v", err)
+	}
+	UnregisterSharedConsumer(cfg.Port, s.name.ShortName())
+	ReleaseSharedControllerForPort(cfg.Port, s.controller)
 	return nil
 }
 
+// lintConfig checks this arm's configuration for common mistakes
+func (s *so101) lintConfig(ctx context.Context) map[string]interface{} {
+	cfg := s.config()
+	self := ConsumerInfo{Name: s.name.ShortName(), ServoIDs: s.servoIDs()}
+	for _, consumer := range GetSharedConsumers(cfg.Port) {
+		if consumer.Name == self.Name {
+			self = consumer
+		}
+	}
+
+	s.mu.RLock()
+	speed := float64(s.defaultSpeed)
+	acc := float64(s.defaultAcc)
+	s.mu.RUnlock()
+
+	issues := lintSharedConfig(ctx, s.controller, cfg.Port, cfg.Baudrate, self)
+	issues = append(issues, lintSpeed(self.Name, speed, acc)...)
+	return lintResult(issues)
+}
+
+// health summarizes conditions that need operator attention
+func (s *so101) health() map[string]interface{} {
+	usage := s.usage.status()
+	return map[string]interface{}{
+		"maintenance_due":        usage["maintenance_due"],
+		"usage":                  usage,
+		"thermal":                s.thermal.status(),
+		"emergency_stop":         s.controller.EmergencyStopStatus(),
+		"stale_joints":           s.jointCache.staleJoints(),
+		"watchdog":               s.watchdog.status(),
+		"collision":              s.collision.status(),
+		"possible_decalibration": s.decalibration.status(),
+		"maintenance_mode":       s.maintenance.status(),
+		"calibration_mismatches": s.calibrationMismatches,
+		"connection":             s.controller.ConnectionStatus(),
+	}
+}
+
 // initializeServos pings each servo and enables torque to ensure proper communication
 func (s *so101) initializeServos() error {
-	return s.initializeServosWithRetry(3)
+	attempts, _ := s.config().Retry.initRetry()
+	return s.initializeServosWithRetry(attempts)
 }
 
 // initializeServosWithRetry attempts servo initialization with retries
 func (s *so101) initializeServosWithRetry(maxRetries int) error {
 	s.logger.Debug("Initializing SO-101 arm servos...")
+	_, backoff := s.config().Retry.initRetry()
 
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
@@ -641,7 +1849,7 @@ func (s *so101) initializeServosWithRetry(maxRetries int) error {
 			s.logger.Warnf("Initialization attempt %d failed: %v", attempt, err)
 
 			if attempt < maxRetries {
-				waitTime := time.Duration(attempt) * 500 * time.Millisecond
+				waitTime := backoff(attempt)
 				s.logger.Debugf("Waiting %v before retry...", waitTime)
 				time.Sleep(waitTime)
 				continue
@@ -657,61 +1865,120 @@ func (s *so101) initializeServosWithRetry(maxRetries int) error {
 
 // doServoInitialization performs the actual initialization steps
 func (s *so101) doServoInitialization() error {
-	// Use stored initialization context instead of creating new one
-	ctx := s.initCtx
+	servoIDs := s.servoIDs()
+	// The arm's own context, the request that created or reconfigured the arm is already done
+	ctx := s.cancelCtx
 
 	// Ping all servos to ensure they're responding
-	s.logger.Debug("Pinging all servos...")
-	if err := s.controller.Ping(ctx); err != nil {
+	s.logger.Debug("Pinging arm servos...")
+	if err := s.controller.Ping(ctx, servoIDs); err != nil {
 		return fmt.Errorf("servo ping failed: %w", err)
 	}
-	s.logger.Debug("All servos ping successful")
+	s.logger.Debug("Arm servo ping successful")
+
+	// Enable torque for all servos (controller manages all 6), unless an emergency stop or
+	// maintenance mode is latched on the port
+	if err := s.controller.checkMotion(); err != nil {
+		s.logger.Warnf("Leaving torque disabled: %v", err)
+	} else {
+		s.logger.Debug("Enabling torque for all servos...")
+		if err := s.controller.EnableTorqueWithRamp(ctx, s.torqueRamp()); err != nil {
+			return fmt.Errorf("failed to enable torque: %w", err)
+		}
+	}
 
-	// Enable torque for all servos (controller manages all 6)
-	s.logger.Debug("Enabling torque for all servos...")
-	if err := s.controller.SetTorqueEnable(ctx, true); err != nil {
-		return fmt.Errorf("failed to enable torque: %w", err)
+	if err := s.applyMaxTorque(ctx); err != nil {
+		return err
 	}
 
 	time.Sleep(100 * time.Millisecond)
 
 	s.logger.Debug("Verifying position reading from arm servos...")
-	positions, err := s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+	positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
 	if err != nil {
 		return fmt.Errorf("failed to read initial joint positions: %w", err)
 	}
 
-	if len(positions) != len(s.armServoIDs) {
-		return fmt.Errorf("expected %d joint positions, got %d", len(s.armServoIDs), len(positions))
+	if len(positions) != len(servoIDs) {
+		return fmt.Errorf("expected %d joint positions, got %d", len(servoIDs), len(positions))
 	}
 
 	s.logger.Debugf("SO-101 arm servo initialization successful. Initial positions: %v", positions)
 	return nil
 }
 
+// restoreAfterReconnect reapplies the torque caps and torque state after the USB adapter
+// comes back, the servos may have lost power along with it
+func (s *so101) restoreAfterReconnect(ctx context.Context) {
+	s.events.add(eventWarning, "serial port reconnected, restoring servo configuration")
+	if err := s.applyMaxTorque(ctx); err != nil {
+		s.logger.Warnf("Failed to restore max_torque_percent after reconnect: %v", err)
+	}
+	if !s.controller.TorqueEnabled() || s.controller.checkMotion() != nil {
+		return
+	}
+	if err := s.controller.EnableTorqueWithRamp(ctx, s.torqueRamp()); err != nil {
+		s.logger.Warnf("Failed to re-enable torque after reconnect: %v", err)
+	}
+}
+
+// enableTorque turns torque on through torque_ramp_ms, unless an emergency stop or
+// maintenance mode is latched on the port
+func (s *so101) enableTorque(ctx context.Context) error {
+	if err := s.controller.checkMotion(); err != nil {
+		return err
+	}
+	return s.controller.EnableTorqueWithRamp(ctx, s.torqueRamp())
+}
+
+// applyMaxTorque sets each joint's running torque_limit to its max_torque_percent cap, and
+// joints without a cap back to the limit they power on with, their max_torque. Only the RAM
+// register is written, so init, reconnects and reconfigures don't wear the EEPROM.
+func (s *so101) applyMaxTorque(ctx context.Context) error {
+	caps := s.config().MaxTorquePercent
+	for _, servoID := range s.servoIDs() {
+		var data []byte
+		if percent, ok := caps[jointNameForServo(servoID)]; ok {
+			value := int(math.Round(percent * 10)) // registers are in 0.1% of stall torque
+			data = s.controller.protocolFor(servoID).EncodeWord(uint16(value))
+			s.logger.Debugf("Capping servo %d torque at %.1f%%", servoID, percent)
+		} else {
+			var err error
+			if data, err = s.controller.ReadServoRegister(ctx, servoID, "max_torque"); err != nil {
+				return fmt.Errorf("failed to read max_torque of servo %d: %w", servoID, err)
+			}
+		}
+		if err := s.controller.WriteServoRegister(ctx, servoID, "torque_limit", data); err != nil {
+			return fmt.Errorf("failed to set torque_limit on servo %d: %w", servoID, err)
+		}
+	}
+	return nil
+}
+
 // diagnoseConnection provides detailed diagnostics for troubleshooting
 func (s *so101) diagnoseConnection() error {
-	// Use stored initialization context instead of creating new one
-	ctx := s.initCtx
+	servoIDs := s.servoIDs()
+	// The arm's own context, the request that created or reconfigured the arm is already done
+	ctx := s.cancelCtx
 
 	s.logger.Debug("Starting SO-101 arm connection diagnosis...")
 
 	// Test overall ping
 	s.logger.Debug("Testing overall servo communication...")
-	if err := s.controller.Ping(ctx); err != nil {
+	if err := s.controller.Ping(ctx, servoIDs); err != nil {
 		s.logger.Errorf("Overall ping failed: %v", err)
 		return err
 	}
 	s.logger.Debug("Overall ping successful")
 
-	positions, err := s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+	positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
 	if err != nil {
 		s.logger.Errorf("Failed to read arm positions: %v", err)
 		return err
 	}
 
 	for i, pos := range positions {
-		s.logger.Debugf("Arm servo %d position: %.3f rad", s.armServoIDs[i], pos)
+		s.logger.Debugf("Arm servo %d position: %.3f rad", servoIDs[i], pos)
 	}
 
 	return nil
@@ -719,18 +1986,19 @@ func (s *so101) diagnoseConnection() error {
 
 // verifyServoConfig checks servo configuration
 func (s *so101) verifyServoConfig() error {
-	// Use stored initialization context instead of creating new one
-	ctx := s.initCtx
+	servoIDs := s.servoIDs()
+	// The arm's own context, the request that created or reconfigured the arm is already done
+	ctx := s.cancelCtx
 
 	s.logger.Debug("Verifying arm servo configuration...")
 
-	positions, err := s.controller.GetJointPositionsForServos(ctx, s.armServoIDs)
+	positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
 	if err != nil {
 		return fmt.Errorf("failed to verify servo config: %w", err)
 	}
 
-	if len(positions) != len(s.armServoIDs) {
-		return fmt.Errorf("config verification failed: expected %d servos, got %d", len(s.armServoIDs), len(positions))
+	if len(positions) != len(servoIDs) {
+		return fmt.Errorf("config verification failed: expected %d servos, got %d", len(servoIDs), len(positions))
 	}
 
 	s.logger.Debugf("Arm servo configuration verified. Current positions: %v", positions)
@@ -0,0 +1,221 @@
+package so_arm
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestJointLimitOverrides(t *testing.T) {
+	arm := &so101{
+		cfg: &SO101ArmConfig{
+			JointLimits: map[string]JointLimit{
+				"shoulder_pan": {MinDegs: -45, MaxDegs: 30},
+			},
+		},
+		controller:  &SafeSoArmController{calibration: DefaultSO101FullCalibration},
+		armServoIDs: []int{1, 2, 3, 4, 5},
+	}
+
+	calibrated := arm.calibratedJointLimits()
+	limits := arm.calculateJointLimits()
+	assert.InDelta(t, DegreesToRadians(-45), limits[0][0], 1e-9)
+	assert.InDelta(t, DegreesToRadians(30), limits[0][1], 1e-9)
+	assert.Equal(t, calibrated[1:], limits[1:])
+	assert.NoError(t, arm.checkJointLimits())
+
+	// Overrides can't widen the calibrated range
+	arm.cfg.JointLimits["shoulder_pan"] = JointLimit{MinDegs: -45, MaxDegs: RadiansToDegrees(calibrated[0][1]) + 10}
+	assert.Error(t, arm.checkJointLimits())
+}
+
+func TestCalibratedJointLimitsAreHalfTheSpan(t *testing.T) {
+	// 2048 steps is 180°, so the joint reaches ±90° rather than the ±π every range used to map onto
+	calibration := SO101FullCalibration{
+		ShoulderPan: &MotorCalibration{ID: 1, RangeMin: 1024, RangeMax: 3072, NormMode: NormModeDegrees},
+	}
+
+	limits := calibratedJointLimitsFor(calibration, []int{1, 2})
+	assert.InDelta(t, DegreesToRadians(-90), limits[0][0], 1e-9)
+	assert.InDelta(t, DegreesToRadians(90), limits[0][1], 1e-9)
+
+	// A joint without calibration keeps the full ±π
+	assert.Equal(t, [2]float64{-math.Pi, math.Pi}, limits[1])
+}
+
+func TestJointLimitsValidation(t *testing.T) {
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", JointLimits: map[string]JointLimit{"elbow_flex": {MinDegs: -10, MaxDegs: 10}}}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.JointLimits = map[string]JointLimit{"elbow_flex": {MinDegs: 10, MaxDegs: -10}}
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+
+	cfg.JointLimits = map[string]JointLimit{"gripper": {MinDegs: -10, MaxDegs: 10}}
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+}
+
+func TestRequireCalibrationFile(t *testing.T) {
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", RequireCalibrationFile: true}
+	_, _, err := cfg.Validate("")
+	assert.Error(t, err)
+
+	cfg.CalibrationFile = "so101.json"
+	_, _, err = cfg.Validate("")
+	assert.NoError(t, err)
+
+	arm := &so101{cfg: cfg, armServoIDs: []int{1, 2, 3, 4, 5}}
+	_, _, _, err = arm.startMove(context.Background(), make([]float64, 5), motionParams{})
+	assert.ErrorIs(t, err, ErrCalibrationRequired)
+
+	arm.calibrationLoaded.Store(true)
+	assert.NoError(t, arm.checkCalibrationRequired())
+
+	arm.cfg.RequireCalibrationFile = false
+	arm.calibrationLoaded.Store(false)
+	assert.NoError(t, arm.checkCalibrationRequired())
+}
+
+func TestShutdownBehaviorValidation(t *testing.T) {
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", ShutdownBehavior: shutdownLimp}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.ShutdownBehavior = "sleep"
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+
+	// park falls back to a computed rest pose, but a configured one must fit the joints
+	cfg.ShutdownBehavior = shutdownPark
+	_, _, err = cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.ParkPose = []float64{0, -90, 90}
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+
+	cfg.ParkPose = []float64{0, -90, 90, 60, 0}
+	_, _, err = cfg.Validate("")
+	assert.NoError(t, err)
+
+	arm := &so101{
+		cfg:         cfg,
+		controller:  &SafeSoArmController{calibration: DefaultSO101FullCalibration},
+		armServoIDs: []int{1, 2, 3, 4, 5},
+	}
+	target, err := arm.parkPoseRadians()
+	assert.NoError(t, err)
+	assert.Len(t, target, 5)
+	assert.InDelta(t, 0, target[0], 1e-9)
+}
+
+func TestMaintenanceModeBlocksMotion(t *testing.T) {
+	arm := &so101{
+		cfg:         &SO101ArmConfig{Port: "/dev/ttyUSB0"},
+		armServoIDs: []int{1, 2, 3, 4, 5},
+		maintenance: &maintenanceMode{},
+	}
+	assert.NoError(t, arm.checkMotionAllowed())
+
+	arm.maintenance.set(true, "changing fingers")
+	_, _, _, err := arm.startMove(context.Background(), make([]float64, 5), motionParams{})
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	assert.Equal(t, "changing fingers", arm.maintenance.status()["reason"])
+
+	arm.maintenance.set(false, "")
+	assert.NoError(t, arm.checkMotionAllowed())
+	assert.Equal(t, false, arm.maintenance.status()["active"])
+}
+
+func TestMaintenanceModeBlocksSharedController(t *testing.T) {
+	// The gripper's and the torque switch's views of the port share the arm's flag
+	maintenance := &maintenanceMode{}
+	view := &SafeSoArmController{maintenance: maintenance}
+	maintenance.set(true, "changing fingers")
+
+	ctx := context.Background()
+	assert.ErrorIs(t, view.SetTorqueEnable(ctx, true), ErrMaintenanceMode)
+	assert.ErrorIs(t, view.MoveServosToPositions(ctx, []int{6}, []float64{0}, 0, 0), ErrMaintenanceMode)
+
+	maintenance.set(false, "")
+	assert.NoError(t, view.checkMotion())
+}
+
+func TestNamedPoses(t *testing.T) {
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", Poses: map[string][]float64{"home": {0, -90, 90, 60, 0}}}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.Poses["transport"] = []float64{0, 0}
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+	delete(cfg.Poses, "transport")
+
+	arm := &so101{cfg: cfg, armServoIDs: []int{1, 2, 3, 4, 5}}
+	assert.Equal(t, []string{"home"}, arm.poseNames())
+
+	result, err := arm.gotoPose(context.Background(), map[string]interface{}{"name": "rest"})
+	assert.NoError(t, err)
+	assert.Equal(t, false, result["success"])
+
+	_, err = arm.gotoPose(context.Background(), map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestTorqueRampValidation(t *testing.T) {
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", TorqueRampMs: 500}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	arm := &so101{cfg: cfg}
+	assert.Equal(t, 500*time.Millisecond, arm.torqueRamp())
+
+	cfg.TorqueRampMs = -1
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+}
+
+func TestMoveCompletionTimeoutLongMove(t *testing.T) {
+	// 100 degrees at 3 degrees per second takes over 30 seconds
+	expected := time.Duration(DegreesToRadians(100) / DegreesToRadians(3) * float64(time.Second))
+	assert.Greater(t, expected, 30*time.Second)
+
+	timeout := moveCompletionTimeout(expected)
+	assert.Greater(t, timeout, expected)
+	assert.Equal(t, 2*expected+moveCompletionGrace, timeout)
+}
+
+func TestApplyMaxTorqueOnlyWritesTorqueLimit(t *testing.T) {
+	ctx := context.Background()
+	ids := []int{1, 2}
+	controller, fake := newFakeController(t, ids...)
+	for _, id := range ids {
+		fake.setRegister(id, feetech.RegMaxTorque, []byte{0xE8, 0x03})
+	}
+	s := &so101{
+		cfg:         &SO101ArmConfig{MaxTorquePercent: map[string]float64{jointNameForServo(1): 50}},
+		controller:  controller,
+		armServoIDs: ids,
+		logger:      logging.NewTestLogger(t),
+	}
+
+	assert.NoError(t, s.applyMaxTorque(ctx))
+	assert.Equal(t, []byte{0xF4, 0x01}, fake.register(1, feetech.RegTorqueLimit))
+	assert.Equal(t, []byte{0xE8, 0x03}, fake.register(2, feetech.RegTorqueLimit))
+
+	// Dropping the cap puts the joint back on its power-on limit
+	s.cfg = &SO101ArmConfig{}
+	assert.NoError(t, s.applyMaxTorque(ctx))
+	assert.Equal(t, []byte{0xE8, 0x03}, fake.register(1, feetech.RegTorqueLimit))
+
+	for _, id := range ids {
+		assert.Empty(t, fake.writesTo(id, feetech.RegMaxTorque))
+	}
+}
@@ -0,0 +1,172 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Automatic range calibration. After set_homing, auto_range drives each joint in turn toward
+// both mechanical limits with a reduced torque limit, in place of moving every joint through
+// its range by hand. The stall positions, less a margin, become the joint's range.
+const (
+	autoRangeTorquePercent    = 35.0
+	autoRangeStallLoadPercent = 30.0
+	autoRangeMarginSteps      = 20
+	autoRangeSettleTime       = time.Second
+)
+
+// autoRangeSearch walks arm joints slower than the gripper, since they carry the rest of the arm
+var autoRangeSearch = stallSearch{
+	StepSteps:        10,
+	StepInterval:     30 * time.Millisecond,
+	LagSteps:         100,
+	StallLoadPercent: autoRangeStallLoadPercent,
+	Timeout:          30 * time.Second,
+}
+
+// autoRangeLimits turns the two stall positions into a range, backed off by margin steps
+func autoRangeLimits(stopA, stopB, margin int) (int, int, error) {
+	rangeMin := min(stopA, stopB) + margin
+	rangeMax := max(stopA, stopB) - margin
+	if rangeMin >= rangeMax {
+		return 0, 0, fmt.Errorf("stops at %d and %d leave no range after a %d step margin", stopA, stopB, margin)
+	}
+	return rangeMin, rangeMax, nil
+}
+
+// autoRange handles the auto_range command. It blocks until every joint has been ranged, which
+// takes up to a minute per joint.
+func (cs *so101CalibrationSensor) autoRange(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.state != StateHomingPosition {
+		return map[string]any{"success": false},
+			fmt.Errorf("must set homing position first (current state: %s)", cs.state.String())
+	}
+
+	margin := autoRangeMarginSteps
+	if v, ok := cmd["margin_steps"].(float64); ok {
+		if v < 0 || v > 500 {
+			return map[string]any{"success": false}, fmt.Errorf("margin_steps must be between 0 and 500, got %.0f", v)
+		}
+		margin = int(v)
+	}
+	search := autoRangeSearch
+	torquePercent := autoRangeTorquePercent
+	if v, ok := cmd["torque_percent"].(float64); ok {
+		if v <= 0 || v > 100 {
+			return map[string]any{"success": false}, fmt.Errorf("torque_percent must be in (0, 100], got %.1f", v)
+		}
+		torquePercent = v
+		// The load can't climb past the torque limit, so stall a little below it
+		search.StallLoadPercent = math.Min(search.StallLoadPercent, v*0.85)
+	}
+
+	cs.setState(StateHomingPosition, "Finding joint limits automatically. Keep clear of the arm.")
+
+	rangeData := make(map[string]any)
+	for _, servoID := range cs.workflowServoIDs() {
+		joint := cs.joints[servoID]
+		stopA, stopB, err := cs.findJointStops(ctx, servoID, torquePercent, search)
+		if err != nil {
+			cs.setState(StateError, fmt.Sprintf("Automatic ranging of %s failed: %v", joint.Name, err))
+			return map[string]any{"success": false}, err
+		}
+		rangeMin, rangeMax, err := autoRangeLimits(stopA, stopB, margin)
+		if err != nil {
+			cs.setState(StateError, fmt.Sprintf("Automatic ranging of %s failed: %v", joint.Name, err))
+			return map[string]any{"success": false}, err
+		}
+
+		joint.RecordedMin = min(stopA, stopB)
+		joint.RecordedMax = max(stopA, stopB)
+		joint.RangeMin = rangeMin
+		joint.RangeMax = rangeMax
+		joint.IsCompleted = true
+		rangeData[joint.Name] = map[string]any{
+			"min":   rangeMin,
+			"max":   rangeMax,
+			"range": rangeMax - rangeMin,
+			"stops": []any{joint.RecordedMin, joint.RecordedMax},
+		}
+		cs.logger.Infof("Servo %d (%s): stalled at %d and %d, range [%d, %d]",
+			servoID, joint.Name, joint.RecordedMin, joint.RecordedMax, rangeMin, rangeMax)
+	}
+
+	cs.setState(StateCompleted,
+		"Automatic range calibration completed. Use 'save_calibration' to write calibration to servos and save to file.")
+
+	return map[string]any{
+		"success":      true,
+		"state":        cs.state.String(),
+		"margin_steps": margin,
+		"ranges":       rangeData,
+		"message":      cs.lastInstruction,
+	}, nil
+}
+
+// findJointStops drives one servo into both of its stops with a reduced torque limit and
+// returns the raw stall positions. The servo is returned between them and left limp.
+func (cs *so101CalibrationSensor) findJointStops(ctx context.Context, servoID int, torquePercent float64, search stallSearch) (int, int, error) {
+	var stopA, stopB int
+	err := cs.withLimitedTorque(ctx, servoID, torquePercent, func() error {
+		var err error
+		if stopA, err = findStallPosition(ctx, cs.controller, servoID, -1, search, cs.logger); err != nil {
+			return fmt.Errorf("failed to find lower stop: %w", err)
+		}
+		if stopB, err = findStallPosition(ctx, cs.controller, servoID, 1, search, cs.logger); err != nil {
+			return fmt.Errorf("failed to find upper stop: %w", err)
+		}
+
+		// Leave the joint between its stops so the next joint's sweep doesn't start pinned
+		middle := (stopA + stopB) / 2
+		if err := cs.controller.WriteServoRegister(ctx, servoID, "goal_position", cs.controller.protocolFor(servoID).EncodeWord(uint16(middle))); err != nil {
+			cs.logger.Warnf("Failed to return servo %d between its stops: %v", servoID, err)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(autoRangeSettleTime):
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return stopA, stopB, nil
+}
+
+// withLimitedTorque enables a servo's torque with its torque limit lowered to torquePercent,
+// runs fn, then disables the torque and restores the limit, leaving the servo limp as the rest
+// of the calibration workflow expects
+func (cs *so101CalibrationSensor) withLimitedTorque(ctx context.Context, servoID int, torquePercent float64, fn func() error) error {
+	data, err := cs.controller.ReadServoRegister(ctx, servoID, "present_position")
+	if err != nil {
+		return fmt.Errorf("failed to read position: %w", err)
+	}
+	// Hold where it is when torque comes on rather than jumping to a stale goal
+	if err := cs.controller.WriteServoRegister(ctx, servoID, "goal_position", data); err != nil {
+		return fmt.Errorf("failed to set goal position: %w", err)
+	}
+
+	savedLimit, err := cs.controller.ReadServoRegister(ctx, servoID, "torque_limit")
+	if err != nil {
+		return fmt.Errorf("failed to read torque limit: %w", err)
+	}
+	limit := int(math.Round(torquePercent * 10)) // torque_limit is in 0.1% of stall torque
+	if err := cs.controller.WriteServoRegister(ctx, servoID, "torque_limit", cs.controller.protocolFor(servoID).EncodeWord(uint16(limit))); err != nil {
+		return fmt.Errorf("failed to lower torque limit: %w", err)
+	}
+	defer func() {
+		if err := cs.controller.WriteServoRegister(ctx, servoID, "torque_enable", []byte{0}); err != nil {
+			cs.logger.Warnf("Failed to disable torque on servo %d: %v", servoID, err)
+		}
+		if err := cs.controller.WriteServoRegister(ctx, servoID, "torque_limit", savedLimit); err != nil {
+			cs.logger.Warnf("Failed to restore torque limit on servo %d: %v", servoID, err)
+		}
+	}()
+	if err := cs.controller.WriteServoRegister(ctx, servoID, "torque_enable", []byte{1}); err != nil {
+		return fmt.Errorf("failed to enable torque: %w", err)
+	}
+	return fn()
+}
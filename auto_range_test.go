@@ -0,0 +1,41 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestAutoRangeLimits(t *testing.T) {
+	rangeMin, rangeMax, err := autoRangeLimits(3100, 900, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 920, rangeMin)
+	assert.Equal(t, 3080, rangeMax)
+
+	// Stops closer together than the margin leave nothing to move through
+	_, _, err = autoRangeLimits(2000, 2030, 20)
+	assert.Error(t, err)
+}
+
+func TestFindStallPositionReadsLoadFromBus(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	controller, fake := newFakeController(t, 1)
+	fake.setRegister(1, feetech.RegPresentPosition, []byte{0x00, 0x08})
+	fake.setRegister(1, feetech.RegGoalPosition, []byte{0x00, 0x08})
+	search := stallSearch{StepSteps: 10, StepInterval: time.Millisecond, LagSteps: 1000, StallLoadPercent: 30, Timeout: time.Second}
+	ctx := context.Background()
+
+	// 40% load on the first step is a stop
+	fake.setRegister(1, feetech.RegPresentLoad, []byte{0x90, 0x01})
+	stop, err := findStallPosition(ctx, controller, 1, 1, search, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 2048, stop)
+
+	fake.failReads(feetech.RegPresentLoad)
+	_, err = findStallPosition(ctx, controller, 1, 1, search, logger)
+	assert.ErrorContains(t, err, "load")
+}
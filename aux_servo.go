@@ -0,0 +1,277 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+var (
+	SO101ServoModel = resource.NewModel("devrel", "so101", "servo")
+)
+
+// Auxiliary servo defaults. 0° sits a quarter turn below the servo's center so the standard
+// 0-180° servo range is centered on it.
+const (
+	defaultAuxServoZeroPosition = ServoStepsPerRevolution / 4
+	defaultAuxServoMaxDegs      = 180
+	defaultAuxServoSpeed        = 60.0
+	auxServoPollInterval        = 20 * time.Millisecond
+	auxServoToleranceSteps      = 10
+)
+
+func init() {
+	resource.RegisterComponent(servo.API, SO101ServoModel,
+		resource.Registration[servo.Servo, *SO101ServoConfig]{
+			Constructor: newSO101Servo,
+		},
+	)
+}
+
+// SO101ServoConfig binds a single servo on the shared bus, such as a camera tilt or a lid
+// opener, to the standard servo API
+type SO101ServoConfig struct {
+	Port     string        `json:"port,omitempty"`
+	Baudrate int           `json:"baudrate,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+
+	// Bus protocol, "sts" (default) or "scs", and servos that speak SCS on an STS bus
+	Protocol    string `json:"protocol,omitempty"`
+	SCSServoIDs []int  `json:"scs_servo_ids,omitempty"`
+
+	// How serial reads, writes and pings are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	ServoID int `json:"servo_id"`
+
+	// Raw position (0-4095) that reads as 0°, default 1024
+	ZeroPosition *int `json:"zero_position,omitempty"`
+	// Largest angle Move accepts, default 180
+	MaxDegs int `json:"max_degs,omitempty"`
+	// Count degrees toward lower raw positions
+	Reversed bool `json:"reversed,omitempty"`
+
+	SpeedDegsPerSec float64 `json:"speed_degs_per_sec,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid
+func (cfg *SO101ServoConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Port == "" {
+		return nil, nil, fmt.Errorf("must specify port for serial communication")
+	}
+	if err := validateBusProtocol(cfg.Protocol, cfg.SCSServoIDs); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Retry.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if cfg.ServoID < 1 || cfg.ServoID > 253 {
+		return nil, nil, fmt.Errorf("servo_id must be between 1 and 253, got %d", cfg.ServoID)
+	}
+	if cfg.MaxDegs < 0 || cfg.MaxDegs > 360 {
+		return nil, nil, fmt.Errorf("max_degs must be between 0 and 360, got %d", cfg.MaxDegs)
+	}
+	if cfg.SpeedDegsPerSec != 0 && (cfg.SpeedDegsPerSec < minSpeedDegsPerSec || cfg.SpeedDegsPerSec > maxSpeedDegsPerSec) {
+		return nil, nil, fmt.Errorf("speed_degs_per_sec must be between %.0f and %.0f, got %.1f",
+			float64(minSpeedDegsPerSec), float64(maxSpeedDegsPerSec), cfg.SpeedDegsPerSec)
+	}
+	mapping := cfg.mapping()
+	for _, deg := range []uint32{0, uint32(mapping.maxDegs)} {
+		if raw := mapping.toRaw(deg); raw < 0 || raw > ServoMaxPosition {
+			return nil, nil, fmt.Errorf("%d° maps to raw position %d, outside 0-%d, adjust zero_position, max_degs or reversed", deg, raw, ServoMaxPosition)
+		}
+	}
+	return nil, nil, nil
+}
+
+// auxServoMapping converts between servo API degrees and raw positions
+type auxServoMapping struct {
+	zero     int
+	maxDegs  int
+	reversed bool
+}
+
+func (cfg *SO101ServoConfig) mapping() auxServoMapping {
+	m := auxServoMapping{zero: defaultAuxServoZeroPosition, maxDegs: defaultAuxServoMaxDegs, reversed: cfg.Reversed}
+	if cfg.ZeroPosition != nil {
+		m.zero = *cfg.ZeroPosition
+	}
+	if cfg.MaxDegs != 0 {
+		m.maxDegs = cfg.MaxDegs
+	}
+	return m
+}
+
+func (m auxServoMapping) toRaw(deg uint32) int {
+	steps := int(math.Round(DegreesToSteps(float64(deg))))
+	if m.reversed {
+		steps = -steps
+	}
+	return m.zero + steps
+}
+
+// toDegrees converts a raw position back, clamped to what the servo API can report
+func (m auxServoMapping) toDegrees(raw int) uint32 {
+	steps := raw - m.zero
+	if m.reversed {
+		steps = -steps
+	}
+	deg := math.Round(StepsToDegrees(float64(steps)))
+	return uint32(clampFloat(deg, 0, float64(m.maxDegs)))
+}
+
+type so101Servo struct {
+	resource.AlwaysRebuild
+
+	name       resource.Name
+	logger     logging.Logger
+	controller *SafeSoArmController
+	port       string
+	servoID    int
+	mapping    auxServoMapping
+	speed      float64
+}
+
+func newSO101Servo(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (servo.Servo, error) {
+	cfg, err := resource.NativeConfig[*SO101ServoConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+	baudrate := cfg.Baudrate
+	if baudrate == 0 {
+		baudrate = 1000000
+	}
+	controllerConfig := &SoArm101Config{
+		Port:        cfg.Port,
+		Baudrate:    baudrate,
+		ServoIDs:    []int{cfg.ServoID},
+		Timeout:     cfg.Timeout,
+		Protocol:    cfg.Protocol,
+		SCSServoIDs: cfg.SCSServoIDs,
+		Retry:       cfg.Retry,
+		Logger:      logger,
+	}
+	calibration, fromFile := controllerConfig.LoadCalibration(logger)
+	controller, err := GetSharedControllerWithCalibration(controllerConfig, calibration, fromFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared controller: %w", err)
+	}
+	RegisterSharedConsumer(cfg.Port, ConsumerInfo{Name: conf.ResourceName().ShortName(), ServoIDs: []int{cfg.ServoID}})
+
+	s := &so101Servo{
+		name:       conf.ResourceName(),
+		logger:     logger,
+		controller: controller,
+		port:       cfg.Port,
+		servoID:    cfg.ServoID,
+		mapping:    cfg.mapping(),
+		speed:      defaultAuxServoSpeed,
+	}
+	if cfg.SpeedDegsPerSec != 0 {
+		s.speed = cfg.SpeedDegsPerSec
+	}
+	if cfg.ServoID <= 6 {
+		logger.Warnf("servo_id %d is an SO-101 joint, moving it here bypasses the arm's joint limits", cfg.ServoID)
+	}
+	// Hold where it is before powering it so it doesn't jump to a stale goal
+	if err := controller.HoldRawServo(ctx, cfg.ServoID); err != nil {
+		s.Close(ctx)
+		return nil, fmt.Errorf("servo %d did not respond: %w", cfg.ServoID, err)
+	}
+	if err := controller.WriteServoRegisterAt(ctx, cfg.ServoID, feetech.RegTorqueEnable, []byte{1}); err != nil {
+		s.Close(ctx)
+		return nil, fmt.Errorf("failed to enable torque on servo %d: %w", cfg.ServoID, err)
+	}
+	return s, nil
+}
+
+func (s *so101Servo) Name() resource.Name {
+	return s.name
+}
+
+// Move moves to angleDeg and blocks until the servo arrives or ctx is done
+func (s *so101Servo) Move(ctx context.Context, angleDeg uint32, extra map[string]interface{}) error {
+	if int(angleDeg) > s.mapping.maxDegs {
+		return fmt.Errorf("angle must be between 0 and %d degrees, got %d", s.mapping.maxDegs, angleDeg)
+	}
+	target := s.mapping.toRaw(angleDeg)
+	if err := s.controller.MoveRawServo(ctx, s.servoID, target, s.speed); err != nil {
+		return fmt.Errorf("failed to move servo %d: %w", s.servoID, err)
+	}
+
+	// A full sweep at the configured speed plus a margin, so a blocked servo can't hang Move
+	limit := time.Duration(float64(s.mapping.maxDegs)/s.speed*float64(time.Second)) + time.Second
+	deadline := time.After(limit)
+	ticker := time.NewTicker(auxServoPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("servo %d did not reach %d° within %v", s.servoID, angleDeg, limit)
+		case <-ticker.C:
+		}
+		positions, err := s.controller.ReadRawPositions(ctx, []int{s.servoID})
+		if err != nil {
+			return fmt.Errorf("failed to read servo %d position: %w", s.servoID, err)
+		}
+		if diff := positions[s.servoID] - target; diff >= -auxServoToleranceSteps && diff <= auxServoToleranceSteps {
+			return nil
+		}
+	}
+}
+
+func (s *so101Servo) Position(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+	positions, err := s.controller.ReadRawPositions(ctx, []int{s.servoID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read servo %d position: %w", s.servoID, err)
+	}
+	return s.mapping.toDegrees(positions[s.servoID]), nil
+}
+
+func (s *so101Servo) Stop(ctx context.Context, extra map[string]interface{}) error {
+	return s.controller.HoldRawServo(ctx, s.servoID)
+}
+
+func (s *so101Servo) IsMoving(ctx context.Context) (bool, error) {
+	moving, err := s.controller.ReadServoRegisterAt(ctx, s.servoID, feetech.RegMoving)
+	if err != nil {
+		return false, err
+	}
+	return len(moving) > 0 && moving[0] != 0, nil
+}
+
+func (s *so101Servo) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("unknown command: %v", cmd["command"])
+}
+
+func (s *so101Servo) Close(ctx context.Context) error {
+	UnregisterSharedConsumer(s.port, s.name.ShortName())
+	ReleaseSharedControllerForPort(s.port, s.controller)
+	return nil
+}
+
+// MoveRawServo sends one servo, which needn't be an arm joint, to a raw position
+func (s *SafeSoArmController) MoveRawServo(ctx context.Context, servoID, rawPos int, speedDegsPerSec float64) error {
+	if err := s.checkMotion(); err != nil {
+		return err
+	}
+	stepsPerSec := int(math.Round(DegreesToSteps(speedDegsPerSec)))
+	return s.syncWriteGoals(ctx, map[int]int{servoID: rawPos}, 0, stepsPerSec)
+}
+
+// HoldRawServo sets one servo's goal to its present position
+func (s *SafeSoArmController) HoldRawServo(ctx context.Context, servoID int) error {
+	positions, err := s.ReadRawPositions(ctx, []int{servoID})
+	if err != nil {
+		return err
+	}
+	return s.syncWriteGoals(ctx, map[int]int{servoID: positions[servoID]}, 0, 0)
+}
@@ -0,0 +1,41 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuxServoMapping(t *testing.T) {
+	cfg := &SO101ServoConfig{Port: "/dev/ttyUSB0", ServoID: 7}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	m := cfg.mapping()
+	assert.Equal(t, 1024, m.toRaw(0))
+	assert.Equal(t, 2048, m.toRaw(90))
+	assert.Equal(t, 3072, m.toRaw(180))
+	assert.Equal(t, uint32(90), m.toDegrees(2048))
+	// Readings past either end are clamped to the servo API's range
+	assert.Equal(t, uint32(0), m.toDegrees(900))
+	assert.Equal(t, uint32(180), m.toDegrees(3500))
+
+	cfg.Reversed = true
+	zero := 3072
+	cfg.ZeroPosition = &zero
+	m = cfg.mapping()
+	assert.Equal(t, 2048, m.toRaw(90))
+	assert.Equal(t, uint32(45), m.toDegrees(2560))
+	_, _, err = cfg.Validate("")
+	assert.NoError(t, err)
+
+	// 0-360° from the middle runs off the end of the servo's range
+	cfg.Reversed = false
+	cfg.MaxDegs = 360
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+
+	cfg = &SO101ServoConfig{Port: "/dev/ttyUSB0"}
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+}
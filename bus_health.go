@@ -0,0 +1,188 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Bus health counters. Every read, write and ping the controller sends is counted per port
+// and failures are split by cause: timeouts point at cabling, power or a missing servo,
+// checksum errors at noise on the line, anything else usually at firmware or configuration.
+const busLatencyWindow = 1000
+
+// Upper bounds of the latency histogram buckets, the last bucket is open-ended
+var busLatencyBucketsMs = []float64{1, 2, 5, 10, 20, 50, 100}
+
+type busHealth struct {
+	mu             sync.Mutex
+	transactions   int64
+	failures       int64
+	timeouts       int64
+	checksumErrors int64
+	retries        int64
+	streak         int64 // failures since the last success
+	buckets        []int64
+	latencies      []time.Duration // the most recent busLatencyWindow transactions
+	next           int
+	since          time.Time
+}
+
+func newBusHealth() *busHealth {
+	return &busHealth{buckets: make([]int64, len(busLatencyBucketsMs)+1), since: time.Now()}
+}
+
+// busErrorKind sorts a failed transaction into "timeout", "checksum" or "other"
+func busErrorKind(err error) string {
+	var status feetech.StatusError
+	switch {
+	case errors.Is(err, feetech.ErrTimeout), errors.Is(err, feetech.ErrNoResponse), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &status) && status&feetech.ErrChecksum != 0, strings.Contains(err.Error(), "checksum"):
+		return "checksum"
+	default:
+		return "other"
+	}
+}
+
+func (h *busHealth) record(latency time.Duration, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.transactions++
+	if err == nil {
+		h.streak = 0
+	} else {
+		h.failures++
+		h.streak++
+		switch busErrorKind(err) {
+		case "timeout":
+			h.timeouts++
+		case "checksum":
+			h.checksumErrors++
+		}
+	}
+
+	ms := float64(latency.Microseconds()) / 1000
+	bucket, _ := slices.BinarySearch(busLatencyBucketsMs, ms)
+	h.buckets[bucket]++
+	if len(h.latencies) < busLatencyWindow {
+		h.latencies = append(h.latencies, latency)
+	} else {
+		h.latencies[h.next] = latency
+		h.next = (h.next + 1) % busLatencyWindow
+	}
+}
+
+func (h *busHealth) recordRetry() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.retries++
+}
+
+// failureStreak returns how many transactions in a row have failed
+func (h *busHealth) failureStreak() int64 {
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.streak
+}
+
+func (h *busHealth) clearFailureStreak() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.streak = 0
+}
+
+func (h *busHealth) reset() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.transactions, h.failures, h.timeouts, h.checksumErrors, h.retries, h.streak = 0, 0, 0, 0, 0, 0
+	h.buckets = make([]int64, len(busLatencyBucketsMs)+1)
+	h.latencies, h.next = nil, 0
+	h.since = time.Now()
+}
+
+func (h *busHealth) status() map[string]interface{} {
+	if h == nil {
+		return map[string]interface{}{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := map[string]interface{}{
+		"transactions":    h.transactions,
+		"failures":        h.failures,
+		"timeouts":        h.timeouts,
+		"checksum_errors": h.checksumErrors,
+		"retries":         h.retries,
+		"failure_streak":  h.streak,
+		"since":           h.since.Format(time.RFC3339),
+	}
+	histogram := make([]interface{}, len(h.buckets))
+	for i, count := range h.buckets {
+		bucket := map[string]interface{}{"count": count}
+		if i < len(busLatencyBucketsMs) {
+			bucket["le_ms"] = busLatencyBucketsMs[i]
+		}
+		histogram[i] = bucket
+	}
+	result["latency_histogram"] = histogram
+
+	if len(h.latencies) > 0 {
+		sorted := slices.Sorted(slices.Values(h.latencies))
+		var total time.Duration
+		for _, l := range sorted {
+			total += l
+		}
+		// Nearest-rank percentile
+		percentile := func(p float64) float64 {
+			return float64(sorted[int(math.Ceil(p*float64(len(sorted))))-1].Microseconds()) / 1000
+		}
+		result["latency_avg_ms"] = float64((total / time.Duration(len(sorted))).Microseconds()) / 1000
+		result["latency_p50_ms"] = percentile(0.5)
+		result["latency_p95_ms"] = percentile(0.95)
+		result["latency_p99_ms"] = percentile(0.99)
+	}
+	return result
+}
+
+// busOp runs one bus transaction with the retry policy, counting each try
+func (s *SafeSoArmController) busOp(ctx context.Context, op func(context.Context) error) error {
+	tries := 0
+	return s.retry.do(ctx, func(ctx context.Context) error {
+		if tries > 0 {
+			s.busHealth.recordRetry()
+		}
+		tries++
+		start := time.Now()
+		err := op(ctx)
+		s.busHealth.record(time.Since(start), err)
+		return err
+	})
+}
+
+// ResetBusStats zeroes the transaction counters and latency history of the port
+func (s *SafeSoArmController) ResetBusStats() {
+	s.busHealth.reset()
+}
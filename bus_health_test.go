@@ -0,0 +1,66 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusErrorKind(t *testing.T) {
+	assert.Equal(t, "timeout", busErrorKind(feetech.ErrNoResponse))
+	assert.Equal(t, "timeout", busErrorKind(fmt.Errorf("%w: read 3 of 8 expected bytes", feetech.ErrTimeout)))
+	assert.Equal(t, "timeout", busErrorKind(context.DeadlineExceeded))
+	assert.Equal(t, "checksum", busErrorKind(errors.New("checksum mismatch: expected 0x12, got 0x34")))
+	assert.Equal(t, "checksum", busErrorKind(feetech.ErrChecksum))
+	assert.Equal(t, "other", busErrorKind(feetech.ErrOverload))
+}
+
+func TestBusHealth(t *testing.T) {
+	h := newBusHealth()
+	h.record(500*time.Microsecond, nil)
+	h.record(3*time.Millisecond, nil)
+	h.record(200*time.Millisecond, feetech.ErrNoResponse)
+	h.record(4*time.Millisecond, errors.New("checksum mismatch"))
+	h.recordRetry()
+
+	status := h.status()
+	assert.Equal(t, int64(4), status["transactions"])
+	assert.Equal(t, int64(2), status["failures"])
+	assert.Equal(t, int64(1), status["timeouts"])
+	assert.Equal(t, int64(1), status["checksum_errors"])
+	assert.Equal(t, int64(1), status["retries"])
+	assert.Equal(t, 3.0, status["latency_p50_ms"])
+	assert.Equal(t, 200.0, status["latency_p99_ms"])
+
+	histogram := status["latency_histogram"].([]interface{})
+	assert.Equal(t, int64(1), histogram[0].(map[string]interface{})["count"])
+	assert.Equal(t, int64(2), histogram[2].(map[string]interface{})["count"])
+	last := histogram[len(histogram)-1].(map[string]interface{})
+	assert.Equal(t, int64(1), last["count"])
+	assert.NotContains(t, last, "le_ms")
+
+	h.reset()
+	assert.Equal(t, int64(0), h.status()["transactions"])
+	assert.NotContains(t, h.status(), "latency_p50_ms")
+}
+
+func TestBusOpCountsRetries(t *testing.T) {
+	controller := &SafeSoArmController{retry: &RetryPolicy{Attempts: 3}, busHealth: newBusHealth()}
+	calls := 0
+	err := controller.busOp(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return feetech.ErrNoResponse
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	stats := controller.busHealth.status()
+	assert.Equal(t, int64(2), stats["transactions"])
+	assert.Equal(t, int64(1), stats["retries"])
+}
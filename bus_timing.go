@@ -0,0 +1,126 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"go.viam.com/rdk/logging"
+)
+
+// Adaptive bus timing. Adapters differ a lot in turnaround (a CH340 answers in about a
+// millisecond, an FTDI with the default latency timer takes 16), so the read timeout and
+// command gap are derived from the slowest response measured at startup.
+const (
+	defaultBusTimeout      = time.Second
+	defaultBusCommandGap   = time.Millisecond
+	latencySamples         = 5
+	latencyTimeoutFactor   = 5
+	minAdaptiveBusTimeout  = 50 * time.Millisecond
+	latencyCommandGapRatio = 4
+	maxAdaptiveCommandGap  = 10 * time.Millisecond
+)
+
+// busTiming records the timeout and command gap a bus was opened with, and the latencies
+// they were derived from when adaptive
+type busTiming struct {
+	Adaptive   bool
+	Timeout    time.Duration
+	CommandGap time.Duration
+	Latency    map[int]time.Duration // slowest response per servo
+	MeasuredAt time.Time
+}
+
+// measureServoLatency times a position read on each servo and keeps the slowest of several
+// samples. Servos that don't answer the first read are left out.
+func measureServoLatency(ctx context.Context, bus *feetech.Bus, servoIDs []int) map[int]time.Duration {
+	latency := map[int]time.Duration{}
+	for _, id := range servoIDs {
+		var slowest time.Duration
+		for i := 0; i < latencySamples; i++ {
+			start := time.Now()
+			if _, err := bus.ReadRegister(ctx, id, feetech.RegPresentPosition.Address, feetech.RegPresentPosition.Size); err != nil {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+			slowest = max(slowest, time.Since(start))
+		}
+		if slowest > 0 {
+			latency[id] = slowest
+		}
+	}
+	return latency
+}
+
+// adaptiveTiming derives a read timeout and command gap from measured latencies
+func adaptiveTiming(latency map[int]time.Duration) (time.Duration, time.Duration) {
+	var slowest time.Duration
+	for _, l := range latency {
+		slowest = max(slowest, l)
+	}
+	timeout := min(max(slowest*latencyTimeoutFactor, minAdaptiveBusTimeout), defaultBusTimeout)
+	gap := min(max(slowest/latencyCommandGapRatio, defaultBusCommandGap), maxAdaptiveCommandGap)
+	return timeout, gap
+}
+
+// adaptBusTiming measures servo latency on a freshly opened bus and reopens it with timing to
+// match. If no servo answers, or the reopen fails, the original bus settings are kept.
+func adaptBusTiming(bus *feetech.Bus, busConfig feetech.BusConfig, servoIDs []int, logger logging.Logger) (*feetech.Bus, *busTiming, error) {
+	timing := &busTiming{Timeout: busConfig.Timeout, CommandGap: defaultBusCommandGap}
+	latency := measureServoLatency(context.Background(), bus, servoIDs)
+	if len(latency) == 0 {
+		return bus, timing, nil
+	}
+
+	timeout, gap := adaptiveTiming(latency)
+	if err := bus.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close bus for timing adjustment: %w", err)
+	}
+	adapted := busConfig
+	adapted.Timeout = timeout
+	adapted.MinCommandGap = gap
+	adaptedBus, err := feetech.NewBus(adapted)
+	if err != nil {
+		if logger != nil {
+			logger.Warnf("Failed to reopen bus with adaptive timing, keeping defaults: %v", err)
+		}
+		bus, err := feetech.NewBus(busConfig)
+		return bus, timing, err
+	}
+
+	if logger != nil {
+		logger.Debugf("Adaptive bus timing: timeout %v, command gap %v from latencies %v", timeout, gap, latency)
+	}
+	return adaptedBus, &busTiming{
+		Adaptive:   true,
+		Timeout:    timeout,
+		CommandGap: gap,
+		Latency:    latency,
+		MeasuredAt: time.Now(),
+	}, nil
+}
+
+// BusStats reports how the shared bus is timed and how its transactions are going, for the
+// bus_stats DoCommand
+func (s *SafeSoArmController) BusStats() map[string]interface{} {
+	result := s.busHealth.status()
+	result["adaptive"] = false
+	if s.timing == nil {
+		return result
+	}
+	latency := map[string]interface{}{}
+	for id, l := range s.timing.Latency {
+		latency[jointNameForServo(id)] = float64(l.Microseconds()) / 1000
+	}
+	result["adaptive"] = s.timing.Adaptive
+	result["timeout_ms"] = float64(s.timing.Timeout.Microseconds()) / 1000
+	result["command_gap_ms"] = float64(s.timing.CommandGap.Microseconds()) / 1000
+	result["latency_ms"] = latency
+	if !s.timing.MeasuredAt.IsZero() {
+		result["measured_at"] = s.timing.MeasuredAt.Format(time.RFC3339)
+	}
+	return result
+}
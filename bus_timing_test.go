@@ -0,0 +1,30 @@
+package so_arm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveTiming(t *testing.T) {
+	// Fast adapter: floors apply
+	timeout, gap := adaptiveTiming(map[int]time.Duration{1: 2 * time.Millisecond, 2: time.Millisecond})
+	assert.Equal(t, minAdaptiveBusTimeout, timeout)
+	assert.Equal(t, defaultBusCommandGap, gap)
+
+	// Slow adapter: scaled from the slowest servo
+	timeout, gap = adaptiveTiming(map[int]time.Duration{1: 16 * time.Millisecond, 2: 20 * time.Millisecond})
+	assert.Equal(t, 100*time.Millisecond, timeout)
+	assert.Equal(t, 5*time.Millisecond, gap)
+
+	// Pathological latency is capped
+	timeout, gap = adaptiveTiming(map[int]time.Duration{1: 500 * time.Millisecond})
+	assert.Equal(t, defaultBusTimeout, timeout)
+	assert.Equal(t, maxAdaptiveCommandGap, gap)
+}
+
+func TestBusStatsWithoutTiming(t *testing.T) {
+	controller := &SafeSoArmController{}
+	assert.Equal(t, false, controller.BusStats()["adaptive"])
+}
@@ -54,8 +54,7 @@ func (c *MotorCalibration) Normalize(rawValue int) (float64, error) {
 
 	case NormModeDegrees:
 		center := float64(c.RangeMin+c.RangeMax) / 2.0
-		maxResolution := float64(4095)
-		normalized = (float64(rawValue) - center) * 360 / maxResolution
+		normalized = StepsToDegrees(float64(rawValue) - center)
 
 	default:
 		return 0, fmt.Errorf("unknown normalization mode: %d", c.NormMode)
@@ -121,8 +120,7 @@ func (c *MotorCalibration) Denormalize(normalizedValue float64) (int, error) {
 
 	case NormModeDegrees:
 		center := float64(c.RangeMin+c.RangeMax) / 2.0
-		maxResolution := float64(4095)
-		rawValue = int((adjustedValue * maxResolution / 360) + center)
+		rawValue = int(math.Round(DegreesToSteps(adjustedValue) + center))
 
 	default:
 		return 0, fmt.Errorf("unknown normalization mode: %d", c.NormMode)
@@ -149,8 +147,8 @@ func (c *MotorCalibration) Validate() error {
 		return fmt.Errorf("invalid range: min (%d) must be less than max (%d)", c.RangeMin, c.RangeMax)
 	}
 
-	if c.RangeMin < 0 || c.RangeMax > 4095 {
-		return fmt.Errorf("range values must be between 0-4095, got min=%d max=%d", c.RangeMin, c.RangeMax)
+	if c.RangeMin < 0 || c.RangeMax > ServoMaxPosition {
+		return fmt.Errorf("range values must be between 0-%d, got min=%d max=%d", ServoMaxPosition, c.RangeMin, c.RangeMax)
 	}
 
 	if c.NormMode < NormModeRaw || c.NormMode > NormModeDegrees {
@@ -302,6 +300,13 @@ func (cs *CalibratedServo) GetRawServo() *feetech.Servo {
 	return cs.servo
 }
 
+// setServo points the wrapper at a servo on a reopened bus
+func (cs *CalibratedServo) setServo(servo *feetech.Servo) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.servo = servo
+}
+
 // UpdateCalibration safely updates the calibration data
 func (cs *CalibratedServo) UpdateCalibration(calibration *MotorCalibration) {
 	cs.mu.Lock()
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -37,6 +38,8 @@ const (
 	StateRangeRecording
 	StateCompleted
 	StateError
+	// The calibration file was saved but some servos didn't accept their limits
+	StateCalibrationPartial
 )
 
 func (s CalibrationState) String() string {
@@ -53,6 +56,8 @@ func (s CalibrationState) String() string {
 		return "completed"
 	case StateError:
 		return "error"
+	case StateCalibrationPartial:
+		return "calibration_partial"
 	default:
 		return "unknown"
 	}
@@ -69,6 +74,7 @@ type JointCalibrationData struct {
 	RecordedMin  int    `json:"recorded_min"`
 	RecordedMax  int    `json:"recorded_max"`
 	IsCompleted  bool   `json:"is_completed"`
+	DriveMode    int    `json:"drive_mode"`
 }
 
 // SO101CalibrationSensorConfig represents the configuration for the calibration sensor
@@ -81,6 +87,13 @@ type SO101CalibrationSensorConfig struct {
 	Port     string        `json:"port,omitempty"`
 	Baudrate int           `json:"baudrate,omitempty"`
 	Timeout  time.Duration `json:"timeout,omitempty"`
+
+	// Bus protocol, "sts" (default) or "scs", and servos that speak SCS on an STS bus
+	Protocol    string `json:"protocol,omitempty"`
+	SCSServoIDs []int  `json:"scs_servo_ids,omitempty"`
+
+	// How serial reads, writes and pings are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid
@@ -89,6 +102,13 @@ func (cfg *SO101CalibrationSensorConfig) Validate(path string) ([]string, []stri
 		return nil, nil, fmt.Errorf("must specify port for serial communication")
 	}
 
+	if err := validateBusProtocol(cfg.Protocol, cfg.SCSServoIDs); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Retry.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	// Default to all servos if not specified
 	if len(cfg.ServoIDs) == 0 {
 		cfg.ServoIDs = []int{1, 2, 3, 4, 5, 6} // All servos
@@ -106,8 +126,6 @@ func (cfg *SO101CalibrationSensorConfig) Validate(path string) ([]string, []stri
 
 // so101CalibrationSensor implements the calibration workflow as a sensor component
 type so101CalibrationSensor struct {
-	resource.AlwaysRebuild
-
 	name       resource.Name
 	logger     logging.Logger
 	cfg        *SO101CalibrationSensorConfig
@@ -122,6 +140,12 @@ type so101CalibrationSensor struct {
 	recordingStarted time.Time
 	lastInstruction  string
 
+	// Servos whose limits failed to write during save, with the error, for retry_failed_writes
+	failedWrites map[int]string
+
+	// Servo calibrated on its own by calibrate_joint, 0 when the workflow covers every servo
+	activeJoint int
+
 	// Range recording state
 	recordingActive bool
 	recordingCtx    context.Context
@@ -132,6 +156,8 @@ type so101CalibrationSensor struct {
 	setupInProgress  bool
 	currentSetupStep int
 	setupStatus      string
+	setupWizard      *motorSetupWizard // motor_setup_run_all in progress
+	pendingReset     *pendingFactoryReset
 }
 
 // NewSO101CalibrationSensor creates a new SO-101 calibration sensor
@@ -146,20 +172,17 @@ func NewSO101CalibrationSensor(
 		return nil, err
 	}
 
-	if conf.Baudrate == 0 {
-		conf.Baudrate = 1000000
-	}
-
-	if conf.CalibrationFile == "" {
-		conf.CalibrationFile = "so101_calibration.json"
-	}
+	applyCalibrationSensorDefaults(conf)
 
 	// Create controller configuration
 	controllerConfig := &SoArm101Config{
 		Port:            conf.Port,
 		Baudrate:        conf.Baudrate,
-		ServoIDs:        []int{1, 2, 3, 4, 5, 6}, // Controller handles all 6
+		ServoIDs:        conf.ServoIDs,
 		Timeout:         conf.Timeout,
+		Protocol:        conf.Protocol,
+		SCSServoIDs:     conf.SCSServoIDs,
+		Retry:           conf.Retry,
 		CalibrationFile: conf.CalibrationFile,
 		Logger:          logger,
 	}
@@ -184,29 +207,13 @@ func NewSO101CalibrationSensor(
 		6: "gripper",
 	}
 
-	// Default to all servos if not specified
-	if len(conf.ServoIDs) == 0 {
-		conf.ServoIDs = []int{1, 2, 3, 4, 5, 6} // All servos
-	}
-
-	// Initialize joint calibration data
-	joints := make(map[int]*JointCalibrationData)
-	for _, servoID := range conf.ServoIDs {
-		joints[servoID] = &JointCalibrationData{
-			ID:          servoID,
-			Name:        servoNames[servoID],
-			RecordedMin: math.MaxInt32,
-			RecordedMax: math.MinInt32,
-		}
-	}
-
 	cs := &so101CalibrationSensor{
 		name:            rawConf.ResourceName(),
 		logger:          logger,
 		cfg:             conf,
 		controller:      controller,
 		state:           StateIdle,
-		joints:          joints,
+		joints:          newJointCalibrationData(conf.ServoIDs, servoNames),
 		servoNames:      servoNames,
 		lastInstruction: "Ready to start calibration. Use DoCommand with 'start' to begin.",
 	}
@@ -215,6 +222,38 @@ func NewSO101CalibrationSensor(
 	return cs, nil
 }
 
+// applyCalibrationSensorDefaults fills in the baudrate, calibration file and servo IDs when
+// they aren't configured
+func applyCalibrationSensorDefaults(conf *SO101CalibrationSensorConfig) {
+	if conf.Baudrate == 0 {
+		conf.Baudrate = 1000000
+	}
+
+	if conf.CalibrationFile == "" {
+		conf.CalibrationFile = "so101_calibration.json"
+	}
+
+	// Default to all servos if not specified. Builds without a gripper list only 1-5, and
+	// every step below sticks to the configured servos.
+	if len(conf.ServoIDs) == 0 {
+		conf.ServoIDs = []int{1, 2, 3, 4, 5, 6} // All servos
+	}
+}
+
+// newJointCalibrationData returns empty calibration data for each servo
+func newJointCalibrationData(servoIDs []int, servoNames map[int]string) map[int]*JointCalibrationData {
+	joints := make(map[int]*JointCalibrationData)
+	for _, servoID := range servoIDs {
+		joints[servoID] = &JointCalibrationData{
+			ID:          servoID,
+			Name:        servoNames[servoID],
+			RecordedMin: math.MaxInt32,
+			RecordedMax: math.MinInt32,
+		}
+	}
+	return joints
+}
+
 // Name returns the sensor's name
 func (cs *so101CalibrationSensor) Name() resource.Name {
 	return cs.name
@@ -231,9 +270,16 @@ func (cs *so101CalibrationSensor) Readings(ctx context.Context, extra map[string
 		"servo_count":       len(cs.cfg.ServoIDs),
 	}
 
-	if cs.state == StateError {
+	if cs.state == StateError || cs.state == StateCalibrationPartial {
 		readings["error"] = cs.errorMsg
 	}
+	if len(cs.failedWrites) > 0 {
+		failed := map[string]any{}
+		for servoID, msg := range cs.failedWrites {
+			failed[cs.servoNames[servoID]] = msg
+		}
+		readings["failed_writes"] = failed
+	}
 
 	// Add joint-specific information
 	jointInfo := make(map[string]any)
@@ -247,9 +293,13 @@ func (cs *so101CalibrationSensor) Readings(ctx context.Context, extra map[string
 			"recorded_min":     joint.RecordedMin,
 			"recorded_max":     joint.RecordedMax,
 			"is_completed":     joint.IsCompleted,
+			"drive_mode":       joint.DriveMode,
 		}
 	}
 	readings["joints"] = jointInfo
+	if joint, ok := cs.joints[cs.activeJoint]; ok {
+		readings["active_joint"] = activeJointReading(joint)
+	}
 
 	// Add progress information
 	if cs.state == StateRangeRecording && cs.recordingActive {
@@ -262,26 +312,32 @@ func (cs *so101CalibrationSensor) Readings(ctx context.Context, extra map[string
 	availableCommands := []any{}
 	switch cs.state {
 	case StateIdle:
-		availableCommands = []any{"start"}
+		availableCommands = []any{"start", "calibrate_joint"}
 	case StateStarted:
 		availableCommands = []any{"set_homing", "abort"}
 	case StateHomingPosition:
-		availableCommands = []any{"start_range_recording", "abort"}
+		availableCommands = []any{"start_range_recording", "auto_range", "detect_drive_mode", "abort"}
 	case StateRangeRecording:
 		availableCommands = []any{"stop_range_recording", "abort"}
 	case StateCompleted:
-		availableCommands = []any{"save_calibration", "start"} // Allow restart
+		availableCommands = []any{"save_calibration", "validate_calibration", "start", "calibrate_joint"} // Allow restart
 	case StateError:
-		availableCommands = []any{"reset", "start"}
+		availableCommands = []any{"reset", "start", "calibrate_joint"}
+	case StateCalibrationPartial:
+		availableCommands = []any{"retry_failed_writes", "reset", "start", "calibrate_joint"}
 	}
 	readings["available_commands"] = availableCommands
 
 	// Add motor setup status
-	readings["motor_setup"] = map[string]any{
+	motorSetup := map[string]any{
 		"in_progress": cs.setupInProgress,
 		"step":        cs.currentSetupStep,
 		"status":      cs.setupStatus,
 	}
+	if cs.setupWizard != nil {
+		motorSetup["wizard"] = cs.setupWizard.reading()
+	}
+	readings["motor_setup"] = motorSetup
 
 	return readings, nil
 }
@@ -300,18 +356,33 @@ func (cs *so101CalibrationSensor) DoCommand(ctx context.Context, cmd map[string]
 	case "start":
 		return cs.startCalibration(ctx)
 
+	case "calibrate_joint":
+		return cs.calibrateJoint(ctx, cmd)
+
 	case "set_homing":
 		return cs.setHomingPosition(ctx)
 
+	case "detect_drive_mode":
+		return cs.detectDriveModes(ctx, cmd)
+
 	case "start_range_recording":
 		return cs.startRangeRecording(ctx)
 
+	case "auto_range":
+		return cs.autoRange(ctx, cmd)
+
 	case "stop_range_recording":
 		return cs.stopRangeRecording(ctx)
 
+	case "validate_calibration":
+		return cs.validateCalibration(ctx, cmd)
+
 	case "save_calibration":
 		return cs.saveCalibration(ctx)
 
+	case "retry_failed_writes":
+		return cs.retryFailedWrites(ctx)
+
 	case "abort":
 		return cs.abortCalibration(ctx)
 
@@ -334,9 +405,39 @@ func (cs *so101CalibrationSensor) DoCommand(ctx context.Context, cmd map[string]
 	case "motor_setup_scan_bus":
 		return cs.motorSetupScanBus(ctx)
 
+	case "dump_registers":
+		return dumpRegisters(ctx, cs.controller, cmd)
+
 	case "motor_setup_reset_status":
 		return cs.motorSetupResetStatus(ctx)
 
+	case "motor_setup_run_all":
+		return cs.motorSetupRunAll()
+
+	case "motor_setup_continue":
+		return cs.motorSetupContinue(ctx)
+
+	case "factory_reset_servo":
+		return cs.factoryResetServo(ctx, cmd)
+
+	case "get_calibration_json":
+		return cs.getCalibrationJSON()
+
+	case "set_calibration_json":
+		return cs.setCalibrationJSON(ctx, cmd)
+
+	case "list_calibration_backups":
+		return cs.calibrationBackups()
+
+	case "restore_calibration":
+		return cs.restoreCalibration(ctx, cmd)
+
+	case "replay_calibration":
+		return replayCalibrationCommand(cmd, cs.logger)
+
+	case "client_snippets":
+		return clientSnippetsResponse(SO101CalibrationSensorModel.String(), calibrationClientSnippets), nil
+
 	default:
 		return nil, fmt.Errorf("unknown command: %s", command)
 	}
@@ -344,10 +445,12 @@ func (cs *so101CalibrationSensor) DoCommand(ctx context.Context, cmd map[string]
 
 // startCalibration begins the calibration workflow
 func (cs *so101CalibrationSensor) startCalibration(ctx context.Context) (map[string]any, error) {
-	if cs.state != StateIdle && cs.state != StateCompleted && cs.state != StateError {
+	if cs.state != StateIdle && cs.state != StateCompleted && cs.state != StateError && cs.state != StateCalibrationPartial {
 		return map[string]any{"success": false},
 			fmt.Errorf("calibration already in progress (state: %s)", cs.state.String())
 	}
+	cs.failedWrites = nil
+	cs.activeJoint = 0
 
 	cs.logger.Info("Starting SO-101 calibration workflow")
 
@@ -357,14 +460,16 @@ func (cs *so101CalibrationSensor) startCalibration(ctx context.Context) (map[str
 		return map[string]any{"success": false}, err
 	}
 
-	// Reset joint data
-	for _, joint := range cs.joints {
+	// Reset joint data, keeping each joint's direction until detect_drive_mode says otherwise
+	calibration := cs.controller.GetCalibration()
+	for servoID, joint := range cs.joints {
 		joint.HomingOffset = 0
 		joint.RangeMin = 0
-		joint.RangeMax = 4095
+		joint.RangeMax = ServoMaxPosition
 		joint.RecordedMin = math.MaxInt32
 		joint.RecordedMax = math.MinInt32
 		joint.IsCompleted = false
+		joint.DriveMode = currentDriveMode(calibration, servoID)
 	}
 
 	cs.setState(StateStarted,
@@ -409,10 +514,11 @@ func (cs *so101CalibrationSensor) setHomingPosition(ctx context.Context) (map[st
 	}
 
 	cs.logger.Info("Setting homing positions...")
+	servoIDs := cs.workflowServoIDs()
 
 	// First, reset all calibration registers to factory defaults
 	cs.logger.Info("Resetting calibration registers to factory defaults...")
-	for _, servoID := range cs.cfg.ServoIDs {
+	for _, servoID := range servoIDs {
 		if err := cs.resetCalibrationRegisters(ctx, servoID); err != nil {
 			cs.setState(StateError, fmt.Sprintf("Failed to reset calibration registers for servo %d: %v", servoID, err))
 			return map[string]any{"success": false}, err
@@ -455,27 +561,19 @@ func (cs *so101CalibrationSensor) setHomingPosition(ctx context.Context) (map[st
 	// 	}
 	// 	positions[servoID] = raw
 	// }
-	positionsData, err := cs.controller.bus.SyncRead(ctx, feetech.RegPresentPosition.Address, len(cs.cfg.ServoIDs), cs.cfg.ServoIDs)
+	rawPositions, err := cs.controller.ReadRawPositions(ctx, servoIDs)
 	if err != nil {
 		cs.setState(StateError, fmt.Sprintf("Failed to read servo positions: %v", err))
 		return map[string]any{"success": false}, err
 	}
-	proto := cs.controller.bus.Protocol()
-	rawPositions := make(map[int]int, len(cs.cfg.ServoIDs))
-	for _, id := range cs.cfg.ServoIDs {
-		if d, ok := positionsData[id]; ok {
-			rawPositions[id] = int(proto.DecodeWord(d))
-		}
-	}
 
 	// Calculate homing offsets to center the range
 	homingOffsets := make(map[string]any)
-	for _, servoID := range cs.cfg.ServoIDs {
+	for _, servoID := range servoIDs {
 		currentRawPos := int(rawPositions[servoID])
 
 		// Calculate offset to make current position the center (2047.5 for 12-bit encoder)
-		targetCenter := 2047
-		homingOffset := currentRawPos - targetCenter
+		homingOffset := currentRawPos - homingCenterPosition
 
 		homingOffsets[strconv.Itoa(servoID)] = homingOffset
 		cs.joints[servoID].HomingOffset = homingOffset
@@ -487,7 +585,7 @@ func (cs *so101CalibrationSensor) setHomingPosition(ctx context.Context) (map[st
 
 	// Write homing offsets to servo registers
 	cs.logger.Info("Writing homing offsets to servo registers...")
-	for _, servoID := range cs.cfg.ServoIDs {
+	for _, servoID := range servoIDs {
 		homingOffset := homingOffsets[strconv.Itoa(servoID)]
 		if err := cs.writeHomingOffset(ctx, servoID, homingOffset.(int)); err != nil {
 			cs.setState(StateError, fmt.Sprintf("Failed to write homing offset to servo %d: %v", servoID, err))
@@ -496,8 +594,14 @@ func (cs *so101CalibrationSensor) setHomingPosition(ctx context.Context) (map[st
 		cs.logger.Debugf("Successfully wrote homing offset %d to servo %d", homingOffset, servoID)
 	}
 
-	cs.setState(StateHomingPosition,
-		"Homing positions set. Now use 'start_range_recording' command, then move all joints through their entire ranges of motion.")
+	if cs.activeJoint != 0 {
+		cs.setState(StateHomingPosition, fmt.Sprintf(
+			"Homing position set for %s. Now use 'start_range_recording' command, then move it through its entire range of motion, or use 'auto_range' to find the range automatically.",
+			cs.servoNames[cs.activeJoint]))
+	} else {
+		cs.setState(StateHomingPosition,
+			"Homing positions set. Now use 'start_range_recording' command, then move all joints through their entire ranges of motion, or use 'auto_range' to find the ranges automatically.")
+	}
 
 	return map[string]any{
 		"success":        true,
@@ -522,11 +626,17 @@ func (cs *so101CalibrationSensor) startRangeRecording(_ context.Context) (map[st
 	cs.recordingStarted = time.Now()
 	cs.positionHistory = []map[int]int{}
 
-	cs.setState(StateRangeRecording,
-		"Recording range of motion. Move all joints through their full ranges. Use 'stop_range_recording' when complete.")
+	if cs.activeJoint != 0 {
+		cs.setState(StateRangeRecording, fmt.Sprintf(
+			"Recording range of motion. Move %s through its full range. Use 'stop_range_recording' when complete.",
+			cs.servoNames[cs.activeJoint]))
+	} else {
+		cs.setState(StateRangeRecording,
+			"Recording range of motion. Move all joints through their full ranges. Use 'stop_range_recording' when complete.")
+	}
 
 	// Start background recording goroutine with dedicated context
-	go cs.recordPositions(cs.recordingCtx)
+	go cs.recordPositions(cs.recordingCtx, cs.workflowServoIDs())
 
 	return map[string]any{
 		"success": true,
@@ -536,7 +646,7 @@ func (cs *so101CalibrationSensor) startRangeRecording(_ context.Context) (map[st
 }
 
 // recordPositions continuously records servo positions in the background
-func (cs *so101CalibrationSensor) recordPositions(recordingCtx context.Context) {
+func (cs *so101CalibrationSensor) recordPositions(recordingCtx context.Context, servoIDs []int) {
 	ticker := time.NewTicker(10 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -556,19 +666,12 @@ func (cs *so101CalibrationSensor) recordPositions(recordingCtx context.Context)
 			}
 			cs.mu.RUnlock()
 
-			// Read current positions for all configured servos
-			positionsData, err := cs.controller.bus.SyncRead(recordingCtx, feetech.RegPresentPosition.Address, len(cs.cfg.ServoIDs), cs.cfg.ServoIDs)
+			// Read current positions for the servos being calibrated
+			rawPositions, err := cs.controller.ReadRawPositions(recordingCtx, servoIDs)
 			if err != nil {
 				cs.logger.Errorf("Failed to read positions during recording: %v", err)
 				continue
 			}
-			proto := cs.controller.bus.Protocol()
-			rawPositions := make(map[int]int, len(cs.cfg.ServoIDs))
-			for _, id := range cs.cfg.ServoIDs {
-				if d, ok := positionsData[id]; ok {
-					rawPositions[id] = int(proto.DecodeWord(d))
-				}
-			}
 
 			// radianPositions, err := cs.controller.GetJointPositionsForServos(recordingCtx, cs.cfg.ServoIDs)
 			// if err != nil {
@@ -652,7 +755,8 @@ func (cs *so101CalibrationSensor) stopRangeRecording(_ context.Context) (map[str
 	rangeData := make(map[string]any)
 	allValid := true
 
-	for servoID, joint := range cs.joints {
+	for _, servoID := range cs.workflowServoIDs() {
+		joint := cs.joints[servoID]
 		if joint.RecordedMin >= joint.RecordedMax {
 			cs.logger.Errorf("Invalid range for servo %d (%s): min=%d, max=%d",
 				servoID, joint.Name, joint.RecordedMin, joint.RecordedMax)
@@ -701,13 +805,19 @@ func (cs *so101CalibrationSensor) saveCalibration(ctx context.Context) (map[stri
 
 	cs.logger.Info("Saving calibration to servos and file...")
 
-	// Create calibration structure
+	// Create calibration structure. A single joint is saved alongside the calibration the
+	// other joints are using.
 	fullCalibration := SO101FullCalibration{}
+	if cs.activeJoint != 0 {
+		fullCalibration = cs.controller.GetCalibration()
+	}
 
-	for servoID, joint := range cs.joints {
+	servoIDs := cs.workflowServoIDs()
+	for _, servoID := range servoIDs {
+		joint := cs.joints[servoID]
 		motorCal := &MotorCalibration{
 			ID:           servoID,
-			DriveMode:    0, // Normal direction
+			DriveMode:    joint.DriveMode,
 			HomingOffset: joint.HomingOffset,
 			RangeMin:     joint.RangeMin,
 			RangeMax:     joint.RangeMax,
@@ -736,41 +846,102 @@ func (cs *so101CalibrationSensor) saveCalibration(ctx context.Context) (map[stri
 		}
 	}
 
-	// Save calibration to file
-	if err := SaveFullCalibrationToFile(cs.cfg.CalibrationFile, fullCalibration); err != nil {
+	// Save calibration to file, keeping the one it replaces as a backup
+	backupID, err := saveCalibrationWithBackup(cs.cfg.CalibrationFile, fullCalibration)
+	if err != nil {
 		cs.setState(StateError, fmt.Sprintf("Failed to save calibration file: %v", err))
 		return map[string]any{"success": false}, err
 	}
 
-	// Apply calibration to servos (write to registers)
+	// Apply calibration to servos (write to registers). Every servo is attempted so a single
+	// failure leaves a known set of servos to retry rather than an unknown mix.
 	cs.logger.Info("Writing calibration data to servo registers...")
-	for servoID, joint := range cs.joints {
+	if partial := cs.writePositionLimits(ctx, servoIDs); partial != nil {
+		return partial, nil
+	}
+
+	if cs.activeJoint != 0 {
+		cs.setState(StateIdle, cs.nextJointInstruction())
+		cs.activeJoint = 0
+	} else {
+		cs.setState(StateIdle, "Calibration completed and saved successfully. Ready for new calibration.")
+	}
+
+	return map[string]any{
+		"success":           true,
+		"state":             cs.state.String(),
+		"calibration_file":  cs.cfg.CalibrationFile,
+		"replaced_backup":   backupID,
+		"joints_calibrated": len(servoIDs),
+		"message":           cs.lastInstruction,
+	}, nil
+}
+
+// writePositionLimits writes the recorded limits to each servo, remembering which writes
+// failed. It returns a failure response and enters calibration_partial if any did.
+func (cs *so101CalibrationSensor) writePositionLimits(ctx context.Context, servoIDs []int) map[string]any {
+	failed := map[int]string{}
+	for _, servoID := range servoIDs {
+		joint := cs.joints[servoID]
 		cs.logger.Infof("Writing to servo %d (%s): min_limit=%d, max_limit=%d",
 			servoID, joint.Name, joint.RangeMin, joint.RangeMax)
 
-		// Write min position limit
 		if err := cs.writeMinPositionLimit(ctx, servoID, joint.RangeMin); err != nil {
-			cs.setState(StateError, fmt.Sprintf("Failed to write min position limit to servo %d: %v", servoID, err))
-			return map[string]any{"success": false}, err
+			failed[servoID] = fmt.Sprintf("min position limit: %v", err)
+			continue
 		}
-
-		// Write max position limit
 		if err := cs.writeMaxPositionLimit(ctx, servoID, joint.RangeMax); err != nil {
-			cs.setState(StateError, fmt.Sprintf("Failed to write max position limit to servo %d: %v", servoID, err))
-			return map[string]any{"success": false}, err
+			failed[servoID] = fmt.Sprintf("max position limit: %v", err)
+			continue
 		}
-
 		cs.logger.Debugf("Successfully wrote position limits to servo %d", servoID)
 	}
 
-	cs.setState(StateIdle, "Calibration completed and saved successfully. Ready for new calibration.")
+	if len(failed) == 0 {
+		cs.failedWrites = nil
+		return nil
+	}
+	cs.failedWrites = failed
+	cs.state = StateCalibrationPartial
+	cs.errorMsg = fmt.Sprintf("calibration_partial: the calibration file was saved but %d servo(s) did not accept their position limits", len(failed))
+	cs.lastInstruction = "Check the connection to the failed servos, then use 'retry_failed_writes'."
+	cs.logger.Errorf("Calibration error: %s: %v", cs.errorMsg, failed)
 
+	names := []any{}
+	for servoID := range failed {
+		names = append(names, cs.servoNames[servoID])
+	}
 	return map[string]any{
-		"success":           true,
-		"state":             cs.state.String(),
-		"calibration_file":  cs.cfg.CalibrationFile,
-		"joints_calibrated": len(cs.joints),
-		"message":           cs.lastInstruction,
+		"success":       false,
+		"state":         cs.state.String(),
+		"error":         cs.errorMsg,
+		"failed_joints": names,
+		"message":       cs.lastInstruction,
+	}
+}
+
+// retryFailedWrites rewrites the position limits to the servos that failed during save
+func (cs *so101CalibrationSensor) retryFailedWrites(ctx context.Context) (map[string]any, error) {
+	if cs.state != StateCalibrationPartial {
+		return map[string]any{"success": false},
+			fmt.Errorf("no failed calibration writes to retry (state: %s)", cs.state.String())
+	}
+
+	servoIDs := make([]int, 0, len(cs.failedWrites))
+	for servoID := range cs.failedWrites {
+		servoIDs = append(servoIDs, servoID)
+	}
+	sort.Ints(servoIDs)
+	if partial := cs.writePositionLimits(ctx, servoIDs); partial != nil {
+		return partial, nil
+	}
+
+	cs.setState(StateIdle, "Calibration completed and saved successfully. Ready for new calibration.")
+	return map[string]any{
+		"success":        true,
+		"state":          cs.state.String(),
+		"servos_written": len(servoIDs),
+		"message":        cs.lastInstruction,
 	}, nil
 }
 
@@ -784,6 +955,7 @@ func (cs *so101CalibrationSensor) abortCalibration(_ context.Context) (map[strin
 		cs.recordingCancel = nil
 	}
 	cs.recordingActive = false
+	cs.activeJoint = 0
 
 	cs.setState(StateIdle, "Calibration aborted. Ready to start new calibration.")
 
@@ -805,13 +977,15 @@ func (cs *so101CalibrationSensor) resetCalibration(_ context.Context) (map[strin
 	}
 	cs.recordingActive = false
 	cs.errorMsg = ""
+	cs.failedWrites = nil
+	cs.activeJoint = 0
 	cs.positionHistory = []map[int]int{}
 
 	// Reset all joint data
 	for _, joint := range cs.joints {
 		joint.HomingOffset = 0
 		joint.RangeMin = 0
-		joint.RangeMax = 4095
+		joint.RangeMax = ServoMaxPosition
 		joint.RecordedMin = math.MaxInt32
 		joint.RecordedMax = math.MinInt32
 		joint.IsCompleted = false
@@ -828,14 +1002,32 @@ func (cs *so101CalibrationSensor) resetCalibration(_ context.Context) (map[strin
 
 // getCurrentPositions returns current servo positions
 func (cs *so101CalibrationSensor) getCurrentPositions(ctx context.Context) (map[string]any, error) {
-	positions, err := cs.controller.GetJointPositionsForServos(ctx, cs.cfg.ServoIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read positions: %w", err)
+	// Read only the configured servos, the shared group also holds a gripper that may not exist
+	radians, failed := cs.controller.ReadJointPositionsPartial(ctx, cs.cfg.ServoIDs)
+	for _, servoID := range cs.cfg.ServoIDs {
+		if err, ok := failed[servoID]; ok {
+			return nil, fmt.Errorf("failed to read position of servo %d: %w", servoID, err)
+		}
+	}
+	positions := make([]float64, len(cs.cfg.ServoIDs))
+	for i, servoID := range cs.cfg.ServoIDs {
+		positions[i] = radians[servoID]
 	}
 
 	positionData := make(map[string]any)
 	for i, servoID := range cs.cfg.ServoIDs {
-		rawPos := int(positions[i] * 4095 / (2 * math.Pi))
+		// Undo the calibration to recover the raw servo position
+		normalized := RadiansToDegrees(positions[i])
+		if isGripperServo(servoID) {
+			normalized = GripperRadiansToPercent(positions[i])
+		}
+		rawPos := 0
+		if cal := cs.controller.getCalibrationForServo(servoID); cal != nil {
+			var err error
+			if rawPos, err = cal.Denormalize(normalized); err != nil {
+				return nil, fmt.Errorf("failed to convert position for servo %d: %w", servoID, err)
+			}
+		}
 
 		joint := cs.joints[servoID]
 		joint.CurrentPos = rawPos
@@ -844,7 +1036,7 @@ func (cs *so101CalibrationSensor) getCurrentPositions(ctx context.Context) (map[
 			"servo_id":     servoID,
 			"raw_position": rawPos,
 			"radians":      positions[i],
-			"degrees":      positions[i] * 180 / math.Pi,
+			"degrees":      RadiansToDegrees(positions[i]),
 		}
 	}
 
@@ -875,21 +1067,13 @@ func (cs *so101CalibrationSensor) writeHomingOffset(ctx context.Context, servoID
 
 // writeMinPositionLimit writes the minimum position limit to a servo's register
 func (cs *so101CalibrationSensor) writeMinPositionLimit(ctx context.Context, servoID, minLimit int) error {
-	data := []byte{
-		byte(minLimit & 0xFF),
-		byte((minLimit >> 8) & 0xFF),
-	}
-
+	data := cs.controller.protocolFor(servoID).EncodeWord(uint16(minLimit))
 	return cs.controller.WriteServoRegister(ctx, servoID, "min_angle_limit", data)
 }
 
 // writeMaxPositionLimit writes the maximum position limit to a servo's register
 func (cs *so101CalibrationSensor) writeMaxPositionLimit(ctx context.Context, servoID, maxLimit int) error {
-	data := []byte{
-		byte(maxLimit & 0xFF),
-		byte((maxLimit >> 8) & 0xFF),
-	}
-
+	data := cs.controller.protocolFor(servoID).EncodeWord(uint16(maxLimit))
 	return cs.controller.WriteServoRegister(ctx, servoID, "max_angle_limit", data)
 }
 
@@ -1022,17 +1206,14 @@ func (cs *so101CalibrationSensor) motorSetupVerify(ctx context.Context) (map[str
 	cs.setupStatus = "Verifying SO-101 motor configuration..."
 	cs.logger.Infof("Motor setup: %s", cs.setupStatus)
 
-	// Expected motor configuration
-	expectedMotors := map[int]string{
-		1: "shoulder_pan",
-		2: "shoulder_lift",
-		3: "elbow_flex",
-		4: "wrist_flex",
-		5: "wrist_roll",
-		6: "gripper",
+	// Expected motor configuration, only the configured servos so gripper-less builds pass
+	expectedMotors := make(map[int]string, len(cs.cfg.ServoIDs))
+	for _, id := range cs.cfg.ServoIDs {
+		expectedMotors[id] = cs.servoNames[id]
 	}
 
 	results := make(map[string]any)
+	versions := make(map[int]servoVersion)
 	allGood := true
 
 	// Check each expected motor
@@ -1056,11 +1237,21 @@ func (cs *so101CalibrationSensor) motorSetupVerify(ctx context.Context) (map[str
 						"error":  err.Error(),
 					}
 				} else {
-					results[name] = map[string]any{
+					result := map[string]any{
 						"id":     id,
 						"status": "ok",
 						"model":  servo.Model().Name,
 					}
+					if v, err := cs.controller.ReadServoVersion(ctx, id); err != nil {
+						result["version_error"] = err.Error()
+					} else {
+						versions[id] = v
+						result["firmware"] = v.firmware()
+						result["firmware_major"] = v.FirmwareMajor
+						result["firmware_minor"] = v.FirmwareMinor
+						result["model_number"] = v.ModelNumber
+					}
+					results[name] = result
 				}
 			}
 		} else {
@@ -1081,10 +1272,16 @@ func (cs *so101CalibrationSensor) motorSetupVerify(ctx context.Context) (map[str
 
 	cs.logger.Infof("Motor setup: %s", cs.setupStatus)
 
+	mixed := mixedFirmware(versions)
+	if mixed {
+		cs.logger.Warnf("Motor setup: motors are on different firmware versions, check the firmware of each motor in the results")
+	}
+
 	return map[string]any{
-		"success": allGood,
-		"motors":  results,
-		"status":  cs.setupStatus,
+		"success":        allGood,
+		"motors":         results,
+		"mixed_firmware": mixed,
+		"status":         cs.setupStatus,
 	}, nil
 }
 
@@ -1143,6 +1340,8 @@ func (cs *so101CalibrationSensor) motorSetupScanBus(ctx context.Context) (map[st
 func (cs *so101CalibrationSensor) motorSetupResetStatus(ctx context.Context) (map[string]any, error) {
 	cs.setupInProgress = false
 	cs.currentSetupStep = 0
+	cs.setupWizard = nil
+	cs.pendingReset = nil
 	cs.setupStatus = "Motor setup status reset"
 
 	return map[string]any{
@@ -1230,7 +1429,7 @@ func (cs *so101CalibrationSensor) Close(ctx context.Context) error {
 	cs.recordingActive = false
 
 	if cs.controller != nil {
-		ReleaseSharedController()
+		ReleaseSharedControllerForPort(cs.cfg.Port, cs.controller)
 	}
 
 	return nil
@@ -0,0 +1,226 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Calibration backups. Before the calibration sensor overwrites the calibration file, the
+// current file is copied to a calibration_backups directory next to it, stamped with the time
+// it was replaced. restore_calibration puts one of them back.
+const (
+	calibrationBackupDir        = "calibration_backups"
+	calibrationBackupTimeFormat = "20060102T150405.000Z"
+	maxCalibrationBackups       = 20
+)
+
+// calibrationBackup is one saved copy of a calibration file
+type calibrationBackup struct {
+	ID        string
+	Path      string
+	CreatedAt time.Time
+}
+
+// calibrationBackupPrefix is what every backup of a calibration file's name starts with, so
+// several calibration files can share a directory
+func calibrationBackupPrefix(calibrationFile string) string {
+	base := filepath.Base(calibrationFile)
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "_"
+}
+
+// backupCalibrationFile copies the calibration file into the backup directory and prunes the
+// oldest backups past maxCalibrationBackups. It returns the new backup's ID, or "" when there
+// is no file to back up yet.
+func backupCalibrationFile(calibrationFile string, now time.Time) (string, error) {
+	data, err := os.ReadFile(calibrationFile)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read calibration file for backup: %w", err)
+	}
+
+	dir := filepath.Join(filepath.Dir(calibrationFile), calibrationBackupDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create calibration backup directory: %w", err)
+	}
+	id := now.UTC().Format(calibrationBackupTimeFormat)
+	path := filepath.Join(dir, calibrationBackupPrefix(calibrationFile)+id+calibrationProfileExt)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write calibration backup: %w", err)
+	}
+
+	backups, err := listCalibrationBackups(calibrationFile)
+	if err != nil {
+		return id, err
+	}
+	for _, old := range backups[min(len(backups), maxCalibrationBackups):] {
+		if err := os.Remove(old.Path); err != nil {
+			return id, fmt.Errorf("failed to prune calibration backup %s: %w", old.ID, err)
+		}
+	}
+	return id, nil
+}
+
+// listCalibrationBackups returns the backups of a calibration file, newest first
+func listCalibrationBackups(calibrationFile string) ([]calibrationBackup, error) {
+	dir := filepath.Join(filepath.Dir(calibrationFile), calibrationBackupDir)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calibration backups: %w", err)
+	}
+
+	prefix := calibrationBackupPrefix(calibrationFile)
+	var backups []calibrationBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || filepath.Ext(name) != calibrationProfileExt {
+			continue
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(name, prefix), calibrationProfileExt)
+		createdAt, err := time.Parse(calibrationBackupTimeFormat, id)
+		if err != nil {
+			continue // another calibration file whose name shares the prefix
+		}
+		backups = append(backups, calibrationBackup{ID: id, Path: filepath.Join(dir, name), CreatedAt: createdAt})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// saveCalibrationWithBackup backs up the calibration file, then overwrites it
+func saveCalibrationWithBackup(calibrationFile string, calibration SO101FullCalibration) (string, error) {
+	backupID, err := backupCalibrationFile(calibrationFile, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if err := SaveFullCalibrationToFile(calibrationFile, calibration); err != nil {
+		return backupID, err
+	}
+	return backupID, nil
+}
+
+// calibrationBackups handles the list_calibration_backups DoCommand
+func (cs *so101CalibrationSensor) calibrationBackups() (map[string]any, error) {
+	backups, err := listCalibrationBackups(cs.cfg.CalibrationFile)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]any, 0, len(backups))
+	for _, backup := range backups {
+		list = append(list, map[string]any{
+			"id":         backup.ID,
+			"path":       backup.Path,
+			"created_at": backup.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return map[string]any{
+		"backups":          list,
+		"calibration_file": cs.cfg.CalibrationFile,
+	}, nil
+}
+
+// restoreCalibration handles the restore_calibration DoCommand. The backup named by "backup",
+// or the newest one, replaces the calibration file, which is itself backed up first so the
+// restore can be undone. The calibration is applied to every component on the port and its
+// homing offsets and position limits are written back to the servos, undoing what a
+// calibration session wrote to them.
+func (cs *so101CalibrationSensor) restoreCalibration(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.calibrationInProgress() {
+		return map[string]any{"success": false},
+			fmt.Errorf("calibration in progress (state: %s), abort it before restoring a calibration", cs.state.String())
+	}
+
+	backups, err := listCalibrationBackups(cs.cfg.CalibrationFile)
+	if err != nil {
+		return map[string]any{"success": false}, err
+	}
+	if len(backups) == 0 {
+		return map[string]any{"success": false}, fmt.Errorf("no calibration backups for %s", cs.cfg.CalibrationFile)
+	}
+	backup := backups[0]
+	if id, ok := cmd["backup"].(string); ok {
+		i := slices.IndexFunc(backups, func(b calibrationBackup) bool { return b.ID == id })
+		if i < 0 {
+			return map[string]any{"success": false}, fmt.Errorf("no calibration backup %q, use 'list_calibration_backups'", id)
+		}
+		backup = backups[i]
+	}
+
+	calibration, err := LoadFullCalibrationFromFile(backup.Path, cs.logger)
+	if err != nil {
+		return map[string]any{"success": false}, fmt.Errorf("failed to load calibration backup %s: %w", backup.ID, err)
+	}
+	replacedID, err := saveCalibrationWithBackup(cs.cfg.CalibrationFile, calibration)
+	if err != nil {
+		return map[string]any{"success": false}, err
+	}
+	if err := ApplySharedCalibration(cs.cfg.Port, calibration); err != nil {
+		return map[string]any{"success": false}, fmt.Errorf("calibration restored to %s but not applied: %w", cs.cfg.CalibrationFile, err)
+	}
+
+	failed := map[string]any{}
+	for _, servoID := range cs.cfg.ServoIDs {
+		motorCal := calibration.GetMotorCalibrationByID(servoID)
+		if motorCal == nil {
+			continue
+		}
+		if err := cs.writeServoCalibration(ctx, servoID, motorCal); err != nil {
+			failed[cs.servoNames[servoID]] = err.Error()
+		}
+	}
+
+	response := map[string]any{
+		"success":          len(failed) == 0,
+		"restored":         backup.ID,
+		"replaced_backup":  replacedID,
+		"calibration_file": cs.cfg.CalibrationFile,
+	}
+	if len(failed) > 0 {
+		response["failed_joints"] = failed
+		response["error"] = "the calibration file was restored but some servos did not accept their calibration registers"
+		cs.logger.Errorf("Calibration backup %s restored, but servo writes failed: %v", backup.ID, failed)
+		return response, nil
+	}
+	cs.logger.Infof("Restored calibration backup %s to %s", backup.ID, cs.cfg.CalibrationFile)
+	return response, nil
+}
+
+// writeServoCalibration writes a joint's homing offset and position limits to its servo. The
+// servo's torque is disabled first, since the offset moves where it thinks it is.
+func (cs *so101CalibrationSensor) writeServoCalibration(ctx context.Context, servoID int, motorCal *MotorCalibration) error {
+	if err := cs.controller.WriteServoRegister(ctx, servoID, "torque_enable", []byte{0}); err != nil {
+		return fmt.Errorf("failed to disable torque: %w", err)
+	}
+	if err := cs.controller.WriteServoRegister(ctx, servoID, "position_offset", encodeHomingOffset(motorCal.HomingOffset, cs.controller.protocolFor(servoID))); err != nil {
+		return fmt.Errorf("failed to write homing offset: %w", err)
+	}
+	if err := cs.writeMinPositionLimit(ctx, servoID, motorCal.RangeMin); err != nil {
+		return fmt.Errorf("failed to write min position limit: %w", err)
+	}
+	if err := cs.writeMaxPositionLimit(ctx, servoID, motorCal.RangeMax); err != nil {
+		return fmt.Errorf("failed to write max position limit: %w", err)
+	}
+	return nil
+}
+
+// encodeHomingOffset encodes a homing offset as the servo stores it, sign-magnitude with the
+// sign in bit 11, the reverse of readInt16Register
+func encodeHomingOffset(offset int, proto *feetech.Protocol) []byte {
+	raw := offset
+	if offset < 0 {
+		raw = -offset | 1<<11
+	}
+	return proto.EncodeWord(uint16(raw))
+}
@@ -0,0 +1,70 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestCalibrationBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "so101_calibration.json")
+
+	// Nothing to back up before the first save
+	id, err := saveCalibrationWithBackup(path, DefaultSO101FullCalibration)
+	assert.NoError(t, err)
+	assert.Empty(t, id)
+
+	calibration := DefaultSO101FullCalibration
+	elbow := *calibration.ElbowFlex
+	elbow.RangeMin += 100
+	calibration.ElbowFlex = &elbow
+	id, err = saveCalibrationWithBackup(path, calibration)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	backups, err := listCalibrationBackups(path)
+	assert.NoError(t, err)
+	if !assert.Len(t, backups, 1) {
+		return
+	}
+	assert.Equal(t, id, backups[0].ID)
+	restored, err := LoadFullCalibrationFromFile(backups[0].Path, logging.NewTestLogger(t))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultSO101FullCalibration.ElbowFlex.RangeMin, restored.ElbowFlex.RangeMin)
+
+	// Another calibration file in the same directory keeps its own backups
+	other := filepath.Join(filepath.Dir(path), "so101_calibration_leader.json")
+	assert.NoError(t, SaveFullCalibrationToFile(other, DefaultSO101FullCalibration))
+	_, err = backupCalibrationFile(other, time.Now())
+	assert.NoError(t, err)
+	backups, err = listCalibrationBackups(path)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+
+	// Only the newest are kept, newest first
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range maxCalibrationBackups + 5 {
+		_, err := backupCalibrationFile(path, start.Add(time.Duration(i)*time.Minute))
+		assert.NoError(t, err)
+	}
+	backups, err = listCalibrationBackups(path)
+	assert.NoError(t, err)
+	assert.Len(t, backups, maxCalibrationBackups)
+	assert.True(t, backups[0].CreatedAt.After(backups[1].CreatedAt))
+	_, err = os.Stat(filepath.Join(filepath.Dir(path), calibrationBackupDir))
+	assert.NoError(t, err)
+}
+
+func TestEncodeHomingOffset(t *testing.T) {
+	proto := feetech.NewProtocol(feetech.ProtocolSTS)
+	assert.Equal(t, []byte{0x2c, 0x01}, encodeHomingOffset(300, proto))
+	assert.Equal(t, []byte{0x2c, 0x09}, encodeHomingOffset(-300, proto))
+
+	// SCS servos store words big-endian
+	assert.Equal(t, []byte{0x09, 0x2c}, encodeHomingOffset(-300, scsProtocol))
+}
@@ -0,0 +1,95 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Drive mode detection. A mirrored build, such as a left-handed leader arm, can have servos
+// mounted so their raw position falls as the joint moves in its positive direction. After
+// set_homing every joint reads about homingCenterPosition, so nudging each one toward its
+// positive direction and sending detect_drive_mode shows which way each servo counts.
+const (
+	homingCenterPosition      = 2047
+	driveModeMinMoveSteps     = 100
+	driveModeNormal           = 0
+	driveModeInverted         = 1
+	driveModeDirectionUnknown = -1
+)
+
+// currentDriveMode returns a servo's drive mode in a calibration, normal if it has no entry
+func currentDriveMode(calibration SO101FullCalibration, servoID int) int {
+	if motorCal := calibration.GetMotorCalibrationByID(servoID); motorCal != nil {
+		return motorCal.DriveMode
+	}
+	return driveModeNormal
+}
+
+// driveModeForMove returns the drive mode shown by a joint moved from the homing center to
+// raw, or driveModeDirectionUnknown if it moved less than minMove steps
+func driveModeForMove(raw, minMove int) int {
+	delta := raw - homingCenterPosition
+	switch {
+	case delta >= minMove:
+		return driveModeNormal
+	case delta <= -minMove:
+		return driveModeInverted
+	default:
+		return driveModeDirectionUnknown
+	}
+}
+
+// detectDriveModes handles the detect_drive_mode DoCommand. Joints that weren't moved far
+// enough keep the drive mode they had.
+func (cs *so101CalibrationSensor) detectDriveModes(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.state != StateHomingPosition {
+		return map[string]any{"success": false},
+			fmt.Errorf("must set homing position first (current state: %s)", cs.state.String())
+	}
+	minMove := driveModeMinMoveSteps
+	if v, ok := cmd["min_move_steps"].(float64); ok {
+		if v < 10 || v > 1000 {
+			return map[string]any{"success": false}, fmt.Errorf("min_move_steps must be between 10 and 1000, got %.0f", v)
+		}
+		minMove = int(v)
+	}
+
+	servoIDs := cs.workflowServoIDs()
+	rawPositions, err := cs.controller.ReadRawPositions(ctx, servoIDs)
+	if err != nil {
+		return map[string]any{"success": false}, fmt.Errorf("failed to read servo positions: %w", err)
+	}
+
+	driveModes := make(map[string]any)
+	var unmoved []any
+	for _, servoID := range servoIDs {
+		joint := cs.joints[servoID]
+		joint.CurrentPos = rawPositions[servoID]
+		mode := driveModeForMove(joint.CurrentPos, minMove)
+		if mode == driveModeDirectionUnknown {
+			unmoved = append(unmoved, joint.Name)
+		} else {
+			joint.DriveMode = mode
+		}
+		driveModes[joint.Name] = joint.DriveMode
+		cs.logger.Infof("Servo %d (%s): moved %d steps from center, drive_mode=%d",
+			servoID, joint.Name, joint.CurrentPos-homingCenterPosition, joint.DriveMode)
+	}
+
+	instruction := "Drive modes detected. Return the joints to the middle, then use 'start_range_recording' or 'auto_range'."
+	if len(unmoved) > 0 {
+		instruction = fmt.Sprintf("%d joint(s) weren't moved far enough to tell and keep their drive mode. Move them further and use 'detect_drive_mode' again, or continue with 'start_range_recording' or 'auto_range'.", len(unmoved))
+	}
+	cs.setState(StateHomingPosition, instruction)
+
+	response := map[string]any{
+		"success":     true,
+		"state":       cs.state.String(),
+		"drive_modes": driveModes,
+		"message":     cs.lastInstruction,
+	}
+	if len(unmoved) > 0 {
+		response["undetected"] = unmoved
+	}
+	return response, nil
+}
@@ -0,0 +1,95 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// workflowServoIDs returns the servos the calibration workflow is working on, the joint given
+// to calibrate_joint or else every configured servo
+func (cs *so101CalibrationSensor) workflowServoIDs() []int {
+	if cs.activeJoint != 0 {
+		return []int{cs.activeJoint}
+	}
+	return cs.cfg.ServoIDs
+}
+
+// calibrateJoint handles the calibrate_joint DoCommand. It starts the calibration workflow for
+// one servo, so set_homing, range recording and save_calibration only touch that joint and the
+// rest of the arm keeps its calibration and torque.
+func (cs *so101CalibrationSensor) calibrateJoint(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.calibrationInProgress() {
+		return map[string]any{"success": false},
+			fmt.Errorf("calibration already in progress (state: %s)", cs.state.String())
+	}
+	id, ok := cmd["servo_id"].(float64)
+	if !ok {
+		return map[string]any{"success": false}, fmt.Errorf("calibrate_joint requires 'servo_id' number parameter")
+	}
+	servoID := int(id)
+	if !slices.Contains(cs.cfg.ServoIDs, servoID) {
+		return map[string]any{"success": false},
+			fmt.Errorf("servo %d is not one of the configured servos %v", servoID, cs.cfg.ServoIDs)
+	}
+	cs.failedWrites = nil
+
+	name := cs.servoNames[servoID]
+	cs.logger.Infof("Starting calibration of servo %d (%s)", servoID, name)
+
+	// Only this joint goes limp
+	if err := cs.controller.WriteServoRegister(ctx, servoID, "torque_enable", []byte{0}); err != nil {
+		cs.setState(StateError, fmt.Sprintf("Failed to disable torque on %s: %v", name, err))
+		return map[string]any{"success": false}, err
+	}
+
+	joint := cs.joints[servoID]
+	joint.HomingOffset = 0
+	joint.RangeMin = 0
+	joint.RangeMax = ServoMaxPosition
+	joint.RecordedMin = math.MaxInt32
+	joint.RecordedMax = math.MinInt32
+	joint.IsCompleted = false
+	joint.DriveMode = currentDriveMode(cs.controller.GetCalibration(), servoID)
+	cs.activeJoint = servoID
+
+	cs.setState(StateStarted, fmt.Sprintf(
+		"Calibrating %s. Manually move it to the middle of its range of motion, then use 'set_homing' command.", name))
+
+	return map[string]any{
+		"success":      true,
+		"state":        cs.state.String(),
+		"active_joint": name,
+		"message":      cs.lastInstruction,
+	}, nil
+}
+
+// activeJointReading describes the joint calibrate_joint is working on, with the range
+// recorded so far once range recording has started
+func activeJointReading(joint *JointCalibrationData) map[string]any {
+	reading := map[string]any{
+		"id":               joint.ID,
+		"name":             joint.Name,
+		"current_position": joint.CurrentPos,
+	}
+	if joint.RecordedMin <= joint.RecordedMax {
+		reading["recorded_min"] = joint.RecordedMin
+		reading["recorded_max"] = joint.RecordedMax
+		reading["recorded_range"] = joint.RecordedMax - joint.RecordedMin
+	}
+	return reading
+}
+
+// nextJointInstruction is shown once a joint calibrated on its own is saved, pointing at the
+// next configured servo
+func (cs *so101CalibrationSensor) nextJointInstruction() string {
+	done := cs.servoNames[cs.activeJoint]
+	i := slices.Index(cs.cfg.ServoIDs, cs.activeJoint)
+	if i < 0 || i == len(cs.cfg.ServoIDs)-1 {
+		return fmt.Sprintf("Calibration of %s saved. It was the last configured joint.", done)
+	}
+	next := cs.cfg.ServoIDs[i+1]
+	return fmt.Sprintf("Calibration of %s saved. Use 'calibrate_joint' with servo_id %d to calibrate %s next.",
+		done, next, cs.servoNames[next])
+}
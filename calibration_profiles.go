@@ -0,0 +1,164 @@
+package so_arm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Calibration profiles are calibration files kept side by side in one directory, one per
+// setup such as "leader" or "with_gripper_payload". The active profile's file is used as the
+// arm's calibration_file.
+const (
+	defaultCalibrationProfile = "default"
+	calibrationProfileExt     = ".json"
+)
+
+// validateCalibrationProfiles checks the profile attributes of an arm config
+func validateCalibrationProfiles(dir, profile, calibrationFile string) error {
+	if dir == "" {
+		if profile != "" {
+			return fmt.Errorf("calibration_profile requires calibration_profiles_dir")
+		}
+		return nil
+	}
+	if profile == "" {
+		profile = defaultCalibrationProfile
+	}
+	// calibration_file is filled in from the profile once the arm is configured
+	if calibrationFile != "" && calibrationFile != calibrationProfileFile(dir, profile) {
+		return fmt.Errorf("calibration_file and calibration_profiles_dir can't both be set, the active profile is the calibration file")
+	}
+	return validateCalibrationProfileName(profile)
+}
+
+// validateCalibrationProfileName rejects names that aren't a plain file name
+func validateCalibrationProfileName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid calibration profile name %q", name)
+	}
+	return nil
+}
+
+// calibrationProfileFile returns the calibration file for a profile, relative paths are
+// under VIAM_MODULE_DATA
+func calibrationProfileFile(dir, profile string) string {
+	return filepath.Join(resolveCalibrationPath(dir), profile+calibrationProfileExt)
+}
+
+// listCalibrationProfiles returns the sorted names of the profiles in dir
+func listCalibrationProfiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(resolveCalibrationPath(dir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calibration profiles: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != calibrationProfileExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), calibrationProfileExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// applyCalibrationProfile points calibration_file at the configured profile, the default
+// profile when none is named
+func applyCalibrationProfile(conf *SO101ArmConfig) {
+	if conf.CalibrationProfilesDir == "" {
+		return
+	}
+	if conf.CalibrationProfile == "" {
+		conf.CalibrationProfile = defaultCalibrationProfile
+	}
+	conf.CalibrationFile = calibrationProfileFile(conf.CalibrationProfilesDir, conf.CalibrationProfile)
+}
+
+// setCalibrationProfile handles the set_calibration_profile DoCommand. The profile's file is
+// loaded and applied to every component on the port, as reload_calibration does, after any
+// move in progress finishes. The config's profile is used again on the next reconfigure.
+func (s *so101) setCalibrationProfile(cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["profile"].(string)
+	if !ok {
+		return nil, fmt.Errorf("set_calibration_profile requires 'profile' string parameter")
+	}
+	if err := validateCalibrationProfileName(name); err != nil {
+		return nil, err
+	}
+
+	cfg := *s.config()
+	if cfg.CalibrationProfilesDir == "" {
+		return nil, fmt.Errorf("no calibration_profiles_dir configured")
+	}
+	if cfg.WatchCalibrationFile {
+		return nil, fmt.Errorf("calibration profiles can't be switched while watch_calibration_file is set")
+	}
+
+	file := calibrationProfileFile(cfg.CalibrationProfilesDir, name)
+	calibration, err := LoadFullCalibrationFromFile(file, s.logger)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("Failed to load calibration profile %q: %v", name, err),
+		}, nil
+	}
+	if err := checkJointLimits(cfg.JointLimits, calibration, cfg.ServoIDs); err != nil {
+		return nil, err
+	}
+
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	if err := ApplySharedCalibration(cfg.Port, calibration); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("Failed to update calibration: %v", err),
+		}, nil
+	}
+	RegisterSharedConsumer(cfg.Port, ConsumerInfo{
+		Name:            s.name.ShortName(),
+		CalibrationFile: file,
+		ServoIDs:        cfg.ServoIDs,
+	})
+
+	cfg.CalibrationProfile = name
+	cfg.CalibrationFile = file
+	s.mu.Lock()
+	s.cfg = &cfg
+	s.mu.Unlock()
+	s.calibrationLoaded.Store(true)
+	s.decalibration.clear()
+
+	s.logger.Infof("Switched to calibration profile %q from %s", name, file)
+	return map[string]interface{}{
+		"success":          true,
+		"profile":          name,
+		"calibration_file": file,
+	}, nil
+}
+
+// calibrationProfiles handles the list_calibration_profiles DoCommand
+func (s *so101) calibrationProfiles() (map[string]interface{}, error) {
+	cfg := s.config()
+	dir, active := cfg.CalibrationProfilesDir, cfg.CalibrationProfile
+	if dir == "" {
+		return nil, fmt.Errorf("no calibration_profiles_dir configured")
+	}
+
+	names, err := listCalibrationProfiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]interface{}, len(names))
+	for i, name := range names {
+		profiles[i] = name
+	}
+	return map[string]interface{}{
+		"profiles":       profiles,
+		"active_profile": active,
+		"directory":      resolveCalibrationPath(dir),
+	}, nil
+}
@@ -0,0 +1,30 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibrationProfiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"leader.json", "follower.json", "notes.txt"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o644))
+	}
+	names, err := listCalibrationProfiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"follower", "leader"}, names)
+
+	conf := &SO101ArmConfig{CalibrationProfilesDir: dir}
+	applyCalibrationProfile(conf)
+	assert.Equal(t, "default", conf.CalibrationProfile)
+	assert.Equal(t, filepath.Join(dir, "default.json"), conf.CalibrationFile)
+
+	// Validating again after the profile filled in calibration_file still passes
+	assert.NoError(t, validateCalibrationProfiles(conf.CalibrationProfilesDir, conf.CalibrationProfile, conf.CalibrationFile))
+	assert.Error(t, validateCalibrationProfiles(dir, "leader", "other.json"))
+	assert.Error(t, validateCalibrationProfiles("", "leader", ""))
+	assert.Error(t, validateCalibrationProfiles(dir, "../leader", ""))
+}
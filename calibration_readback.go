@@ -0,0 +1,137 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+)
+
+// What to do when the calibration file and the servo registers disagree at startup
+const (
+	calibrationMismatchPreferFile  = "prefer_file"
+	calibrationMismatchPreferServo = "prefer_servo"
+	calibrationMismatchFail        = "fail"
+)
+
+// calibrationReadbackToleranceSteps absorbs the rounding between a computed homing offset
+// and the one a servo stores when it centers itself
+const calibrationReadbackToleranceSteps = 2
+
+// calibrationMismatch is one register that differs between the file and a servo
+type calibrationMismatch struct {
+	Joint string
+	Field string
+	File  int
+	Servo int
+}
+
+func (m calibrationMismatch) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"joint": m.Joint,
+		"field": m.Field,
+		"file":  m.File,
+		"servo": m.Servo,
+	}
+}
+
+// ReadServoCalibration reads the homing offset and angle limits stored in a servo
+func (s *SafeSoArmController) ReadServoCalibration(ctx context.Context, servoID int) (offset, rangeMin, rangeMax int, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	servo := s.group.ServoByID(servoID)
+	if servo == nil {
+		return 0, 0, 0, fmt.Errorf("servo %d not found", servoID)
+	}
+	proto := s.protocolFor(servoID)
+	if offset, err = readInt16Register(ctx, servo, "position_offset", proto); err != nil {
+		return 0, 0, 0, err
+	}
+	minLimit, err := readUint16Register(ctx, servo, "min_angle_limit", proto)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	maxLimit, err := readUint16Register(ctx, servo, "max_angle_limit", proto)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return offset, int(minLimit), int(maxLimit), nil
+}
+
+// compareServoCalibration lists the fields where a servo's registers differ from the file
+func compareServoCalibration(servoID int, file *MotorCalibration, offset, rangeMin, rangeMax int) []calibrationMismatch {
+	var mismatches []calibrationMismatch
+	for _, field := range []struct {
+		name        string
+		file, servo int
+	}{
+		{"homing_offset", file.HomingOffset, offset},
+		{"range_min", file.RangeMin, rangeMin},
+		{"range_max", file.RangeMax, rangeMax},
+	} {
+		diff := field.file - field.servo
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > calibrationReadbackToleranceSteps {
+			mismatches = append(mismatches, calibrationMismatch{
+				Joint: jointNameForServo(servoID),
+				Field: field.name,
+				File:  field.file,
+				Servo: field.servo,
+			})
+		}
+	}
+	return mismatches
+}
+
+// checkCalibrationReadback compares the loaded calibration file with what the arm's servos
+// have stored, for when someone recalibrated with another tool, and applies the
+// calibration_mismatch policy
+func (s *so101) checkCalibrationReadback(ctx context.Context) error {
+	calibration := s.controller.GetCalibration()
+	var mismatches []calibrationMismatch
+	servoValues := map[int][3]int{}
+	for _, id := range s.servoIDs() {
+		offset, rangeMin, rangeMax, err := s.controller.ReadServoCalibration(ctx, id)
+		if err != nil {
+			s.logger.Warnf("Failed to read calibration registers of servo %d: %v", id, err)
+			continue
+		}
+		found := compareServoCalibration(id, calibration.GetMotorCalibrationByID(id), offset, rangeMin, rangeMax)
+		if len(found) > 0 {
+			servoValues[id] = [3]int{offset, rangeMin, rangeMax}
+		}
+		mismatches = append(mismatches, found...)
+	}
+
+	reported := make([]interface{}, 0, len(mismatches))
+	for _, m := range mismatches {
+		reported = append(reported, m.toMap())
+		s.logger.Warnf("Calibration mismatch on %s %s: file %d, servo %d", m.Joint, m.Field, m.File, m.Servo)
+	}
+	s.calibrationMismatches = reported
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	cfg := s.config()
+	switch cfg.CalibrationMismatch {
+	case calibrationMismatchFail:
+		return fmt.Errorf("calibration file %s does not match the servos in %d places, recalibrate or change calibration_mismatch", cfg.CalibrationFile, len(mismatches))
+	case calibrationMismatchPreferServo:
+		motors := calibration.ToFeetechCalibrationMap()
+		for id, values := range servoValues {
+			updated := *motors[id]
+			updated.HomingOffset, updated.RangeMin, updated.RangeMax = values[0], values[1], values[2]
+			if err := updated.Validate(); err != nil {
+				return fmt.Errorf("servo %d calibration registers are invalid: %w", id, err)
+			}
+			motors[id] = &updated
+		}
+		if err := ApplySharedCalibration(cfg.Port, FromFeetechCalibrationMap(motors)); err != nil {
+			return fmt.Errorf("failed to apply servo calibration: %w", err)
+		}
+		s.logger.Infof("Using calibration from servo registers for %d joint(s)", len(servoValues))
+	}
+	return nil
+}
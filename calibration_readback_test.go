@@ -0,0 +1,30 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareServoCalibration(t *testing.T) {
+	file := &MotorCalibration{ID: 2, HomingOffset: 100, RangeMin: 800, RangeMax: 3200}
+
+	// Rounding in the stored offset is tolerated
+	assert.Empty(t, compareServoCalibration(2, file, 101, 800, 3200))
+
+	mismatches := compareServoCalibration(2, file, -40, 800, 3000)
+	assert.Len(t, mismatches, 2)
+	assert.Equal(t, calibrationMismatch{Joint: jointNameForServo(2), Field: "homing_offset", File: 100, Servo: -40}, mismatches[0])
+	assert.Equal(t, "range_max", mismatches[1].Field)
+	assert.Equal(t, 3000, mismatches[1].toMap()["servo"])
+}
+
+func TestCalibrationMismatchValidation(t *testing.T) {
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", CalibrationMismatch: calibrationMismatchPreferServo}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.CalibrationMismatch = "prefer_newest"
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+}
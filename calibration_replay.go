@@ -0,0 +1,114 @@
+package so_arm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"go.viam.com/rdk/logging"
+)
+
+// replayCalibration shows what a calibration makes of raw positions captured from the bus,
+// without any hardware. samples maps a joint name or servo ID to raw positions.
+func replayCalibration(calibration SO101FullCalibration, samples map[string][]int) (map[string]interface{}, error) {
+	names := make([]string, 0, len(samples))
+	for name := range samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	joints := map[string]interface{}{}
+	for _, name := range names {
+		servoID, err := servoIDForJoint(name)
+		if err != nil {
+			return nil, err
+		}
+		cal := calibration.GetMotorCalibrationByID(servoID)
+
+		units := "degrees"
+		if cal.NormMode == NormModeRange100 {
+			units = "percent"
+		}
+
+		readings := make([]interface{}, 0, len(samples[name]))
+		for _, raw := range samples[name] {
+			reading := map[string]interface{}{
+				"raw":      raw,
+				"in_range": raw >= cal.RangeMin && raw <= cal.RangeMax,
+			}
+			normalized, err := cal.Normalize(raw)
+			if err != nil {
+				reading["error"] = err.Error()
+			} else {
+				reading["normalized"] = normalized
+			}
+			readings = append(readings, reading)
+		}
+
+		joint := map[string]interface{}{
+			"servo_id":    servoID,
+			"units":       units,
+			"calibration": FromMotorCalibration(cal),
+			"readings":    readings,
+		}
+		// The raw position this calibration calls zero is the usual answer to "why is my 0° off"
+		if zero, err := cal.Denormalize(0); err == nil {
+			joint["raw_at_zero"] = zero
+		}
+		joints[jointNameForServo(servoID)] = joint
+	}
+
+	return map[string]interface{}{"joints": joints}, nil
+}
+
+// replayCalibrationCommand handles the replay_calibration DoCommand. The calibration comes
+// from the "calibration" object in the command, or from "calibration_file" if given.
+func replayCalibrationCommand(cmd map[string]interface{}, logger logging.Logger) (map[string]interface{}, error) {
+	var calibration SO101FullCalibration
+	var err error
+	if inline, ok := cmd["calibration"]; ok {
+		data, marshalErr := json.Marshal(inline)
+		if marshalErr != nil {
+			return nil, fmt.Errorf("invalid calibration: %w", marshalErr)
+		}
+		calibration, err = ParseFullCalibration(data, logger)
+	} else if file, ok := cmd["calibration_file"].(string); ok && file != "" {
+		calibration, err = LoadFullCalibrationFromFile(resolveCalibrationPath(file), logger)
+	} else {
+		return nil, fmt.Errorf("replay_calibration requires 'calibration' or 'calibration_file'")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rawSamples, ok := cmd["raw_positions"].(map[string]interface{})
+	if !ok || len(rawSamples) == 0 {
+		return nil, fmt.Errorf("replay_calibration requires 'raw_positions', raw values keyed by joint name or servo ID")
+	}
+	samples := map[string][]int{}
+	for name, v := range rawSamples {
+		values, err := floatList(v, "raw_positions."+name)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			samples[name] = append(samples[name], int(value))
+		}
+	}
+
+	return replayCalibration(calibration, samples)
+}
+
+// servoIDForJoint accepts a joint name ("elbow_flex") or a servo ID ("3")
+func servoIDForJoint(name string) (int, error) {
+	for id := 1; id <= 6; id++ {
+		if jointNameForServo(id) == name {
+			return id, nil
+		}
+	}
+	if id, err := strconv.Atoi(name); err == nil && id >= 1 && id <= 6 {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown joint %q", name)
+}
@@ -0,0 +1,45 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestReplayCalibration(t *testing.T) {
+	cmd := map[string]interface{}{
+		"calibration": map[string]interface{}{
+			"shoulder_pan": map[string]interface{}{
+				"id": 1, "drive_mode": 0, "homing_offset": 0, "range_min": 1000, "range_max": 3000,
+			},
+		},
+		"raw_positions": map[string]interface{}{
+			"shoulder_pan": []interface{}{2000.0, 3024.0},
+			"6":            []interface{}{1024.0},
+		},
+	}
+
+	result, err := replayCalibrationCommand(cmd, logging.NewTestLogger(t))
+	assert.NoError(t, err)
+	joints := result["joints"].(map[string]interface{})
+
+	pan := joints["shoulder_pan"].(map[string]interface{})
+	assert.Equal(t, 2000, pan["raw_at_zero"])
+	assert.Equal(t, "degrees", pan["units"])
+	readings := pan["readings"].([]interface{})
+	assert.InDelta(t, 0.0, readings[0].(map[string]interface{})["normalized"], 1e-9)
+	assert.InDelta(t, 90.0, readings[1].(map[string]interface{})["normalized"], 1e-9)
+	assert.Equal(t, false, readings[1].(map[string]interface{})["in_range"])
+
+	// Servo IDs work as keys too, and the default calibration fills in missing joints
+	gripper := joints["gripper"].(map[string]interface{})
+	assert.Equal(t, "percent", gripper["units"])
+
+	_, err = replayCalibrationCommand(map[string]interface{}{"calibration": map[string]interface{}{}}, logging.NewTestLogger(t))
+	assert.Error(t, err)
+
+	cmd["raw_positions"] = map[string]interface{}{"elbow": []interface{}{1.0}}
+	_, err = replayCalibrationCommand(cmd, logging.NewTestLogger(t))
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibrationPartialState(t *testing.T) {
+	cs := &so101CalibrationSensor{
+		cfg:          &SO101CalibrationSensorConfig{ServoIDs: []int{1, 2}},
+		state:        StateCalibrationPartial,
+		errorMsg:     "calibration_partial: 1 servo(s) did not accept their position limits",
+		joints:       map[int]*JointCalibrationData{},
+		servoNames:   map[int]string{1: "shoulder_pan", 2: "shoulder_lift"},
+		failedWrites: map[int]string{2: "max position limit: timeout"},
+	}
+
+	readings, err := cs.Readings(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "calibration_partial", readings["calibration_state"])
+	assert.Equal(t, cs.errorMsg, readings["error"])
+	assert.Equal(t, map[string]any{"shoulder_lift": "max position limit: timeout"}, readings["failed_writes"])
+	assert.Contains(t, readings["available_commands"], "retry_failed_writes")
+
+	cs.state = StateIdle
+	cs.failedWrites = nil
+	_, err = cs.retryFailedWrites(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCalibrateJoint(t *testing.T) {
+	cfg := &SO101CalibrationSensorConfig{ServoIDs: []int{1, 2, 3}}
+	servoNames := map[int]string{1: "shoulder_pan", 2: "shoulder_lift", 3: "elbow_flex"}
+	cs := &so101CalibrationSensor{
+		cfg:        cfg,
+		state:      StateIdle,
+		joints:     newJointCalibrationData(cfg.ServoIDs, servoNames),
+		servoNames: servoNames,
+	}
+	assert.Equal(t, []int{1, 2, 3}, cs.workflowServoIDs())
+
+	_, err := cs.calibrateJoint(context.Background(), map[string]any{"servo_id": 6.0})
+	assert.Error(t, err)
+	_, err = cs.calibrateJoint(context.Background(), map[string]any{})
+	assert.Error(t, err)
+
+	// A joint in range recording reports what it has seen so far
+	cs.activeJoint = 2
+	cs.state = StateRangeRecording
+	cs.joints[2].CurrentPos = 2100
+	cs.joints[2].RecordedMin = 900
+	cs.joints[2].RecordedMax = 3000
+	assert.Equal(t, []int{2}, cs.workflowServoIDs())
+
+	readings, err := cs.Readings(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"id":               2,
+		"name":             "shoulder_lift",
+		"current_position": 2100,
+		"recorded_min":     900,
+		"recorded_max":     3000,
+		"recorded_range":   2100,
+	}, readings["active_joint"])
+
+	assert.Contains(t, cs.nextJointInstruction(), "servo_id 3")
+	cs.activeJoint = 3
+	assert.NotContains(t, cs.nextJointInstruction(), "servo_id")
+}
+
+func TestValidationReport(t *testing.T) {
+	points := validationTargets(&JointCalibrationData{RangeMin: 1000, RangeMax: 3000})
+	assert.Equal(t, []int{1000, 2000, 3000}, []int{points[0].Target, points[1].Target, points[2].Target})
+
+	points[0].Reached = 1010
+	points[1].Reached = 1995
+	points[2].Reached = 2900 // stopped short, the range was recorded past a stop
+	report, passed := validationReport(points, 25)
+	assert.False(t, passed)
+	assert.Equal(t, false, report["passed"])
+	assert.Equal(t, 100, report["points"].([]any)[2].(map[string]any)["error_steps"])
+
+	points[2].Reached = 3020
+	_, passed = validationReport(points, 25)
+	assert.True(t, passed)
+
+	cs := &so101CalibrationSensor{cfg: &SO101CalibrationSensorConfig{}, state: StateHomingPosition}
+	_, err := cs.validateCalibration(context.Background(), map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestDriveModeForMove(t *testing.T) {
+	assert.Equal(t, driveModeNormal, driveModeForMove(homingCenterPosition+150, 100))
+	assert.Equal(t, driveModeInverted, driveModeForMove(homingCenterPosition-150, 100))
+	assert.Equal(t, driveModeDirectionUnknown, driveModeForMove(homingCenterPosition+40, 100))
+
+	calibration := DefaultSO101FullCalibration
+	elbow := *calibration.ElbowFlex
+	elbow.DriveMode = driveModeInverted
+	calibration.ElbowFlex = &elbow
+	assert.Equal(t, driveModeInverted, currentDriveMode(calibration, 3))
+	assert.Equal(t, driveModeNormal, currentDriveMode(calibration, 1))
+	assert.Equal(t, driveModeNormal, currentDriveMode(SO101FullCalibration{}, 6))
+}
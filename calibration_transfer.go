@@ -0,0 +1,79 @@
+package so_arm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.viam.com/rdk/logging"
+)
+
+// getCalibrationJSON handles the get_calibration_json DoCommand, returning the calibration in
+// use on the port in the calibration file format so it can be backed up or cloned elsewhere
+func (cs *so101CalibrationSensor) getCalibrationJSON() (map[string]any, error) {
+	data, err := MarshalFullCalibration(cs.controller.GetCalibration())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"calibration_json": string(data),
+		"calibration_file": cs.cfg.CalibrationFile,
+	}, nil
+}
+
+// setCalibrationJSON handles the set_calibration_json DoCommand. The calibration, as a JSON
+// string in "calibration_json" or an object in "calibration", is validated, saved to
+// calibration_file and applied to every component on the port.
+func (cs *so101CalibrationSensor) setCalibrationJSON(_ context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.calibrationInProgress() {
+		return map[string]any{"success": false},
+			fmt.Errorf("calibration in progress (state: %s), abort it before setting a calibration", cs.state.String())
+	}
+
+	calibration, err := calibrationFromPayload(cmd, cs.logger)
+	if err != nil {
+		return map[string]any{"success": false}, err
+	}
+
+	backupID, err := saveCalibrationWithBackup(cs.cfg.CalibrationFile, calibration)
+	if err != nil {
+		return map[string]any{"success": false}, err
+	}
+	if err := ApplySharedCalibration(cs.cfg.Port, calibration); err != nil {
+		return map[string]any{"success": false}, fmt.Errorf("calibration saved to %s but not applied: %w", cs.cfg.CalibrationFile, err)
+	}
+
+	cs.logger.Infof("Calibration received over DoCommand saved to %s and applied", cs.cfg.CalibrationFile)
+	return map[string]any{
+		"success":          true,
+		"calibration_file": cs.cfg.CalibrationFile,
+		"replaced_backup":  backupID,
+	}, nil
+}
+
+// calibrationInProgress reports whether a calibration session is partway through, when the
+// calibration file mustn't be replaced under it
+func (cs *so101CalibrationSensor) calibrationInProgress() bool {
+	switch cs.state {
+	case StateIdle, StateCompleted, StateError, StateCalibrationPartial:
+		return false
+	default:
+		return true
+	}
+}
+
+// calibrationFromPayload parses and validates a calibration sent in a command
+func calibrationFromPayload(cmd map[string]any, logger logging.Logger) (SO101FullCalibration, error) {
+	var data []byte
+	if blob, ok := cmd["calibration_json"].(string); ok {
+		data = []byte(blob)
+	} else if inline, ok := cmd["calibration"].(map[string]any); ok {
+		var err error
+		if data, err = json.Marshal(inline); err != nil {
+			return SO101FullCalibration{}, fmt.Errorf("invalid calibration: %w", err)
+		}
+	} else {
+		return SO101FullCalibration{}, fmt.Errorf("set_calibration_json requires 'calibration_json' string or 'calibration' object")
+	}
+	return ParseFullCalibration(data, logger)
+}
@@ -0,0 +1,35 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestCalibrationJSONRoundTrip(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	cs := &so101CalibrationSensor{
+		logger:     logger,
+		cfg:        &SO101CalibrationSensorConfig{CalibrationFile: "so101_calibration.json"},
+		controller: &SafeSoArmController{calibration: DefaultSO101FullCalibration},
+	}
+
+	result, err := cs.getCalibrationJSON()
+	assert.NoError(t, err)
+	blob := result["calibration_json"].(string)
+
+	calibration, err := calibrationFromPayload(map[string]any{"calibration_json": blob}, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultSO101FullCalibration.ElbowFlex.RangeMax, calibration.ElbowFlex.RangeMax)
+
+	_, err = calibrationFromPayload(map[string]any{"calibration_json": `{"elbow_flex": {"id": 3, "range_min": 3000, "range_max": 1000}}`}, logger)
+	assert.Error(t, err)
+	_, err = calibrationFromPayload(map[string]any{}, logger)
+	assert.Error(t, err)
+
+	cs.state = StateRangeRecording
+	_, err = cs.setCalibrationJSON(context.Background(), map[string]any{"calibration_json": blob})
+	assert.Error(t, err)
+}
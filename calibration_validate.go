@@ -0,0 +1,166 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calibration validation. Before a new calibration is saved, validate_calibration drives each
+// joint with torque on to its recorded min, center and max and checks the servo gets there,
+// catching ranges that were recorded past a hard stop or a joint that binds partway.
+const (
+	validateTolerance     = 25 // raw steps
+	validateTorquePercent = 50.0
+	validateStepSteps     = 8
+	validateStepInterval  = 20 * time.Millisecond
+	validateSettleTime    = 500 * time.Millisecond
+)
+
+// validationPoint is one target of a joint's sweep and where the servo ended up
+type validationPoint struct {
+	Name    string
+	Target  int
+	Reached int
+}
+
+func (p validationPoint) errorSteps() int {
+	if p.Reached > p.Target {
+		return p.Reached - p.Target
+	}
+	return p.Target - p.Reached
+}
+
+// validationTargets returns the points a joint is swept through: min, center, max
+func validationTargets(joint *JointCalibrationData) []validationPoint {
+	return []validationPoint{
+		{Name: "min", Target: joint.RangeMin},
+		{Name: "center", Target: (joint.RangeMin + joint.RangeMax) / 2},
+		{Name: "max", Target: joint.RangeMax},
+	}
+}
+
+// validationReport turns a joint's sweep into its report, passing when every point was
+// reached within tolerance
+func validationReport(points []validationPoint, tolerance int) (map[string]any, bool) {
+	passed := true
+	list := make([]any, 0, len(points))
+	for _, p := range points {
+		ok := p.errorSteps() <= tolerance
+		passed = passed && ok
+		list = append(list, map[string]any{
+			"point":       p.Name,
+			"target":      p.Target,
+			"reached":     p.Reached,
+			"error_steps": p.errorSteps(),
+			"passed":      ok,
+		})
+	}
+	return map[string]any{"passed": passed, "points": list}, passed
+}
+
+// validateCalibration handles the validate_calibration DoCommand. It blocks while each joint
+// is swept in turn, then leaves the joints limp and the workflow ready for save_calibration.
+func (cs *so101CalibrationSensor) validateCalibration(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.state != StateCompleted {
+		return map[string]any{"success": false},
+			fmt.Errorf("record ranges before validating them (current state: %s)", cs.state.String())
+	}
+
+	tolerance := validateTolerance
+	if v, ok := cmd["tolerance_steps"].(float64); ok {
+		if v <= 0 {
+			return map[string]any{"success": false}, fmt.Errorf("tolerance_steps must be positive, got %.0f", v)
+		}
+		tolerance = int(v)
+	}
+	torquePercent := validateTorquePercent
+	if v, ok := cmd["torque_percent"].(float64); ok {
+		if v <= 0 || v > 100 {
+			return map[string]any{"success": false}, fmt.Errorf("torque_percent must be in (0, 100], got %.1f", v)
+		}
+		torquePercent = v
+	}
+
+	joints := make(map[string]any)
+	var failed []string
+	for _, servoID := range cs.workflowServoIDs() {
+		joint := cs.joints[servoID]
+		points := validationTargets(joint)
+		err := cs.withLimitedTorque(ctx, servoID, torquePercent, func() error {
+			for i := range points {
+				reached, err := cs.sweepServoTo(ctx, servoID, points[i].Target)
+				if err != nil {
+					return err
+				}
+				points[i].Reached = reached
+			}
+			// Finish in the middle so the joint doesn't drop onto a stop when it goes limp
+			_, err := cs.sweepServoTo(ctx, servoID, points[1].Target)
+			return err
+		})
+		if err != nil {
+			return map[string]any{"success": false}, fmt.Errorf("failed to validate %s: %w", joint.Name, err)
+		}
+
+		report, passed := validationReport(points, tolerance)
+		joints[joint.Name] = report
+		if !passed {
+			failed = append(failed, joint.Name)
+		}
+		cs.logger.Infof("Servo %d (%s) validation passed: %v", servoID, joint.Name, passed)
+	}
+
+	if len(failed) > 0 {
+		cs.setState(StateCompleted, fmt.Sprintf(
+			"Validation failed for %s. Calibrate again, or use 'save_calibration' to save anyway.", strings.Join(failed, ", ")))
+	} else {
+		cs.setState(StateCompleted, "Validation passed. Use 'save_calibration' to write calibration to servos and save to file.")
+	}
+
+	return map[string]any{
+		"success":         true,
+		"passed":          len(failed) == 0,
+		"tolerance_steps": tolerance,
+		"joints":          joints,
+		"message":         cs.lastInstruction,
+	}, nil
+}
+
+// sweepServoTo steps a servo's goal slowly to target, waits for it to settle, and returns the
+// raw position it reached. The servo's torque must be enabled.
+func (cs *so101CalibrationSensor) sweepServoTo(ctx context.Context, servoID, target int) (int, error) {
+	data, err := cs.controller.ReadServoRegister(ctx, servoID, "goal_position")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read goal position: %w", err)
+	}
+	proto := cs.controller.protocolFor(servoID)
+	goal := int(proto.DecodeWord(data))
+	for goal != target {
+		if goal < target {
+			goal = min(goal+validateStepSteps, target)
+		} else {
+			goal = max(goal-validateStepSteps, target)
+		}
+		if err := cs.controller.WriteServoRegister(ctx, servoID, "goal_position", proto.EncodeWord(uint16(goal))); err != nil {
+			return 0, fmt.Errorf("failed to step servo: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(validateStepInterval):
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(validateSettleTime):
+	}
+	data, err = cs.controller.ReadServoRegister(ctx, servoID, "present_position")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read position: %w", err)
+	}
+	return int(proto.DecodeWord(data)), nil
+}
@@ -0,0 +1,105 @@
+package so_arm
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.viam.com/rdk/logging"
+)
+
+// Editors and the calibration sensor write files in several steps, wait for them to settle
+const calibrationReloadDebounce = 500 * time.Millisecond
+
+// calibrationWatcher reloads a calibration file whenever it changes on disk
+type calibrationWatcher struct {
+	path     string
+	logger   logging.Logger
+	onChange func(SO101FullCalibration)
+
+	watcher   *fsnotify.Watcher
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newCalibrationWatcher starts watching path, calling onChange with each valid calibration written to it
+func newCalibrationWatcher(path string, logger logging.Logger, onChange func(SO101FullCalibration)) (*calibrationWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create calibration file watcher: %w", err)
+	}
+
+	// Watch the directory rather than the file so atomic renames are picked up
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch calibration directory: %w", err)
+	}
+
+	cw := &calibrationWatcher{
+		path:     path,
+		logger:   logger,
+		onChange: onChange,
+		watcher:  watcher,
+		done:     make(chan struct{}),
+	}
+	go cw.run()
+
+	logger.Infof("Watching calibration file %s for changes", path)
+	return cw, nil
+}
+
+func (cw *calibrationWatcher) run() {
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-cw.done:
+			return
+
+		case event, ok := <-cw.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(cw.path) {
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+				debounce = time.After(calibrationReloadDebounce)
+			}
+
+		case err, ok := <-cw.watcher.Errors:
+			if !ok {
+				return
+			}
+			cw.logger.Warnf("Calibration file watcher error: %v", err)
+
+		case <-debounce:
+			debounce = nil
+			cw.reload()
+		}
+	}
+}
+
+// reload loads and validates the file, keeping the current calibration if it is invalid
+func (cw *calibrationWatcher) reload() {
+	calibration, err := LoadFullCalibrationFromFile(cw.path, cw.logger)
+	if err != nil {
+		cw.logger.Warnf("Ignoring change to calibration file %s: %v", cw.path, err)
+		return
+	}
+
+	cw.logger.Infof("Calibration file %s changed, applying new calibration", cw.path)
+	cw.onChange(calibration)
+}
+
+// Close stops watching the file. It does not wait for an in-progress reload to finish.
+func (cw *calibrationWatcher) Close() {
+	cw.closeOnce.Do(func() {
+		close(cw.done)
+		if err := cw.watcher.Close(); err != nil {
+			cw.logger.Debugf("Error closing calibration file watcher: %v", err)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestCalibrationWatcherReloadsSavedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "so101_calibration.json")
+	assert.NoError(t, SaveFullCalibrationToFile(path, DefaultSO101FullCalibration))
+
+	changes := make(chan SO101FullCalibration, 4)
+	cw, err := newCalibrationWatcher(path, logging.NewTestLogger(t), func(calibration SO101FullCalibration) {
+		changes <- calibration
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer cw.Close()
+
+	// An invalid file keeps the current calibration
+	assert.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+	select {
+	case <-changes:
+		t.Fatal("invalid calibration file was applied")
+	case <-time.After(2 * calibrationReloadDebounce):
+	}
+
+	// What the calibration sensor saves is applied
+	calibration := DefaultSO101FullCalibration
+	elbow := *calibration.ElbowFlex
+	elbow.RangeMin += 100
+	calibration.ElbowFlex = &elbow
+	assert.NoError(t, SaveFullCalibrationToFile(path, calibration))
+	select {
+	case got := <-changes:
+		assert.Equal(t, elbow.RangeMin, got.ElbowFlex.RangeMin)
+	case <-time.After(5 * time.Second):
+		t.Fatal("saved calibration file was not reloaded")
+	}
+}
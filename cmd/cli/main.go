@@ -213,7 +213,7 @@ func realMain() error {
 	jointNames := []string{"Base", "Shoulder", "Elbow", "Wrist_P", "Wrist_R"}
 
 	for i, angle := range testAngles {
-		degrees := angle * 180.0 / 3.14159
+		degrees := soarm.RadiansToDegrees(angle)
 		logger.Infof("Joint %d (%s): Target=%.3f rad (%.1f°)", i+1, jointNames[i], angle, degrees)
 
 		// Check what the calibration system will actually produce
@@ -264,8 +264,8 @@ func realMain() error {
 
 		// Convert to degrees for easier reading
 		logger.Infof("In degrees: [%.1f°, %.1f°, %.1f°, %.1f°, %.1f°]",
-			actualPos[0].Value*180/3.14159, actualPos[1].Value*180/3.14159,
-			actualPos[2].Value*180/3.14159, actualPos[3].Value*180/3.14159, actualPos[4].Value*180/3.14159)
+			soarm.RadiansToDegrees(actualPos[0].Value), soarm.RadiansToDegrees(actualPos[1].Value),
+			soarm.RadiansToDegrees(actualPos[2].Value), soarm.RadiansToDegrees(actualPos[3].Value), soarm.RadiansToDegrees(actualPos[4].Value))
 
 		// Check if position is close to target
 		positionOk := true
@@ -279,7 +279,7 @@ func realMain() error {
 				if diff > tolerance {
 					positionOk = false
 					logger.Warnf("Joint %d not at safe position: actual=%.1f°, target=%.1f°, diff=%.1f°",
-						i+1, actualPos[i].Value*180/3.14159, target.Value*180/3.14159, diff*180/3.14159)
+						i+1, soarm.RadiansToDegrees(actualPos[i].Value), soarm.RadiansToDegrees(target.Value), soarm.RadiansToDegrees(diff))
 				}
 			}
 		}
@@ -90,7 +90,7 @@ func main() {
 				if i > 0 {
 					fmt.Printf(", ")
 				}
-				degrees := pos.Value * 180.0 / 3.14159
+				degrees := soarm.RadiansToDegrees(pos.Value)
 				fmt.Printf("%6.1f°", degrees)
 			}
 			fmt.Printf("]\n")
@@ -86,8 +86,8 @@ func main() {
 
 		// Convert to degrees for easier reading
 		logger.Infof("In degrees: [%.1f°, %.1f°, %.1f°, %.1f°, %.1f°]",
-			actualPos[0].Value*180/3.14159, actualPos[1].Value*180/3.14159,
-			actualPos[2].Value*180/3.14159, actualPos[3].Value*180/3.14159, actualPos[4].Value*180/3.14159)
+			soarm.RadiansToDegrees(actualPos[0].Value), soarm.RadiansToDegrees(actualPos[1].Value),
+			soarm.RadiansToDegrees(actualPos[2].Value), soarm.RadiansToDegrees(actualPos[3].Value), soarm.RadiansToDegrees(actualPos[4].Value))
 	}
 
 	// Now safely disable torque
@@ -2,8 +2,11 @@ package main
 
 import (
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/button"
 	"go.viam.com/rdk/components/gripper"
 	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/components/servo"
+	toggleswitch "go.viam.com/rdk/components/switch"
 	"go.viam.com/rdk/module"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/discovery"
@@ -16,6 +19,9 @@ func main() {
 		resource.APIModel{API: arm.API, Model: soArm.SO101Model},
 		resource.APIModel{API: gripper.API, Model: soArm.SO101GripperModel},
 		resource.APIModel{API: sensor.API, Model: soArm.SO101CalibrationSensorModel},
+		resource.APIModel{API: toggleswitch.API, Model: soArm.SO101TorqueSwitchModel},
+		resource.APIModel{API: button.API, Model: soArm.SO101HomeButtonModel},
+		resource.APIModel{API: servo.API, Model: soArm.SO101ServoModel},
 		resource.APIModel{API: discovery.API, Model: soArm.SO101DiscoveryModel},
 	)
 }
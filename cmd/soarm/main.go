@@ -0,0 +1,63 @@
+// Command soarm holds command line utilities for the SO-101 module
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	soArm "so_arm"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "doctor":
+		os.Exit(doctor(os.Args[2:]))
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: soarm doctor [-port PORT] [-calibration FILE]")
+}
+
+// doctor runs the environment checks and prints each result with its fix, exiting non-zero
+// when any check fails
+func doctor(args []string) int {
+	flags := flag.NewFlagSet("doctor", flag.ExitOnError)
+	port := flags.String("port", "", "serial port to check, defaults to every USB serial port")
+	calibration := flags.String("calibration", "", "calibration file to validate, relative paths are under VIAM_MODULE_DATA")
+	flags.Parse(args)
+
+	checks := soArm.RunDoctor(context.Background(), soArm.DoctorOptions{
+		Port:            *port,
+		CalibrationFile: *calibration,
+	})
+
+	failed := 0
+	for _, check := range checks {
+		fmt.Printf("[%s] %s: %s\n", strings.ToUpper(check.Status), check.Name, check.Message)
+		if check.Fix != "" {
+			fmt.Printf("       fix: %s\n", check.Fix)
+		}
+		if check.Status == soArm.DoctorFail {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d check(s) failed\n", failed)
+		return 1
+	}
+	fmt.Println("\nAll checks passed")
+	return 0
+}
@@ -0,0 +1,167 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrCollision is returned by a move that was aborted because a joint's load spiked
+var ErrCollision = errors.New("collision detected")
+
+// defaultCollisionSamples filters out the short load spikes of normal acceleration
+const defaultCollisionSamples = 3
+
+// collisionDetector watches joint loads during moves. A joint whose load stays at or above
+// the threshold for enough consecutive polls is treated as a collision.
+type collisionDetector struct {
+	loadPercent   float64
+	samples       int
+	torquePercent float64
+
+	mu          sync.Mutex
+	counts      map[int]int
+	savedLimits map[int][]byte // torque_limit before it was reduced, keyed by servo ID
+	lastJoint   string
+	lastLoad    float64
+	lastTime    time.Time
+}
+
+// newCollisionDetector returns nil when collision detection is disabled
+func newCollisionDetector(loadPercent float64, samples int, torquePercent float64) *collisionDetector {
+	if loadPercent == 0 {
+		return nil
+	}
+	if samples == 0 {
+		samples = defaultCollisionSamples
+	}
+	return &collisionDetector{
+		loadPercent:   loadPercent,
+		samples:       samples,
+		torquePercent: torquePercent,
+		counts:        map[int]int{},
+		savedLimits:   map[int][]byte{},
+	}
+}
+
+// reset forgets samples from a previous move
+func (c *collisionDetector) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = map[int]int{}
+}
+
+// observe counts one poll of loads and returns the servo that collided, if any
+func (c *collisionDetector) observe(loads map[int]float64) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, load := range loads {
+		if math.Abs(load) < c.loadPercent {
+			c.counts[id] = 0
+			continue
+		}
+		c.counts[id]++
+		if c.counts[id] >= c.samples {
+			c.counts = map[int]int{}
+			c.lastJoint = jointNameForServo(id)
+			c.lastLoad = load
+			c.lastTime = time.Now()
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (c *collisionDetector) status() map[string]interface{} {
+	if c == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := map[string]interface{}{
+		"enabled":                true,
+		"collision_load_percent": c.loadPercent,
+		"collision_samples":      c.samples,
+		"torque_reduced":         len(c.savedLimits) > 0,
+	}
+	if !c.lastTime.IsZero() {
+		result["last_collision"] = map[string]interface{}{
+			"joint":        c.lastJoint,
+			"load_percent": c.lastLoad,
+			"time":         c.lastTime.Format(time.RFC3339),
+		}
+	}
+	return result
+}
+
+// checkCollision reads joint loads once during a move. On a collision the arm is stopped,
+// torque is reduced if configured, and ErrCollision is returned.
+func (s *so101) checkCollision(ctx context.Context) error {
+	loads, err := s.controller.GetServoLoads(ctx, s.servoIDs())
+	if err != nil {
+		s.logs.Warnf("collision-load", "Failed to read joint loads, collision detection is not running: %v", err)
+		return nil
+	}
+	id, collided := s.collision.observe(loads)
+	if !collided {
+		return nil
+	}
+
+	joint := jointNameForServo(id)
+	s.logger.Warnf("Collision detected on joint %s (load %.0f%%), stopping", joint, loads[id])
+	s.events.add(eventWarning, "collision detected on joint %s at %.0f%% load", joint, loads[id])
+	if err := s.Stop(ctx, nil); err != nil {
+		s.logger.Errorf("Failed to stop after collision: %v", err)
+	}
+	if s.collision.torquePercent > 0 {
+		if err := s.reduceCollisionTorque(ctx); err != nil {
+			s.logger.Errorf("Failed to reduce torque after collision: %v", err)
+		}
+	}
+	return fmt.Errorf("%w on joint %s at %.0f%% load", ErrCollision, joint, loads[id])
+}
+
+// reduceCollisionTorque lowers every arm joint's torque limit to collision_torque_percent,
+// saving the previous limits for clear_collision
+func (s *so101) reduceCollisionTorque(ctx context.Context) error {
+	value := int(math.Round(s.collision.torquePercent * 10))
+	for _, servoID := range s.servoIDs() {
+		s.collision.mu.Lock()
+		_, saved := s.collision.savedLimits[servoID]
+		s.collision.mu.Unlock()
+		if !saved {
+			current, err := s.controller.ReadServoRegister(ctx, servoID, "torque_limit")
+			if err != nil {
+				return fmt.Errorf("failed to read torque limit of servo %d: %w", servoID, err)
+			}
+			s.collision.mu.Lock()
+			s.collision.savedLimits[servoID] = current
+			s.collision.mu.Unlock()
+		}
+		if err := s.controller.WriteServoRegister(ctx, servoID, "torque_limit", s.controller.protocolFor(servoID).EncodeWord(uint16(value))); err != nil {
+			return fmt.Errorf("failed to reduce torque limit of servo %d: %w", servoID, err)
+		}
+	}
+	return nil
+}
+
+// clearCollision restores torque limits reduced by a collision
+func (s *so101) clearCollision(ctx context.Context) error {
+	s.collision.mu.Lock()
+	saved := s.collision.savedLimits
+	s.collision.savedLimits = map[int][]byte{}
+	s.collision.mu.Unlock()
+
+	for servoID, limit := range saved {
+		if err := s.controller.WriteServoRegister(ctx, servoID, "torque_limit", limit); err != nil {
+			return fmt.Errorf("failed to restore torque limit of servo %d: %w", servoID, err)
+		}
+	}
+	return nil
+}
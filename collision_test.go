@@ -0,0 +1,63 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestCollisionDetector(t *testing.T) {
+	assert.Nil(t, newCollisionDetector(0, 0, 0))
+	assert.Equal(t, false, (*collisionDetector)(nil).status()["enabled"])
+
+	c := newCollisionDetector(50, 0, 0)
+	assert.Equal(t, defaultCollisionSamples, c.samples)
+
+	// A spike shorter than the sample count is ignored
+	_, hit := c.observe(map[int]float64{2: 60})
+	assert.False(t, hit)
+	_, hit = c.observe(map[int]float64{2: 10})
+	assert.False(t, hit)
+
+	// Negative loads count too
+	c.observe(map[int]float64{2: -70})
+	c.observe(map[int]float64{2: -70})
+	id, hit := c.observe(map[int]float64{2: -70})
+	assert.True(t, hit)
+	assert.Equal(t, 2, id)
+
+	last := c.status()["last_collision"].(map[string]interface{})
+	assert.Equal(t, jointNameForServo(2), last["joint"])
+	assert.Equal(t, -70.0, last["load_percent"])
+
+	c.observe(map[int]float64{3: 90})
+	c.reset()
+	_, hit = c.observe(map[int]float64{3: 90})
+	assert.False(t, hit, "reset clears partial counts")
+}
+
+func TestCheckCollisionReadsLoadsFromBus(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, fake := newFakeController(t, ids...)
+	s := &so101{
+		cfg:         &SO101ArmConfig{},
+		controller:  controller,
+		armServoIDs: ids,
+		logger:      logger,
+		logs:        newRateLimitedLogger(logger, 0),
+		events:      &eventLog{},
+		collision:   newCollisionDetector(50, 1, 0),
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, s.checkCollision(ctx))
+
+	// 80% on the elbow stops the arm where it is
+	fake.setRegister(3, feetech.RegPresentLoad, []byte{0x20, 0x03})
+	assert.ErrorIs(t, s.checkCollision(ctx), ErrCollision)
+	assert.NotEmpty(t, fake.writesTo(3, feetech.RegGoalPosition))
+}
@@ -0,0 +1,189 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Compliance mode defaults. torque_limit is in 0.1% of stall torque, so 300 is 30%.
+const (
+	complianceDefaultTorqueLimitPercent = 30.0
+	complianceDefaultPGain              = 8
+	complianceFollowInterval            = 50 * time.Millisecond
+	// How far a joint must be pushed before its goal follows, small enough to feel free but
+	// larger than the sag of a weakened joint under gravity
+	complianceFollowThresholdDegs = 3.0
+)
+
+// servoGains are the registers compliance mode lowers, saved so they can be restored
+type servoGains struct {
+	torqueLimit []byte
+	pGain       []byte
+}
+
+// complianceState tracks an active compliance mode
+type complianceState struct {
+	saved  map[int]servoGains
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// setComplianceMode switches the arm into or out of a compliant teaching mode. Instead of
+// disabling torque, the torque limit and P gain are lowered so the arm can be pushed by hand
+// but still holds against gravity, and each joint's goal follows wherever it's pushed.
+func (s *so101) setComplianceMode(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	servoIDs := s.servoIDs()
+	enable, ok := cmd["enable"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("compliance_mode requires 'enable' boolean parameter")
+	}
+	if !enable {
+		if err := s.disableCompliance(ctx); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"success": true, "compliance_mode": false}, nil
+	}
+	if err := s.maintenance.check(); err != nil {
+		return nil, err
+	}
+
+	torquePercent := complianceDefaultTorqueLimitPercent
+	if v, ok := cmd["torque_limit_percent"].(float64); ok {
+		torquePercent = v
+	}
+	if torquePercent <= 0 || torquePercent > 100 {
+		return nil, fmt.Errorf("torque_limit_percent must be in (0, 100], got %.1f", torquePercent)
+	}
+	pGain := complianceDefaultPGain
+	if v, ok := cmd["p_gain"].(float64); ok {
+		pGain = int(v)
+	}
+	if pGain < 1 || pGain > 254 {
+		return nil, fmt.Errorf("p_gain must be between 1 and 254, got %d", pGain)
+	}
+
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	s.mu.Lock()
+	active := s.compliance != nil
+	s.mu.Unlock()
+	if active {
+		return nil, fmt.Errorf("compliance mode is already enabled, disable it before changing its settings")
+	}
+
+	saved := map[int]servoGains{}
+	for _, id := range servoIDs {
+		torqueLimit, err := s.controller.ReadServoRegister(ctx, id, "torque_limit")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read torque limit of servo %d: %w", id, err)
+		}
+		gain, err := s.controller.ReadServoRegister(ctx, id, "p_gain")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read P gain of servo %d: %w", id, err)
+		}
+		saved[id] = servoGains{torqueLimit: torqueLimit, pGain: gain}
+	}
+
+	torqueLimit := int(math.Round(torquePercent * 10))
+	for _, id := range servoIDs {
+		err := s.controller.WriteServoRegister(ctx, id, "torque_limit", s.controller.protocolFor(id).EncodeWord(uint16(torqueLimit)))
+		if err == nil {
+			err = s.controller.WriteServoRegister(ctx, id, "p_gain", []byte{byte(pGain)})
+		}
+		if err != nil {
+			s.restoreGains(ctx, saved)
+			return nil, fmt.Errorf("failed to lower gains of servo %d: %w", id, err)
+		}
+	}
+
+	followCtx, cancel := context.WithCancel(s.cancelCtx)
+	state := &complianceState{saved: saved, cancel: cancel, done: make(chan struct{})}
+	s.mu.Lock()
+	s.compliance = state
+	s.mu.Unlock()
+	go s.followPushes(followCtx, state.done)
+
+	return map[string]interface{}{
+		"success":              true,
+		"compliance_mode":      true,
+		"torque_limit_percent": torquePercent,
+		"p_gain":               pGain,
+	}, nil
+}
+
+// disableCompliance stops following pushes and restores the saved gains
+func (s *so101) disableCompliance(ctx context.Context) error {
+	s.mu.Lock()
+	state := s.compliance
+	s.compliance = nil
+	s.mu.Unlock()
+	if state == nil {
+		return nil
+	}
+
+	state.cancel()
+	<-state.done
+
+	if err := s.restoreGains(ctx, state.saved); err != nil {
+		return err
+	}
+	// Hold where the arm was left rather than where the last goal was
+	return s.controller.StopServos(ctx, s.servoIDs())
+}
+
+// complianceEnabled reports whether compliance mode is active
+func (s *so101) complianceEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.compliance != nil
+}
+
+func (s *so101) restoreGains(ctx context.Context, saved map[int]servoGains) error {
+	var firstErr error
+	for id, gains := range saved {
+		if err := s.controller.WriteServoRegister(ctx, id, "torque_limit", gains.torqueLimit); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to restore torque limit of servo %d: %w", id, err)
+		}
+		if err := s.controller.WriteServoRegister(ctx, id, "p_gain", gains.pGain); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to restore P gain of servo %d: %w", id, err)
+		}
+	}
+	return firstErr
+}
+
+// followPushes moves each joint's goal to where it has been pushed, so the arm stays where
+// it's placed instead of springing back
+func (s *so101) followPushes(ctx context.Context, done chan struct{}) {
+	servoIDs := s.servoIDs()
+	defer close(done)
+
+	ticker := time.NewTicker(complianceFollowInterval)
+	defer ticker.Stop()
+
+	threshold := DegreesToRadians(complianceFollowThresholdDegs)
+	var goal []float64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		present, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+		if err != nil {
+			s.logs.Warnf("compliance-read", "Compliance mode failed to read joint positions: %v", err)
+			continue
+		}
+		if goal != nil && withinTolerance(present, goal, threshold) {
+			continue
+		}
+		if err := s.controller.MoveServosToPositions(ctx, servoIDs, present, 0, 0); err != nil {
+			s.logs.Warnf("compliance-write", "Compliance mode failed to update joint goals: %v", err)
+			continue
+		}
+		goal = present
+	}
+}
@@ -0,0 +1,48 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestComplianceModeRestoresGains(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, fake := newFakeController(t, ids...)
+	for _, id := range ids {
+		fake.setRegister(id, feetech.RegTorqueLimit, []byte{0xE8, 0x03})
+		fake.setRegister(id, feetech.RegPGain, []byte{32})
+	}
+	s := &so101{
+		cfg:         &SO101ArmConfig{},
+		controller:  controller,
+		armServoIDs: ids,
+		cancelCtx:   context.Background(),
+		logger:      logger,
+		logs:        newRateLimitedLogger(logger, logRateLimitInterval(false)),
+	}
+	ctx := context.Background()
+
+	_, err := s.setComplianceMode(ctx, map[string]interface{}{"enable": true, "torque_limit_percent": 30.0})
+	assert.NoError(t, err)
+	for _, id := range ids {
+		assert.Equal(t, []byte{0x2C, 0x01}, fake.register(id, feetech.RegTorqueLimit))
+		assert.Equal(t, []byte{complianceDefaultPGain}, fake.register(id, feetech.RegPGain))
+	}
+
+	// Moves would fight the hand guiding the arm
+	_, _, _, err = s.startMove(ctx, make([]float64, len(ids)), motionParams{})
+	assert.ErrorContains(t, err, "compliance mode is enabled")
+
+	_, err = s.setComplianceMode(ctx, map[string]interface{}{"enable": false})
+	assert.NoError(t, err)
+	assert.False(t, s.complianceEnabled())
+	for _, id := range ids {
+		assert.Equal(t, []byte{0xE8, 0x03}, fake.register(id, feetech.RegTorqueLimit))
+		assert.Equal(t, []byte{32}, fake.register(id, feetech.RegPGain))
+	}
+}
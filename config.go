@@ -20,6 +20,18 @@ type SoArm101Config struct {
 
 	Timeout time.Duration `json:"timeout,omitempty"`
 
+	// Bus protocol, "sts" (default) or "scs", and servos that speak SCS on an STS bus
+	Protocol    string `json:"protocol,omitempty"`
+	SCSServoIDs []int  `json:"scs_servo_ids,omitempty"`
+
+	// How serial reads, writes and pings are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Second adapter on the same bus, held open in standby and switched to when the port fails
+	SecondaryPort string `json:"secondary_port,omitempty"`
+	// Failed transactions in a row on the port that trigger the switch, default 10
+	FailoverAfterFailures int `json:"failover_after_failures,omitempty"`
+
 	SpeedDegsPerSec        float32 `json:"speed_degs_per_sec,omitempty"`
 	AccelerationDegsPerSec float32 `json:"acceleration_degs_per_sec_per_sec,omitempty"`
 
@@ -85,9 +97,59 @@ func (cfg *SoArm101Config) Validate(path string) ([]string, []string, error) {
 		cfg.Baudrate = 1000000
 	}
 
+	if err := validateBusProtocol(cfg.Protocol, cfg.SCSServoIDs); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Retry.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := validateFailover(cfg.Port, cfg.SecondaryPort, cfg.FailoverAfterFailures); err != nil {
+		return nil, nil, err
+	}
+
 	return nil, nil, nil
 }
 
+// moduleDataDir returns the directory used for persistent module data
+func moduleDataDir() string {
+	dir := os.Getenv("VIAM_MODULE_DATA")
+	if dir == "" {
+		dir = "/tmp" // Fallback if VIAM_MODULE_DATA not set
+	}
+	return dir
+}
+
+// resolveCalibrationPath makes a relative calibration file path relative to VIAM_MODULE_DATA
+func resolveCalibrationPath(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(moduleDataDir(), file)
+}
+
+// firstCalibrationFile returns the name of the calibration file in dir for a port: the port's
+// own file, such as ttyUSB0_calibration.json, else so101_calibration.json. It returns "" if
+// neither exists.
+func firstCalibrationFile(dir, portSuffix string) string {
+	for _, name := range []string{portSuffix + "_calibration.json", "so101_calibration.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// portCalibrationFile returns the calibration file an arm or gripper on port uses when no
+// calibration_file is configured: the port's own file, if it exists. The shared
+// so101_calibration.json could belong to any arm, so it's only used when named explicitly.
+func portCalibrationFile(port string) string {
+	name := extractPortSuffix(port) + "_calibration.json"
+	if _, err := os.Stat(filepath.Join(moduleDataDir(), name)); err != nil {
+		return ""
+	}
+	return name
+}
+
 // LoadCalibration loads calibration from file or returns default calibration
 // Returns (calibration, fromFile) where fromFile indicates if loaded from file
 func (cfg *SoArm101Config) LoadCalibration(logger logging.Logger) (SO101FullCalibration, bool) {
@@ -98,14 +160,7 @@ func (cfg *SoArm101Config) LoadCalibration(logger logging.Logger) (SO101FullCali
 		return DefaultSO101FullCalibration, false
 	}
 
-	// Handle relative paths using VIAM_MODULE_DATA
-	if !filepath.IsAbs(cfg.CalibrationFile) {
-		moduleDataDir := os.Getenv("VIAM_MODULE_DATA")
-		if moduleDataDir == "" {
-			moduleDataDir = "/tmp" // Fallback if VIAM_MODULE_DATA not set
-		}
-		cfg.CalibrationFile = filepath.Join(moduleDataDir, cfg.CalibrationFile)
-	}
+	cfg.CalibrationFile = resolveCalibrationPath(cfg.CalibrationFile)
 
 	calibration, err := LoadFullCalibrationFromFile(cfg.CalibrationFile, logger)
 	if err != nil {
@@ -121,14 +176,15 @@ func (cfg *SoArm101Config) LoadCalibration(logger logging.Logger) (SO101FullCali
 	return calibration, true
 }
 
-// Maintains backward compatibility with existing calibration files
+// Maintains backward compatibility with existing calibration files. Joints that weren't
+// calibrated, such as the gripper on a gripper-less build, are left out.
 type CalibrationFileFormat struct {
-	ShoulderPan  *CalibrationEntry `json:"shoulder_pan"`
-	ShoulderLift *CalibrationEntry `json:"shoulder_lift"`
-	ElbowFlex    *CalibrationEntry `json:"elbow_flex"`
-	WristFlex    *CalibrationEntry `json:"wrist_flex"`
-	WristRoll    *CalibrationEntry `json:"wrist_roll"`
-	Gripper      *CalibrationEntry `json:"gripper"`
+	ShoulderPan  *CalibrationEntry `json:"shoulder_pan,omitempty"`
+	ShoulderLift *CalibrationEntry `json:"shoulder_lift,omitempty"`
+	ElbowFlex    *CalibrationEntry `json:"elbow_flex,omitempty"`
+	WristFlex    *CalibrationEntry `json:"wrist_flex,omitempty"`
+	WristRoll    *CalibrationEntry `json:"wrist_roll,omitempty"`
+	Gripper      *CalibrationEntry `json:"gripper,omitempty"`
 }
 
 type CalibrationEntry struct {
@@ -179,26 +235,38 @@ func LoadFullCalibrationFromFile(filePath string, logger logging.Logger) (SO101F
 	if err != nil {
 		return SO101FullCalibration{}, fmt.Errorf("failed to read calibration file: %w", err)
 	}
+	return ParseFullCalibration(data, logger)
+}
 
+// ParseFullCalibration parses and validates calibration file JSON. Missing joints use the default calibration.
+func ParseFullCalibration(data []byte, logger logging.Logger) (SO101FullCalibration, error) {
 	var fileFormat CalibrationFileFormat
 	if err := json.Unmarshal(data, &fileFormat); err != nil {
 		return SO101FullCalibration{}, fmt.Errorf("failed to parse calibration JSON: %w", err)
 	}
 
-	convertOrDefault := func(entry *CalibrationEntry, defaultCal *MotorCalibration) *MotorCalibration {
+	var missing []string
+	convertOrDefault := func(name string, entry *CalibrationEntry, defaultCal *MotorCalibration) *MotorCalibration {
 		if entry != nil {
 			return entry.ToMotorCalibration()
 		}
+		missing = append(missing, name)
 		return defaultCal
 	}
 
 	calibration := SO101FullCalibration{
-		ShoulderPan:  convertOrDefault(fileFormat.ShoulderPan, DefaultSO101FullCalibration.ShoulderPan),
-		ShoulderLift: convertOrDefault(fileFormat.ShoulderLift, DefaultSO101FullCalibration.ShoulderLift),
-		ElbowFlex:    convertOrDefault(fileFormat.ElbowFlex, DefaultSO101FullCalibration.ElbowFlex),
-		WristFlex:    convertOrDefault(fileFormat.WristFlex, DefaultSO101FullCalibration.WristFlex),
-		WristRoll:    convertOrDefault(fileFormat.WristRoll, DefaultSO101FullCalibration.WristRoll),
-		Gripper:      convertOrDefault(fileFormat.Gripper, DefaultSO101FullCalibration.Gripper),
+		ShoulderPan:  convertOrDefault("shoulder_pan", fileFormat.ShoulderPan, DefaultSO101FullCalibration.ShoulderPan),
+		ShoulderLift: convertOrDefault("shoulder_lift", fileFormat.ShoulderLift, DefaultSO101FullCalibration.ShoulderLift),
+		ElbowFlex:    convertOrDefault("elbow_flex", fileFormat.ElbowFlex, DefaultSO101FullCalibration.ElbowFlex),
+		WristFlex:    convertOrDefault("wrist_flex", fileFormat.WristFlex, DefaultSO101FullCalibration.WristFlex),
+		WristRoll:    convertOrDefault("wrist_roll", fileFormat.WristRoll, DefaultSO101FullCalibration.WristRoll),
+		Gripper:      convertOrDefault("gripper", fileFormat.Gripper, DefaultSO101FullCalibration.Gripper),
+	}
+	if len(missing) == 6 {
+		return SO101FullCalibration{}, fmt.Errorf("calibration file has no joint entries")
+	}
+	if len(missing) > 0 && logger != nil {
+		logger.Debugf("Calibration file has no entry for %v, using defaults", missing)
 	}
 
 	if err := ValidateFullCalibration(calibration, logger); err != nil {
@@ -210,6 +278,20 @@ func LoadFullCalibrationFromFile(filePath string, logger logging.Logger) (SO101F
 
 // SaveFullCalibrationToFile saves calibration to a JSON file
 func SaveFullCalibrationToFile(filePath string, calibration SO101FullCalibration) error {
+	data, err := MarshalFullCalibration(calibration)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write calibration file: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalFullCalibration returns calibration in the calibration file format
+func MarshalFullCalibration(calibration SO101FullCalibration) ([]byte, error) {
 	convertOrNil := func(mc *MotorCalibration) *CalibrationEntry {
 		if mc != nil {
 			return FromMotorCalibration(mc)
@@ -228,14 +310,9 @@ func SaveFullCalibrationToFile(filePath string, calibration SO101FullCalibration
 
 	data, err := json.MarshalIndent(fileFormat, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal calibration: %w", err)
-	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write calibration file: %w", err)
+		return nil, fmt.Errorf("failed to marshal calibration: %w", err)
 	}
-
-	return nil
+	return data, nil
 }
 
 // ValidateFullCalibration validates that all calibration values are reasonable
@@ -353,7 +430,7 @@ func getNormModeForServo(servoID int) int {
 }
 
 // readUint16Register reads a 2-byte register from servo and decodes as uint16
-func readUint16Register(ctx context.Context, servo *feetech.Servo, registerName string) (uint16, error) {
+func readUint16Register(ctx context.Context, servo *feetech.Servo, registerName string, proto *feetech.Protocol) (uint16, error) {
 	data, err := servo.ReadRegister(ctx, registerName)
 	if err != nil {
 		return 0, err
@@ -361,11 +438,11 @@ func readUint16Register(ctx context.Context, servo *feetech.Servo, registerName
 	if len(data) != 2 {
 		return 0, fmt.Errorf("expected 2 bytes for %s, got %d", registerName, len(data))
 	}
-	return uint16(data[0]) | (uint16(data[1]) << 8), nil
+	return proto.DecodeWord(data), nil
 }
 
 // readInt16Register reads a 2-byte register and decodes as signed int16
-func readInt16Register(ctx context.Context, servo *feetech.Servo, registerName string) (int, error) {
+func readInt16Register(ctx context.Context, servo *feetech.Servo, registerName string, proto *feetech.Protocol) (int, error) {
 	data, err := servo.ReadRegister(ctx, registerName)
 	if err != nil {
 		return 0, err
@@ -375,7 +452,7 @@ func readInt16Register(ctx context.Context, servo *feetech.Servo, registerName s
 	}
 
 	// Decode as uint16 first
-	raw := uint16(data[0]) | (uint16(data[1]) << 8)
+	raw := proto.DecodeWord(data)
 
 	// Check if this is a sign-magnitude encoded value (bit 15 is sign bit for STS3215)
 	// For homing_offset, sign bit is at position 11 (12-bit value)
@@ -414,14 +491,14 @@ func ReadCalibrationFromServos(
 		servo := feetech.NewServo(bus, servoID, &feetech.ModelSTS3215)
 
 		// Try reading registers - updated method names
-		homingOffset, offsetErr := readInt16Register(ctx, servo, "position_offset")
-		minLimit, minErr := readUint16Register(ctx, servo, "min_angle_limit")
-		maxLimit, maxErr := readUint16Register(ctx, servo, "max_angle_limit")
+		homingOffset, offsetErr := readInt16Register(ctx, servo, "position_offset", bus.Protocol())
+		minLimit, minErr := readUint16Register(ctx, servo, "min_angle_limit", bus.Protocol())
+		maxLimit, maxErr := readUint16Register(ctx, servo, "max_angle_limit", bus.Protocol())
 
 		// Check if we got valid data
 		if offsetErr == nil && minErr == nil && maxErr == nil {
 			// Validate range limits are within servo resolution
-			if minLimit < maxLimit && maxLimit <= 4095 {
+			if minLimit < maxLimit && maxLimit <= ServoMaxPosition {
 				calibrations[servoID] = &MotorCalibration{
 					ID:           servoID,
 					DriveMode:    0,
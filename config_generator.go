@@ -0,0 +1,160 @@
+package so_arm
+
+import "fmt"
+
+// Questionnaire answers accepted by the discovery generate_config command
+const (
+	roleLeader   = "leader"
+	roleFollower = "follower"
+
+	mountTable = "table"
+	mountRover = "rover"
+
+	speedProfileGentle = "gentle"
+	speedProfileNormal = "normal"
+	speedProfileFast   = "fast"
+)
+
+// speedProfiles maps a speed profile to speed_degs_per_sec and acceleration_degs_per_sec_per_sec
+var speedProfiles = map[string][2]float64{
+	speedProfileGentle: {25, 50},
+	speedProfileNormal: {50, 100},
+	speedProfileFast:   {100, 250},
+}
+
+// configAnswers are the answers to the first-time setup questionnaire
+type configAnswers struct {
+	Role            string
+	Mount           string
+	HasGripper      bool
+	SpeedProfile    string
+	Port            string
+	CalibrationFile string
+	NameSuffix      string
+}
+
+// parseConfigAnswers reads questionnaire answers from a DoCommand payload, applying defaults
+func parseConfigAnswers(cmd map[string]interface{}) (configAnswers, error) {
+	answers := configAnswers{
+		Role:         roleFollower,
+		Mount:        mountTable,
+		HasGripper:   true,
+		SpeedProfile: speedProfileNormal,
+		Port:         "/dev/ttyUSB0",
+	}
+
+	fields := map[string]*string{
+		"role":             &answers.Role,
+		"mount":            &answers.Mount,
+		"speed_profile":    &answers.SpeedProfile,
+		"port":             &answers.Port,
+		"calibration_file": &answers.CalibrationFile,
+	}
+	for key, dest := range fields {
+		if v, ok := cmd[key]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return answers, fmt.Errorf("%s must be a string", key)
+			}
+			*dest = s
+		}
+	}
+	if v, ok := cmd["has_gripper"]; ok {
+		b, ok := v.(bool)
+		if !ok {
+			return answers, fmt.Errorf("has_gripper must be a boolean")
+		}
+		answers.HasGripper = b
+	}
+
+	switch answers.Role {
+	case roleLeader, roleFollower:
+	default:
+		return answers, fmt.Errorf("role must be %q or %q, got %q", roleLeader, roleFollower, answers.Role)
+	}
+	switch answers.Mount {
+	case mountTable, mountRover:
+	default:
+		return answers, fmt.Errorf("mount must be %q or %q, got %q", mountTable, mountRover, answers.Mount)
+	}
+	if _, ok := speedProfiles[answers.SpeedProfile]; !ok {
+		return answers, fmt.Errorf("speed_profile must be %q, %q or %q, got %q",
+			speedProfileGentle, speedProfileNormal, speedProfileFast, answers.SpeedProfile)
+	}
+	if answers.Port == "" {
+		return answers, fmt.Errorf("port must not be empty")
+	}
+
+	answers.NameSuffix = answers.Role + "-" + extractPortSuffix(answers.Port)
+	return answers, nil
+}
+
+// generateMachineConfig builds a recommended machine config block from questionnaire answers
+func generateMachineConfig(answers configAnswers) map[string]interface{} {
+	profile := speedProfiles[answers.SpeedProfile]
+	speed, acc := profile[0], profile[1]
+	notes := []interface{}{}
+
+	armAttrs := map[string]interface{}{
+		"port":                              answers.Port,
+		"speed_degs_per_sec":                speed,
+		"acceleration_degs_per_sec_per_sec": acc,
+	}
+	if answers.CalibrationFile != "" {
+		armAttrs["calibration_file"] = answers.CalibrationFile
+	} else {
+		notes = append(notes, "No calibration file given, run the calibration sensor workflow and add calibration_file to the arm and gripper")
+	}
+
+	switch answers.Role {
+	case roleLeader:
+		// A leader is moved by hand, so report those moves and stay put if a move is cancelled
+		armAttrs["is_moving_source"] = isMovingSourceHardware
+		armAttrs["on_cancel"] = onCancelHold
+		notes = append(notes, "Leader arms are usually moved by hand, disable torque with the set_torque command before teleoperating")
+	case roleFollower:
+		armAttrs["watch_calibration_file"] = answers.CalibrationFile != ""
+	}
+
+	if answers.Mount == mountRover {
+		// A moving base makes fast swings tip the rover, and a stowed arm is safer to drive with
+		armAttrs["acceleration_degs_per_sec_per_sec"] = acc / 2
+		armAttrs["on_cancel"] = onCancelPark
+		armAttrs["park_pose"] = []interface{}{0.0, -90.0, 90.0, 45.0, 0.0}
+		notes = append(notes, "park_pose is a folded pose, adjust it so the arm clears the rover while driving")
+	}
+
+	components := []interface{}{
+		map[string]interface{}{
+			"name":       "so101-arm-" + answers.NameSuffix,
+			"api":        "rdk:component:arm",
+			"model":      SO101Model.String(),
+			"attributes": armAttrs,
+		},
+	}
+
+	if answers.HasGripper {
+		gripperAttrs := map[string]interface{}{"port": answers.Port}
+		if answers.CalibrationFile != "" {
+			gripperAttrs["calibration_file"] = answers.CalibrationFile
+		}
+		components = append(components, map[string]interface{}{
+			"name":       "so101-gripper-" + answers.NameSuffix,
+			"api":        "rdk:component:gripper",
+			"model":      SO101GripperModel.String(),
+			"attributes": gripperAttrs,
+		})
+	}
+
+	components = append(components, map[string]interface{}{
+		"name":       "so101-calibration-" + answers.NameSuffix,
+		"api":        "rdk:component:sensor",
+		"model":      SO101CalibrationSensorModel.String(),
+		"attributes": map[string]interface{}{"port": answers.Port},
+	})
+
+	return map[string]interface{}{
+		"components": components,
+		"notes":      notes,
+	}
+}
@@ -0,0 +1,60 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMachineConfig(t *testing.T) {
+	answers, err := parseConfigAnswers(map[string]interface{}{
+		"role":          "leader",
+		"mount":         "rover",
+		"has_gripper":   false,
+		"speed_profile": "gentle",
+		"port":          "/dev/ttyACM0",
+	})
+	assert.NoError(t, err)
+
+	config := generateMachineConfig(answers)
+	components := config["components"].([]interface{})
+	assert.Len(t, components, 2)
+
+	armConfig := components[0].(map[string]interface{})
+	assert.Equal(t, "so101-arm-leader-ttyACM0", armConfig["name"])
+	attrs := armConfig["attributes"].(map[string]interface{})
+	assert.Equal(t, "/dev/ttyACM0", attrs["port"])
+	assert.Equal(t, 25.0, attrs["speed_degs_per_sec"])
+	assert.Equal(t, 25.0, attrs["acceleration_degs_per_sec_per_sec"])
+	assert.Equal(t, isMovingSourceHardware, attrs["is_moving_source"])
+	assert.Equal(t, onCancelPark, attrs["on_cancel"])
+
+	// The generated arm attributes must pass validation
+	cfg := &SO101ArmConfig{
+		Port:     attrs["port"].(string),
+		OnCancel: attrs["on_cancel"].(string),
+	}
+	for _, v := range attrs["park_pose"].([]interface{}) {
+		cfg.ParkPose = append(cfg.ParkPose, v.(float64))
+	}
+	_, _, err = cfg.Validate("")
+	assert.NoError(t, err)
+
+	calibration := components[1].(map[string]interface{})
+	assert.Equal(t, SO101CalibrationSensorModel.String(), calibration["model"])
+}
+
+func TestParseConfigAnswers(t *testing.T) {
+	answers, err := parseConfigAnswers(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, roleFollower, answers.Role)
+	assert.True(t, answers.HasGripper)
+	assert.Len(t, generateMachineConfig(answers)["components"], 3)
+
+	_, err = parseConfigAnswers(map[string]interface{}{"role": "puppeteer"})
+	assert.Error(t, err)
+	_, err = parseConfigAnswers(map[string]interface{}{"speed_profile": "ludicrous"})
+	assert.Error(t, err)
+	_, err = parseConfigAnswers(map[string]interface{}{"has_gripper": "yes"})
+	assert.Error(t, err)
+}
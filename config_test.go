@@ -2,7 +2,9 @@ package so_arm
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"go.viam.com/rdk/logging"
@@ -63,6 +65,46 @@ func TestLoadCalibrationFromFile(t *testing.T) {
 	})
 }
 
+func TestPortCalibrationFile(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("VIAM_MODULE_DATA", dataDir)
+
+	arm := &SO101ArmConfig{Port: "/dev/ttyUSB0"}
+	if _, _, err := applyArmDefaults(arm); err != nil {
+		t.Fatal(err)
+	}
+	if arm.CalibrationFile != "" {
+		t.Errorf("Expected no calibration file without any saved, got %q", arm.CalibrationFile)
+	}
+
+	for _, name := range []string{"so101_calibration.json", "ttyUSB0_calibration.json"} {
+		if err := SaveFullCalibrationToFile(filepath.Join(dataDir, name), DefaultSO101FullCalibration); err != nil {
+			t.Fatalf("Failed to create test calibration file: %v", err)
+		}
+	}
+
+	arm = &SO101ArmConfig{Port: "/dev/ttyUSB0"}
+	if _, _, err := applyArmDefaults(arm); err != nil {
+		t.Fatal(err)
+	}
+	if arm.CalibrationFile != "ttyUSB0_calibration.json" {
+		t.Errorf("Expected the port's calibration file, got %q", arm.CalibrationFile)
+	}
+
+	gripper := &SO101GripperConfig{Port: "/dev/ttyUSB1"}
+	applyGripperDefaults(gripper)
+	if gripper.CalibrationFile != "" {
+		t.Errorf("Expected no calibration file for a port without its own, got %q", gripper.CalibrationFile)
+	}
+
+	// A configured file is left alone
+	gripper = &SO101GripperConfig{Port: "/dev/ttyUSB0", CalibrationFile: "mine.json"}
+	applyGripperDefaults(gripper)
+	if gripper.CalibrationFile != "mine.json" {
+		t.Errorf("Expected the configured calibration file, got %q", gripper.CalibrationFile)
+	}
+}
+
 func TestGetNormModeForServo(t *testing.T) {
 	tests := []struct {
 		servoID  int
@@ -130,3 +172,37 @@ func TestValidateServoRegisterValues(t *testing.T) {
 		})
 	}
 }
+
+func TestCalibrationFileWithoutGripper(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	armOnly := DefaultSO101FullCalibration
+	armOnly.Gripper = nil
+
+	calibFile := filepath.Join(t.TempDir(), "arm_only.json")
+	if err := SaveFullCalibrationToFile(calibFile, armOnly); err != nil {
+		t.Fatalf("Failed to save calibration: %v", err)
+	}
+	data, err := os.ReadFile(calibFile)
+	if err != nil {
+		t.Fatalf("Failed to read calibration: %v", err)
+	}
+	if strings.Contains(string(data), "gripper") {
+		t.Errorf("Expected no gripper entry in saved file, got %s", data)
+	}
+
+	cal, err := LoadFullCalibrationFromFile(calibFile, logger)
+	if err != nil {
+		t.Fatalf("Expected file without gripper to load: %v", err)
+	}
+	if !calibrationsEqual(cal.Gripper, DefaultSO101FullCalibration.Gripper) {
+		t.Error("Expected missing gripper to use the default calibration")
+	}
+	if !calibrationsEqual(cal.WristRoll, DefaultSO101FullCalibration.WristRoll) {
+		t.Error("Expected arm joints to load from the file")
+	}
+
+	if _, err := ParseFullCalibration([]byte(`{}`), logger); err == nil {
+		t.Error("Expected a file with no joint entries to be rejected")
+	}
+}
@@ -0,0 +1,100 @@
+package so_arm
+
+import (
+	"fmt"
+	"time"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/logging"
+)
+
+// controlServoIDs are the servos the auxiliary control components act on: the five arm
+// joints and the gripper
+var controlServoIDs = []int{1, 2, 3, 4, 5, 6}
+
+// SO101ControlConfig is the shared configuration for the torque switch and home button
+type SO101ControlConfig struct {
+	Port            string        `json:"port,omitempty"`
+	Baudrate        int           `json:"baudrate,omitempty"`
+	Timeout         time.Duration `json:"timeout,omitempty"`
+	CalibrationFile string        `json:"calibration_file,omitempty"`
+
+	// Bus protocol, "sts" (default) or "scs", and servos that speak SCS on an STS bus
+	Protocol    string `json:"protocol,omitempty"`
+	SCSServoIDs []int  `json:"scs_servo_ids,omitempty"`
+
+	// How serial reads, writes and pings are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Home button only: the arm to move, joint positions to move it to in degrees, and how fast
+	Arm             string    `json:"arm,omitempty"`
+	HomeDegrees     []float64 `json:"home_degrees,omitempty"`
+	SpeedDegsPerSec float64   `json:"speed_degs_per_sec,omitempty"`
+
+	// Torque switch only: how long turning torque on ramps torque_limit up, 0 for no ramp
+	TorqueRampMs int `json:"torque_ramp_ms,omitempty"`
+}
+
+// defaultHomeSpeedDegsPerSec keeps homing from an unknown pose gentle
+const defaultHomeSpeedDegsPerSec = 30.0
+
+// Validate ensures all parts of the config are valid
+func (cfg *SO101ControlConfig) Validate(path string) ([]string, []string, error) {
+	if cfg.Port == "" {
+		return nil, nil, fmt.Errorf("must specify port for serial communication")
+	}
+
+	if err := validateBusProtocol(cfg.Protocol, cfg.SCSServoIDs); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Retry.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if cfg.HomeDegrees != nil && len(cfg.HomeDegrees) != 5 {
+		return nil, nil, fmt.Errorf("home_degrees must have 5 values, got %d", len(cfg.HomeDegrees))
+	}
+	if cfg.SpeedDegsPerSec != 0 && (cfg.SpeedDegsPerSec < minSpeedDegsPerSec || cfg.SpeedDegsPerSec > maxSpeedDegsPerSec) {
+		return nil, nil, fmt.Errorf("speed_degs_per_sec must be between %.0f and %.0f, got %.1f",
+			float64(minSpeedDegsPerSec), float64(maxSpeedDegsPerSec), cfg.SpeedDegsPerSec)
+	}
+	if cfg.TorqueRampMs < 0 || cfg.TorqueRampMs > 10000 {
+		return nil, nil, fmt.Errorf("torque_ramp_ms must be between 0 and 10000, got %d", cfg.TorqueRampMs)
+	}
+	if cfg.Arm != "" {
+		return []string{arm.Named(cfg.Arm).String()}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// openControlController gets the shared controller for a control component and registers it
+// as a consumer of the port
+func openControlController(cfg *SO101ControlConfig, name string, logger logging.Logger) (*SafeSoArmController, error) {
+	baudrate := cfg.Baudrate
+	if baudrate == 0 {
+		baudrate = 1000000
+	}
+	controllerConfig := &SoArm101Config{
+		Port:            cfg.Port,
+		Baudrate:        baudrate,
+		ServoIDs:        controlServoIDs,
+		Timeout:         cfg.Timeout,
+		Protocol:        cfg.Protocol,
+		SCSServoIDs:     cfg.SCSServoIDs,
+		Retry:           cfg.Retry,
+		CalibrationFile: cfg.CalibrationFile,
+		Logger:          logger,
+	}
+	controllerConfig.Validate(cfg.CalibrationFile)
+
+	calibration, fromFile := controllerConfig.LoadCalibration(logger)
+	controller, err := GetSharedControllerWithCalibration(controllerConfig, calibration, fromFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared controller: %w", err)
+	}
+	RegisterSharedConsumer(cfg.Port, ConsumerInfo{
+		Name:            name,
+		CalibrationFile: controllerConfig.CalibrationFile,
+		ServoIDs:        controlServoIDs,
+	})
+	return controller, nil
+}
@@ -0,0 +1,128 @@
+package so_arm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/logging"
+)
+
+func TestControlConfigTorqueRamp(t *testing.T) {
+	cfg := &SO101ControlConfig{Port: "/dev/ttyUSB0", TorqueRampMs: 500}
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.TorqueRampMs = -1
+	_, _, err = cfg.Validate("")
+	assert.ErrorContains(t, err, "torque_ramp_ms")
+}
+
+func TestControlConfigArmDependency(t *testing.T) {
+	cfg := &SO101ControlConfig{Port: "/dev/ttyUSB0", Arm: "my-arm"}
+	deps, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{arm.Named("my-arm").String()}, deps)
+}
+
+func TestTorqueSwitchGuardsEnable(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, controlServoIDs...)
+	controller.estop = &emergencyStop{}
+	controller.maintenance = &maintenanceMode{}
+	for _, id := range controlServoIDs {
+		fake.setRegister(id, feetech.RegTorqueLimit, []byte{0xE8, 0x03})
+	}
+	sw := &so101TorqueSwitch{logger: logging.NewTestLogger(t), controller: controller, ramp: 3 * torqueRampStep}
+
+	// Refused before the ramp lowers any torque limit
+	controller.maintenance.set(true, "replacing the wrist servo")
+	assert.ErrorIs(t, sw.SetPosition(ctx, torqueSwitchOn, nil), ErrMaintenanceMode)
+	assert.Empty(t, fake.writesTo(1, feetech.RegTorqueLimit))
+	assert.False(t, controller.TorqueEnabled())
+
+	// Turning torque off is always allowed
+	assert.NoError(t, sw.SetPosition(ctx, torqueSwitchOff, nil))
+	controller.maintenance.set(false, "")
+
+	controller.estop.latch("test")
+	assert.ErrorIs(t, sw.SetPosition(ctx, torqueSwitchOn, nil), ErrEmergencyStop)
+	assert.Empty(t, fake.writesTo(1, feetech.RegTorqueLimit))
+	controller.estop = &emergencyStop{}
+
+	// Torque comes on through the ramp and the limits end where they started
+	assert.NoError(t, sw.SetPosition(ctx, torqueSwitchOn, nil))
+	assert.True(t, controller.TorqueEnabled())
+	assert.Equal(t, []byte{100, 0}, fake.writesTo(1, feetech.RegTorqueLimit)[0])
+	assert.Equal(t, []byte{0xE8, 0x03}, fake.register(1, feetech.RegTorqueLimit))
+
+	assert.ErrorContains(t, sw.SetPosition(ctx, 2, nil), "must be 0 (off) or 1 (on)")
+}
+
+func TestTorqueSwitchCountsTowardArmUsage(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	controller, _ := newFakeController(t, controlServoIDs...)
+	controller.estop = &emergencyStop{}
+	controller.maintenance = &maintenanceMode{}
+	usage := newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger)
+	usage.trackTorque(controller.torque)
+	sw := &so101TorqueSwitch{logger: logger, controller: controller}
+
+	assert.NoError(t, sw.SetPosition(ctx, torqueSwitchOn, nil))
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(t, sw.SetPosition(ctx, torqueSwitchOff, nil))
+
+	// The arm's torque-on time stops growing once the switch turns torque off
+	hours := usage.status()["torque_on_hours"].(float64)
+	assert.Greater(t, hours, 0.0)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, hours, usage.status()["torque_on_hours"])
+}
+
+func TestHomeButtonMovesThroughArm(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, fake := newFakeController(t, controlServoIDs...)
+	controller.estop = &emergencyStop{}
+	controller.maintenance = &maintenanceMode{}
+	s := &so101{
+		cfg: &SO101ArmConfig{JointLimits: map[string]JointLimit{
+			jointNameForServo(1): {MinDegs: -10, MaxDegs: 10},
+		}},
+		controller:     controller,
+		maintenance:    controller.maintenance,
+		armServoIDs:    ids,
+		logger:         logger,
+		logs:           newRateLimitedLogger(logger, 0),
+		events:         &eventLog{},
+		usage:          newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger),
+		waypointFilter: &waypointFilterStats{},
+	}
+	b := &so101HomeButton{
+		logger:      logger,
+		arm:         s,
+		controller:  controller,
+		homeDegrees: []float64{45, 0, 0, 0, 0},
+		speed:       defaultHomeSpeedDegsPerSec,
+	}
+
+	controller.maintenance.set(true, "replacing the wrist servo")
+	assert.ErrorIs(t, b.Push(ctx, nil), ErrMaintenanceMode)
+	controller.maintenance.set(false, "")
+	for _, id := range ids {
+		assert.Empty(t, fake.writesTo(id, feetech.RegAcceleration))
+	}
+
+	// The arm's joint_limits apply to the home pose too
+	assert.NoError(t, b.Push(ctx, nil))
+	limit, err := controller.GetCalibration().GetMotorCalibrationByID(1).Denormalize(10)
+	assert.NoError(t, err)
+	goal := feetech.NewProtocol(feetech.ProtocolSTS).DecodeWord(fake.register(1, feetech.RegGoalPosition))
+	assert.Equal(t, limit, int(goal))
+}
@@ -0,0 +1,122 @@
+package so_arm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrPossibleDecalibration is returned for moves faster than decalibration_max_speed_degs_per_sec
+// while the possible_decalibration flag is latched
+var ErrPossibleDecalibration = errors.New("possible decalibration")
+
+// decalibrationDetector latches when a joint comes to rest far from where it was commanded,
+// which after a crash usually means a horn slipped on its spline and the calibration no
+// longer matches the arm
+type decalibrationDetector struct {
+	thresholdDegs   float64
+	maxSpeedDegsSec float64
+
+	mu        sync.Mutex
+	latched   bool
+	joint     string
+	errorDegs float64
+	at        time.Time
+}
+
+// newDecalibrationDetector returns nil when decalibration_threshold_degs is unset
+func newDecalibrationDetector(thresholdDegs, maxSpeedDegsSec float64) *decalibrationDetector {
+	if thresholdDegs == 0 {
+		return nil
+	}
+	return &decalibrationDetector{thresholdDegs: thresholdDegs, maxSpeedDegsSec: maxSpeedDegsSec}
+}
+
+// observe compares where the joints stopped with the commanded target, both in radians, and
+// reports whether this latched the flag
+func (d *decalibrationDetector) observe(servoIDs []int, positions, target []float64) bool {
+	if d == nil || len(positions) != len(target) {
+		return false
+	}
+	worst, worstErr := -1, 0.0
+	for i := range target {
+		if e := RadiansToDegrees(math.Abs(positions[i] - target[i])); e > d.thresholdDegs && e > worstErr {
+			worst, worstErr = i, e
+		}
+	}
+	if worst < 0 {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.latched {
+		return false
+	}
+	d.latched = true
+	d.joint = jointNameForServo(servoIDs[worst])
+	d.errorDegs = worstErr
+	d.at = time.Now()
+	return true
+}
+
+// checkSpeed refuses moves above the configured speed while the flag is latched
+func (d *decalibrationDetector) checkSpeed(speedDegsPerSec float64) error {
+	if d == nil || d.maxSpeedDegsSec == 0 {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.latched && speedDegsPerSec > d.maxSpeedDegsSec {
+		return fmt.Errorf("%w: %s stopped %.1f° from its target, moves are limited to %.0f deg/s until clear_decalibration or recalibration",
+			ErrPossibleDecalibration, d.joint, d.errorDegs, d.maxSpeedDegsSec)
+	}
+	return nil
+}
+
+func (d *decalibrationDetector) clear() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.latched = false
+	d.joint = ""
+	d.errorDegs = 0
+	d.at = time.Time{}
+}
+
+func (d *decalibrationDetector) status() map[string]interface{} {
+	if d == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	result := map[string]interface{}{
+		"enabled":        true,
+		"latched":        d.latched,
+		"threshold_degs": d.thresholdDegs,
+	}
+	if d.maxSpeedDegsSec > 0 {
+		result["max_speed_degs_per_sec"] = d.maxSpeedDegsSec
+	}
+	if d.latched {
+		result["joint"] = d.joint
+		result["error_degs"] = d.errorDegs
+		result["time"] = d.at.Format(time.RFC3339)
+	}
+	return result
+}
+
+// checkDecalibration runs when the servos stopped short of target during a move
+func (s *so101) checkDecalibration(positions, target []float64) {
+	if !s.decalibration.observe(s.servoIDs(), positions, target) {
+		return
+	}
+	status := s.decalibration.status()
+	s.logger.Warnf("Joint %s stopped %.1f° from its target, the calibration may no longer match the arm (e.g. a horn slipped). Check it and recalibrate, or clear with clear_decalibration",
+		status["joint"], status["error_degs"])
+	s.events.add(eventWarning, "possible decalibration: %s stopped %.1f° from its target", status["joint"], status["error_degs"])
+}
@@ -0,0 +1,40 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecalibrationDetector(t *testing.T) {
+	assert.Nil(t, newDecalibrationDetector(0, 30))
+	var disabled *decalibrationDetector
+	assert.False(t, disabled.observe([]int{1}, []float64{0}, []float64{1}))
+	assert.NoError(t, disabled.checkSpeed(100))
+	assert.Equal(t, false, disabled.status()["enabled"])
+
+	d := newDecalibrationDetector(10, 30)
+	servoIDs := []int{1, 2, 3}
+	target := []float64{0, DegreesToRadians(45), 0}
+
+	// Stopping a few degrees short is normal sag
+	assert.False(t, d.observe(servoIDs, []float64{0, DegreesToRadians(40), 0}, target))
+	assert.NoError(t, d.checkSpeed(100))
+
+	assert.True(t, d.observe(servoIDs, []float64{DegreesToRadians(12), DegreesToRadians(20), 0}, target))
+	status := d.status()
+	assert.Equal(t, true, status["latched"])
+	assert.Equal(t, "shoulder_lift", status["joint"])
+	assert.InDelta(t, 25, status["error_degs"], 1e-9)
+
+	// The first latch is kept until cleared
+	assert.False(t, d.observe(servoIDs, []float64{0, 0, DegreesToRadians(90)}, target))
+	assert.Equal(t, "shoulder_lift", d.status()["joint"])
+
+	assert.NoError(t, d.checkSpeed(30))
+	assert.ErrorIs(t, d.checkSpeed(50), ErrPossibleDecalibration)
+
+	d.clear()
+	assert.Equal(t, false, d.status()["latched"])
+	assert.NoError(t, d.checkSpeed(50))
+}
@@ -3,8 +3,9 @@ package so_arm
 
 import (
 	"context"
-	"os"
+	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,22 +30,41 @@ func init() {
 		})
 }
 
+// discoveryBaudrate is the only baudrate probed without a baudrates list, the SO-101 default
+const discoveryBaudrate = 1000000
+
 // SO101DiscoveryConfig is the configuration for the discovery service
 type SO101DiscoveryConfig struct {
-	// Empty for now - could add port filters or baudrate options later
+	// Baudrates probed on each port in order, so arms whose servos were set to another rate
+	// are still found. Defaults to 1000000 only.
+	Baudrates []int `json:"baudrates,omitempty"`
 }
 
 // Validate ensures the config is valid
 func (cfg *SO101DiscoveryConfig) Validate(path string) ([]string, []string, error) {
+	for _, baudrate := range cfg.Baudrates {
+		if !slices.Contains(feetech.DefaultBaudRates, baudrate) {
+			return nil, nil, fmt.Errorf("baudrates: %d is not a Feetech baudrate, use one of %v", baudrate, feetech.DefaultBaudRates)
+		}
+	}
 	return nil, nil, nil
 }
 
+// baudrates returns the baudrates to probe, in order
+func (cfg *SO101DiscoveryConfig) baudrates() []int {
+	if len(cfg.Baudrates) == 0 {
+		return []int{discoveryBaudrate}
+	}
+	return cfg.Baudrates
+}
+
 // so101Discovery implements the discovery service
 type so101Discovery struct {
 	resource.Named
 	resource.AlwaysRebuild
 	resource.TriviallyCloseable
-	logger logging.Logger
+	logger    logging.Logger
+	baudrates []int
 }
 
 // newSO101Discovery creates a new SO-101 discovery service
@@ -54,14 +74,15 @@ func newSO101Discovery(
 	conf resource.Config,
 	logger logging.Logger,
 ) (discovery.Service, error) {
-	_, err := resource.NativeConfig[*SO101DiscoveryConfig](conf)
+	cfg, err := resource.NativeConfig[*SO101DiscoveryConfig](conf)
 	if err != nil {
 		return nil, err
 	}
 
 	return &so101Discovery{
-		Named:  conf.ResourceName().AsNamed(),
-		logger: logger,
+		Named:     conf.ResourceName().AsNamed(),
+		logger:    logger,
+		baudrates: cfg.baudrates(),
 	}, nil
 }
 
@@ -101,40 +122,64 @@ func (dis *so101Discovery) DiscoverResources(ctx context.Context, extra map[stri
 	return allConfigs, nil
 }
 
+// DoCommand handles discovery commands
+func (dis *so101Discovery) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	switch cmd["command"] {
+	case "generate_config":
+		answers, err := parseConfigAnswers(cmd)
+		if err != nil {
+			return nil, err
+		}
+		return generateMachineConfig(answers), nil
+	default:
+		return nil, fmt.Errorf("unknown command: %v", cmd["command"])
+	}
+}
+
 // discoverPort validates a single port and generates component configurations
 func (dis *so101Discovery) discoverPort(ctx context.Context, portPath string) []resource.Config {
 	portSuffix := extractPortSuffix(portPath)
 	dis.logger.Debugf("Checking port %s", portPath)
 
-	// Try to open port and ping servos
-	hasArm, hasGripper := dis.pingServos(portPath)
+	// Try to open port and ping servos at each baudrate until one answers
+	var hasArm, hasGripper bool
+	var baudrate int
+	for _, baudrate = range dis.baudrates {
+		if ctx.Err() != nil {
+			return nil
+		}
+		hasArm, hasGripper = dis.pingServos(portPath, baudrate)
+		if hasArm || hasGripper {
+			break
+		}
+	}
 
 	if !hasArm && !hasGripper {
-		dis.logger.Debugf("No SO-101 servos detected on %s", portPath)
+		dis.logger.Debugf("No SO-101 servos detected on %s at %v baud", portPath, dis.baudrates)
 		return nil
 	}
 
-	dis.logger.Infof("Discovered SO-101 on %s (arm: %v, gripper: %v)", portPath, hasArm, hasGripper)
+	dis.logger.Infof("Discovered SO-101 on %s at %d baud (arm: %v, gripper: %v)", portPath, baudrate, hasArm, hasGripper)
+	if baudrate != discoveryBaudrate {
+		dis.logger.Warnf("Servos on %s answer at %d baud instead of %d, the suggested configs set baudrate to match. Run motor setup to move them to %d.",
+			portPath, baudrate, discoveryBaudrate, discoveryBaudrate)
+	}
 
 	// Find calibration file
-	moduleDataDir := os.Getenv("VIAM_MODULE_DATA")
-	if moduleDataDir == "" {
-		moduleDataDir = "/tmp"
-	}
-	calibrationFile := findCalibrationFile(moduleDataDir, portSuffix, dis.logger)
+	calibrationFile := findCalibrationFile(moduleDataDir(), portSuffix, dis.logger)
 
 	// Generate component configs
-	return dis.generateConfigs(portPath, portSuffix, hasArm, hasGripper, calibrationFile)
+	return dis.generateConfigs(portPath, portSuffix, hasArm, hasGripper, calibrationFile, baudrate)
 }
 
-// pingServos attempts to ping servo 1 and servo 6 on the given port
+// pingServos attempts to ping servo 1 and servo 6 on the given port at one baudrate
 // Returns (hasArm, hasGripper)
-func (dis *so101Discovery) pingServos(portPath string) (bool, bool) {
+func (dis *so101Discovery) pingServos(portPath string, baudrate int) (bool, bool) {
 	ctx := context.Background()
 
 	busConfig := feetech.BusConfig{
 		Port:     portPath,
-		BaudRate: 1000000,
+		BaudRate: baudrate,
 		Protocol: feetech.ProtocolSTS,
 		Timeout:  500 * time.Millisecond,
 	}
@@ -168,6 +213,7 @@ func (dis *so101Discovery) generateConfigs(
 	portPath, portSuffix string,
 	hasArm, hasGripper bool,
 	calibrationFile string,
+	baudrate int,
 ) []resource.Config {
 	var configs []resource.Config
 
@@ -179,6 +225,9 @@ func (dis *so101Discovery) generateConfigs(
 		if calibrationFile != "" {
 			attrs["calibration_file"] = calibrationFile
 		}
+		if baudrate != discoveryBaudrate {
+			attrs["baudrate"] = baudrate
+		}
 
 		configs = append(configs, resource.Config{
 			Name:       "so101-arm-" + portSuffix,
@@ -196,6 +245,9 @@ func (dis *so101Discovery) generateConfigs(
 		if calibrationFile != "" {
 			attrs["calibration_file"] = calibrationFile
 		}
+		if baudrate != discoveryBaudrate {
+			attrs["baudrate"] = baudrate
+		}
 
 		configs = append(configs, resource.Config{
 			Name:       "so101-gripper-" + portSuffix,
@@ -207,13 +259,18 @@ func (dis *so101Discovery) generateConfigs(
 
 	// Always generate calibration sensor if either servo responded
 	if hasArm || hasGripper {
+		attrs := map[string]interface{}{
+			"port": portPath,
+		}
+		if baudrate != discoveryBaudrate {
+			attrs["baudrate"] = baudrate
+		}
+
 		configs = append(configs, resource.Config{
-			Name:  "so101-calibration-" + portSuffix,
-			API:   sensor.API,
-			Model: SO101CalibrationSensorModel,
-			Attributes: map[string]interface{}{
-				"port": portPath,
-			},
+			Name:       "so101-calibration-" + portSuffix,
+			API:        sensor.API,
+			Model:      SO101CalibrationSensorModel,
+			Attributes: attrs,
 		})
 	}
 
@@ -270,18 +327,9 @@ func extractPortSuffix(portPath string) string {
 // Tries port-specific file first, then falls back to default
 // Returns just the filename (not full path) or empty string if not found
 func findCalibrationFile(moduleDataDir, portSuffix string, logger logging.Logger) string {
-	// Try port-specific file first: ttyUSB0_calibration.json
-	portSpecific := filepath.Join(moduleDataDir, portSuffix+"_calibration.json")
-	if _, err := os.Stat(portSpecific); err == nil {
-		logger.Debugf("Found port-specific calibration file: %s", filepath.Base(portSpecific))
-		return filepath.Base(portSpecific)
-	}
-
-	// Try default file: so101_calibration.json
-	defaultFile := filepath.Join(moduleDataDir, "so101_calibration.json")
-	if _, err := os.Stat(defaultFile); err == nil {
-		logger.Debugf("Found default calibration file: so101_calibration.json")
-		return "so101_calibration.json"
+	if name := firstCalibrationFile(moduleDataDir, portSuffix); name != "" {
+		logger.Debugf("Found calibration file: %s", name)
+		return name
 	}
 
 	logger.Debug("No calibration file found")
@@ -155,3 +155,33 @@ func TestEnumerateSerialPorts(t *testing.T) {
 	// Ports list can be empty on systems without serial devices
 	t.Logf("Found %d serial ports", len(ports))
 }
+
+func TestDiscoveryBaudrates(t *testing.T) {
+	cfg := &SO101DiscoveryConfig{}
+	assert.Equal(t, []int{1000000}, cfg.baudrates())
+	_, _, err := cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.Baudrates = []int{1000000, 500000, 250000, 128000, 115200}
+	assert.Equal(t, cfg.Baudrates, cfg.baudrates())
+	_, _, err = cfg.Validate("")
+	assert.NoError(t, err)
+
+	cfg.Baudrates = []int{1000000, 9600}
+	_, _, err = cfg.Validate("")
+	assert.ErrorContains(t, err, "9600")
+}
+
+func TestGenerateConfigsBaudrate(t *testing.T) {
+	dis := &so101Discovery{logger: logging.NewTestLogger(t)}
+
+	for _, cfg := range dis.generateConfigs("/dev/ttyUSB0", "ttyUSB0", true, true, "", 1000000) {
+		assert.NotContains(t, cfg.Attributes, "baudrate", cfg.Name)
+	}
+
+	configs := dis.generateConfigs("/dev/ttyUSB0", "ttyUSB0", true, true, "", 115200)
+	assert.Len(t, configs, 3)
+	for _, cfg := range configs {
+		assert.Equal(t, 115200, cfg.Attributes["baudrate"], cfg.Name)
+	}
+}
@@ -0,0 +1,241 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Doctor check outcomes
+const (
+	DoctorOK   = "ok"
+	DoctorWarn = "warn"
+	DoctorFail = "fail"
+	DoctorSkip = "skip"
+)
+
+// DoctorOptions selects what RunDoctor checks. An empty Port checks every candidate port.
+type DoctorOptions struct {
+	Port            string
+	CalibrationFile string
+}
+
+// DoctorCheck is the result of one environment check, with a suggested fix when it isn't ok
+type DoctorCheck struct {
+	Name    string
+	Status  string
+	Message string
+	Fix     string
+}
+
+// RunDoctor checks the environment end to end: data directory, USB serial driver, port
+// enumeration, port permissions and locks, servo ping at each baudrate and the calibration file
+func RunDoctor(ctx context.Context, opts DoctorOptions) []DoctorCheck {
+	checks := []DoctorCheck{
+		checkDataDir(moduleDataDir()),
+		checkSerialDriver(),
+	}
+
+	ports := filterCandidatePorts(enumerateSerialPorts())
+	if opts.Port != "" {
+		ports = []string{opts.Port}
+	}
+	if len(ports) == 0 {
+		checks = append(checks, DoctorCheck{
+			Name:    "port enumeration",
+			Status:  DoctorFail,
+			Message: "no USB serial ports found",
+			Fix:     "check the USB cable (some are charge-only) and that the controller board is powered, then run again",
+		})
+	} else {
+		checks = append(checks, DoctorCheck{
+			Name:    "port enumeration",
+			Status:  DoctorOK,
+			Message: fmt.Sprintf("found %s", strings.Join(ports, ", ")),
+		})
+	}
+
+	for _, port := range ports {
+		access := checkPortAccess(port)
+		checks = append(checks, access)
+		if access.Status == DoctorFail {
+			continue
+		}
+		checks = append(checks, checkServoPing(ctx, port))
+	}
+
+	if opts.CalibrationFile != "" {
+		checks = append(checks, checkCalibrationFile(opts.CalibrationFile))
+	}
+	return checks
+}
+
+// checkDataDir verifies calibration, usage and identity files can be written
+func checkDataDir(dir string) DoctorCheck {
+	check := DoctorCheck{Name: "module data directory"}
+	file, err := os.CreateTemp(dir, ".so101-doctor-*")
+	if err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%s is not writable: %v", dir, err)
+		check.Fix = "set VIAM_MODULE_DATA to a writable directory or fix its permissions"
+		return check
+	}
+	file.Close()
+	os.Remove(file.Name())
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("%s is writable", dir)
+	return check
+}
+
+// checkSerialDriver looks for the kernel drivers used by the SO-101 controller boards
+func checkSerialDriver() DoctorCheck {
+	check := DoctorCheck{Name: "USB serial driver"}
+	if runtime.GOOS != "linux" {
+		check.Status = DoctorSkip
+		check.Message = fmt.Sprintf("driver check is only available on Linux, not %s", runtime.GOOS)
+		return check
+	}
+
+	var found []string
+	for _, driver := range []string{"ch341", "cdc_acm", "ftdi_sio", "cp210x"} {
+		if _, err := os.Stat(filepath.Join("/sys/module", driver)); err == nil {
+			found = append(found, driver)
+		}
+	}
+	if len(found) == 0 {
+		check.Status = DoctorWarn
+		check.Message = "no ch341, cdc_acm, ftdi_sio or cp210x driver is loaded"
+		check.Fix = "plug in the arm, then load the driver for its board, e.g. `sudo modprobe ch341` or `sudo modprobe cdc_acm`"
+		return check
+	}
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("loaded: %s", strings.Join(found, ", "))
+	return check
+}
+
+// checkPortAccess verifies the port can be opened and isn't held by another process
+func checkPortAccess(port string) DoctorCheck {
+	check := DoctorCheck{Name: fmt.Sprintf("%s access", port)}
+	file, err := os.OpenFile(port, os.O_RDWR, 0)
+	if err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("cannot open %s: %v", port, err)
+		switch {
+		case errors.Is(err, os.ErrPermission):
+			check.Fix = permissionFix(port)
+		case errors.Is(err, os.ErrNotExist):
+			check.Fix = "the port doesn't exist, unplug and replug the arm and check the port name"
+		default:
+			check.Fix = "stop any other program using the port, such as a LeRobot script or another viam-server"
+		}
+		return check
+	}
+	file.Close()
+
+	lock, err := acquirePortLock(port)
+	if err != nil {
+		check.Status = DoctorFail
+		check.Message = err.Error()
+		check.Fix = "stop the other process using the port before starting viam-server"
+		return check
+	}
+	lock.Release()
+
+	check.Status = DoctorOK
+	check.Message = "port can be opened"
+	return check
+}
+
+// permissionFix suggests how to get access to a serial port on this OS
+func permissionFix(port string) string {
+	if runtime.GOOS != "linux" {
+		return fmt.Sprintf("give your user read and write access to %s", port)
+	}
+	if u, err := user.Current(); err == nil {
+		if groups, err := u.GroupIds(); err == nil {
+			for _, gid := range groups {
+				if g, err := user.LookupGroupId(gid); err == nil && g.Name == "dialout" {
+					return "you're in the dialout group but the port still can't be opened, log out and back in so the group takes effect, or check udev rules for the port"
+				}
+			}
+		}
+	}
+	return "add your user to the dialout group with `sudo usermod -aG dialout $USER`, then log out and back in"
+}
+
+// checkServoPing pings servos 1-6 at every Feetech baudrate, the SO-101's 1000000 first, and
+// reports where they answer
+func checkServoPing(ctx context.Context, port string) DoctorCheck {
+	check := DoctorCheck{Name: fmt.Sprintf("%s servos", port)}
+	found := map[int][]int{} // baudrate -> responding IDs
+	for _, baudrate := range feetech.DefaultBaudRates {
+		bus, err := feetech.NewBus(feetech.BusConfig{
+			Port:     port,
+			BaudRate: baudrate,
+			Protocol: feetech.ProtocolSTS,
+			Timeout:  100 * time.Millisecond,
+		})
+		if err != nil {
+			check.Status = DoctorFail
+			check.Message = fmt.Sprintf("failed to open bus at %d baud: %v", baudrate, err)
+			return check
+		}
+		for id := 1; id <= 6; id++ {
+			if _, err := bus.Ping(ctx, id); err == nil {
+				found[baudrate] = append(found[baudrate], id)
+			}
+		}
+		bus.Close()
+	}
+
+	if len(found) == 0 {
+		check.Status = DoctorFail
+		check.Message = "no servos answered at any baudrate"
+		check.Fix = "check that the controller board has power (USB alone doesn't power the servos) and the servo cables are seated"
+		return check
+	}
+
+	var parts []string
+	for _, baudrate := range feetech.DefaultBaudRates {
+		if ids, ok := found[baudrate]; ok {
+			parts = append(parts, fmt.Sprintf("%v at %d baud", ids, baudrate))
+		}
+	}
+	check.Message = strings.Join(parts, ", ")
+
+	atDefault := found[1000000]
+	switch {
+	case len(found) > 1 || len(atDefault) == 0:
+		check.Status = DoctorWarn
+		check.Fix = "set every servo to 1000000 baud with the calibration sensor's motor setup commands"
+	case len(atDefault) < 6:
+		check.Status = DoctorWarn
+		check.Fix = "check the cables to the servos that didn't answer, or assign their IDs with the calibration sensor's motor setup commands"
+	default:
+		check.Status = DoctorOK
+	}
+	return check
+}
+
+// checkCalibrationFile verifies a calibration file parses and has valid ranges
+func checkCalibrationFile(file string) DoctorCheck {
+	path := resolveCalibrationPath(file)
+	check := DoctorCheck{Name: "calibration file"}
+	if _, err := LoadFullCalibrationFromFile(path, nil); err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%s: %v", path, err)
+		check.Fix = "recalibrate the arm with the calibration sensor, or point calibration_file at a valid LeRobot calibration"
+		return check
+	}
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("%s is valid", path)
+	return check
+}
@@ -0,0 +1,25 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctorChecks(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, DoctorOK, checkDataDir(dir).Status)
+	assert.Equal(t, DoctorFail, checkDataDir(filepath.Join(dir, "missing")).Status)
+
+	access := checkPortAccess(filepath.Join(dir, "ttyUSB9"))
+	assert.Equal(t, DoctorFail, access.Status)
+	assert.Contains(t, access.Fix, "doesn't exist")
+
+	calPath := filepath.Join(dir, "cal.json")
+	assert.NoError(t, os.WriteFile(calPath, []byte("{not json"), 0644))
+	check := checkCalibrationFile(calPath)
+	assert.Equal(t, DoctorFail, check.Status)
+	assert.NotEmpty(t, check.Fix)
+}
@@ -0,0 +1,298 @@
+package so_arm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/logging"
+)
+
+// How often accumulated usage is flushed to disk while the arm is running
+const usageSaveInterval = 30 * time.Second
+
+// JointUsage holds cumulative wear data for a single joint
+type JointUsage struct {
+	TravelDegrees float64 `json:"travel_degrees"`
+}
+
+// UsageRecord is the persisted duty-cycle data for one arm
+type UsageRecord struct {
+	Joints        map[string]*JointUsage `json:"joints"`
+	TorqueOnHours float64                `json:"torque_on_hours"`
+	LastReset     time.Time              `json:"last_reset"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// Kept only with usage_summary enabled
+	Moves  int            `json:"moves,omitempty"`
+	Errors map[string]int `json:"errors,omitempty"`
+}
+
+// dutyCycleTracker accumulates per-joint travel and torque-on time and persists it in module data
+type dutyCycleTracker struct {
+	mu     sync.Mutex
+	logger logging.Logger
+	path   string
+
+	record   UsageRecord
+	lastSave time.Time
+
+	// Torque-on time comes from the port's torque state, so every path that turns torque on or
+	// off is counted. torqueCounted is how much of its total is already in the record.
+	torque        *torqueState
+	torqueCounted time.Duration
+
+	// Thresholds for maintenance reminders, zero disables the check
+	travelThresholdDegs float64
+	torqueThresholdHrs  float64
+
+	// Count moves and errors for get_usage_summary, opt-in with usage_summary
+	summary bool
+}
+
+// usageFilePath returns the usage file location for the arm on the given port
+func usageFilePath(port string) string {
+	return filepath.Join(moduleDataDir(), extractPortSuffix(port)+"_usage.json")
+}
+
+// newDutyCycleTracker loads existing usage from path, starting fresh if the file is missing or unreadable
+func newDutyCycleTracker(path string, travelThresholdDegs, torqueThresholdHrs float64, logger logging.Logger) *dutyCycleTracker {
+	t := &dutyCycleTracker{
+		logger:              logger,
+		path:                path,
+		travelThresholdDegs: travelThresholdDegs,
+		torqueThresholdHrs:  torqueThresholdHrs,
+		lastSave:            time.Now(),
+	}
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &t.record); err != nil {
+			logger.Warnf("Failed to parse usage file %s, starting fresh: %v", path, err)
+			t.record = UsageRecord{}
+		}
+	} else if !os.IsNotExist(err) {
+		logger.Warnf("Failed to read usage file %s, starting fresh: %v", path, err)
+	}
+
+	if t.record.Joints == nil {
+		t.record.Joints = make(map[string]*JointUsage)
+	}
+	if t.record.LastReset.IsZero() {
+		t.record.LastReset = time.Now()
+	}
+
+	return t
+}
+
+// addTravel records the commanded movement of each joint, in radians
+func (t *dutyCycleTracker) addTravel(servoIDs []int, from, to []float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, servoID := range servoIDs {
+		if i >= len(from) || i >= len(to) {
+			break
+		}
+		name := jointNameForServo(servoID)
+		usage, ok := t.record.Joints[name]
+		if !ok {
+			usage = &JointUsage{}
+			t.record.Joints[name] = usage
+		}
+		usage.TravelDegrees += RadiansToDegrees(math.Abs(to[i] - from[i]))
+	}
+
+	if time.Since(t.lastSave) > usageSaveInterval {
+		t.saveLocked()
+	}
+}
+
+// trackTorque counts torque-on time from the port's torque state from now on
+func (t *dutyCycleTracker) trackTorque(torque *torqueState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.accumulateTorqueLocked()
+	t.torque = torque
+	t.torqueCounted = torque.onTime()
+}
+
+// recordMove counts a commanded move for the usage summary
+func (t *dutyCycleTracker) recordMove() {
+	if !t.summary {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.record.Moves++
+}
+
+// recordError counts a failed operation by type for the usage summary, ignoring cancellations
+func (t *dutyCycleTracker) recordError(err error) {
+	if !t.summary || err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.record.Errors == nil {
+		t.record.Errors = make(map[string]int)
+	}
+	t.record.Errors[usageErrorType(err)]++
+}
+
+// usageErrorType names the kind of a failed operation for the usage summary
+func usageErrorType(err error) string {
+	switch {
+	case errors.Is(err, ErrEmergencyStop):
+		return "emergency_stop"
+	case errors.Is(err, ErrMaintenanceMode):
+		return "maintenance_mode"
+	case errors.Is(err, ErrCalibrationRequired):
+		return "calibration_required"
+	case errors.Is(err, ErrCollision):
+		return "collision"
+	case errors.Is(err, ErrPossibleDecalibration):
+		return "decalibration"
+	case errors.Is(err, ErrRequestTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "other"
+	}
+}
+
+// summaryStatus handles the get_usage_summary DoCommand. The summary stays in module data on
+// this machine and is never uploaded.
+func (t *dutyCycleTracker) summaryStatus() (map[string]interface{}, error) {
+	if !t.summary {
+		return nil, fmt.Errorf("usage summary is not enabled, set usage_summary in the arm config")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accumulateTorqueLocked()
+	errorCounts := make(map[string]interface{}, len(t.record.Errors))
+	total := 0
+	for kind, count := range t.record.Errors {
+		errorCounts[kind] = count
+		total += count
+	}
+	return map[string]interface{}{
+		"moves":           t.record.Moves,
+		"torque_on_hours": t.record.TorqueOnHours,
+		"errors":          errorCounts,
+		"error_count":     total,
+		"since":           t.record.LastReset.Format(time.RFC3339),
+	}, nil
+}
+
+// accumulateTorqueLocked folds the torque-on time since the last call into the record
+func (t *dutyCycleTracker) accumulateTorqueLocked() {
+	onTime := t.torque.onTime()
+	t.record.TorqueOnHours += (onTime - t.torqueCounted).Hours()
+	t.torqueCounted = onTime
+}
+
+// maintenanceDueLocked reports whether any configured threshold has been reached
+func (t *dutyCycleTracker) maintenanceDueLocked() (bool, []string) {
+	reasons := []string{}
+	if t.travelThresholdDegs > 0 {
+		for name, usage := range t.record.Joints {
+			if usage.TravelDegrees >= t.travelThresholdDegs {
+				reasons = append(reasons, fmt.Sprintf("%s travel %.0f° exceeds %.0f°", name, usage.TravelDegrees, t.travelThresholdDegs))
+			}
+		}
+	}
+	if t.torqueThresholdHrs > 0 && t.record.TorqueOnHours >= t.torqueThresholdHrs {
+		reasons = append(reasons, fmt.Sprintf("torque-on time %.1fh exceeds %.1fh", t.record.TorqueOnHours, t.torqueThresholdHrs))
+	}
+	return len(reasons) > 0, reasons
+}
+
+// status returns the usage data and maintenance flag for health output
+func (t *dutyCycleTracker) status() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accumulateTorqueLocked()
+
+	joints := make(map[string]interface{}, len(t.record.Joints))
+	for name, usage := range t.record.Joints {
+		joints[name] = map[string]interface{}{
+			"travel_degrees": usage.TravelDegrees,
+		}
+	}
+
+	due, reasons := t.maintenanceDueLocked()
+	return map[string]interface{}{
+		"maintenance_due":          due,
+		"maintenance_reasons":      reasons,
+		"joints":                   joints,
+		"torque_on_hours":          t.record.TorqueOnHours,
+		"travel_threshold_degrees": t.travelThresholdDegs,
+		"torque_threshold_hours":   t.torqueThresholdHrs,
+		"since":                    t.record.LastReset.Format(time.RFC3339),
+	}
+}
+
+// reset clears accumulated usage, typically after gears or horns have been serviced
+func (t *dutyCycleTracker) reset() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.record = UsageRecord{
+		Joints:    make(map[string]*JointUsage),
+		LastReset: time.Now(),
+	}
+	t.torqueCounted = t.torque.onTime()
+	return t.saveLocked()
+}
+
+// save flushes the usage record to disk
+func (t *dutyCycleTracker) save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accumulateTorqueLocked()
+	return t.saveLocked()
+}
+
+func (t *dutyCycleTracker) saveLocked() error {
+	t.record.UpdatedAt = time.Now()
+	t.lastSave = t.record.UpdatedAt
+
+	data, err := json.MarshalIndent(t.record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage record: %w", err)
+	}
+	if err := os.WriteFile(t.path, data, 0644); err != nil {
+		t.logger.Warnf("Failed to save usage record to %s: %v", t.path, err)
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	return nil
+}
+
+// jointNameForServo returns the SO-101 joint name for a servo ID
+func jointNameForServo(servoID int) string {
+	switch servoID {
+	case 1:
+		return "shoulder_pan"
+	case 2:
+		return "shoulder_lift"
+	case 3:
+		return "elbow_flex"
+	case 4:
+		return "wrist_flex"
+	case 5:
+		return "wrist_roll"
+	case 6:
+		return "gripper"
+	default:
+		return fmt.Sprintf("servo_%d", servoID)
+	}
+}
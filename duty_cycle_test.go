@@ -0,0 +1,96 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestDutyCycleTracker(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	t.Run("accumulates travel and flags maintenance", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "usage.json")
+		tracker := newDutyCycleTracker(path, 100, 0, logger)
+
+		tracker.addTravel([]int{1, 2}, []float64{0, 0}, []float64{math.Pi / 2, -math.Pi / 4})
+
+		status := tracker.status()
+		joints := status["joints"].(map[string]interface{})
+		assert.InDelta(t, 90.0, joints["shoulder_pan"].(map[string]interface{})["travel_degrees"], 1e-9)
+		assert.InDelta(t, 45.0, joints["shoulder_lift"].(map[string]interface{})["travel_degrees"], 1e-9)
+		assert.Equal(t, false, status["maintenance_due"])
+
+		tracker.addTravel([]int{1}, []float64{math.Pi / 2}, []float64{0})
+		assert.Equal(t, true, tracker.status()["maintenance_due"])
+	})
+
+	t.Run("persists across restarts", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "usage.json")
+		tracker := newDutyCycleTracker(path, 0, 0, logger)
+		tracker.addTravel([]int{3}, []float64{0}, []float64{math.Pi})
+		assert.NoError(t, tracker.save())
+
+		reloaded := newDutyCycleTracker(path, 0, 0, logger)
+		assert.InDelta(t, 180.0, reloaded.record.Joints["elbow_flex"].TravelDegrees, 1e-9)
+	})
+
+	t.Run("summary counts moves and errors when enabled", func(t *testing.T) {
+		tracker := newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger)
+		tracker.recordMove()
+		_, err := tracker.summaryStatus()
+		assert.Error(t, err)
+
+		tracker.summary = true
+		tracker.recordMove()
+		tracker.recordMove()
+		tracker.recordError(fmt.Errorf("move: %w", ErrEmergencyStop))
+		tracker.recordError(errors.New("no response from servo 3"))
+		tracker.recordError(context.Canceled)
+
+		summary, err := tracker.summaryStatus()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, summary["moves"])
+		assert.Equal(t, 2, summary["error_count"])
+		assert.Equal(t, map[string]interface{}{"emergency_stop": 1, "other": 1}, summary["errors"])
+
+		assert.NoError(t, tracker.reset())
+		summary, _ = tracker.summaryStatus()
+		assert.Equal(t, 0, summary["moves"])
+	})
+
+	t.Run("reset clears usage", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "usage.json")
+		tracker := newDutyCycleTracker(path, 10, 0, logger)
+		tracker.addTravel([]int{1}, []float64{0}, []float64{math.Pi})
+		assert.NoError(t, tracker.reset())
+
+		assert.Equal(t, false, tracker.status()["maintenance_due"])
+		assert.Empty(t, tracker.record.Joints)
+	})
+
+	t.Run("counts torque-on time from the port's torque state", func(t *testing.T) {
+		ctx := context.Background()
+		controller, _ := newFakeController(t, 1)
+		controller.estop = &emergencyStop{}
+		tracker := newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger)
+		tracker.trackTorque(controller.torque)
+
+		assert.NoError(t, controller.SetTorqueEnable(ctx, true))
+		time.Sleep(20 * time.Millisecond)
+
+		// Torque turned off outside the arm, here by an emergency stop, ends the interval too
+		assert.NoError(t, controller.EmergencyStop(ctx, "test"))
+		hours := tracker.status()["torque_on_hours"].(float64)
+		assert.Greater(t, hours, 0.0)
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, hours, tracker.status()["torque_on_hours"])
+	})
+}
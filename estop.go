@@ -0,0 +1,164 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/pkg/errors"
+	toggleswitch "go.viam.com/rdk/components/switch"
+	"go.viam.com/rdk/logging"
+)
+
+// ErrEmergencyStop is returned for motion and torque commands while an emergency stop is latched
+var ErrEmergencyStop = errors.New("EMERGENCY_STOP: motion is blocked until the emergency stop is cleared")
+
+// estopSwitchPollInterval is how often a configured e-stop switch is read
+const estopSwitchPollInterval = 100 * time.Millisecond
+
+// emergencyStop is the latched fault shared by every component on a port
+type emergencyStop struct {
+	mu     sync.Mutex
+	active bool
+	reason string
+	time   time.Time
+}
+
+// latch records the fault, returning false if it was already latched
+func (e *emergencyStop) latch(reason string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.active {
+		return false
+	}
+	e.active = true
+	e.reason = reason
+	e.time = time.Now()
+	return true
+}
+
+func (e *emergencyStop) clear() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.active = false
+	e.reason = ""
+}
+
+// check returns ErrEmergencyStop while latched
+func (e *emergencyStop) check() error {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.active {
+		return ErrEmergencyStop
+	}
+	return nil
+}
+
+func (e *emergencyStop) status() map[string]interface{} {
+	if e == nil {
+		return map[string]interface{}{"active": false}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := map[string]interface{}{"active": e.active}
+	if e.active {
+		result["reason"] = e.reason
+		result["time"] = e.time.Format(time.RFC3339)
+	}
+	return result
+}
+
+// EmergencyStop latches the port's fault state, disables torque on every servo in one sync
+// write, then zeroes the goal velocity of servos in velocity mode so they don't spin off when
+// torque comes back. The latch is set first so nothing can start moving in between. The goal
+// velocity register is the goal speed in position mode, where 0 means maximum speed, so it's
+// left alone on the other servos.
+func (s *SafeSoArmController) EmergencyStop(ctx context.Context, reason string) error {
+	if s.estop != nil && s.estop.latch(reason) && s.logger != nil {
+		s.logger.Warnf("Emergency stop: %s", reason)
+	}
+
+	s.torque.set(false)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	if err := s.busOp(ctx, s.group.DisableAll); err != nil {
+		firstErr = fmt.Errorf("failed to disable torque: %w", err)
+	}
+	for _, id := range s.velocityModes.servos() {
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			return s.bus.WriteRegister(ctx, id, feetech.RegGoalVelocity.Address, []byte{0, 0})
+		}); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to zero goal velocity on servo %d: %w", id, err)
+		}
+	}
+	return firstErr
+}
+
+// ClearEmergencyStop releases the latch. Torque stays off until something enables it.
+func (s *SafeSoArmController) ClearEmergencyStop() {
+	if s.estop != nil {
+		s.estop.clear()
+	}
+}
+
+// EmergencyStopStatus reports whether the port's emergency stop is latched
+func (s *SafeSoArmController) EmergencyStopStatus() map[string]interface{} {
+	return s.estop.status()
+}
+
+// estopCommand handles the emergency_stop, clear_emergency_stop and emergency_stop_status
+// DoCommands shared by the arm and gripper
+func estopCommand(ctx context.Context, controller *SafeSoArmController, name string, cmd map[string]interface{}) (map[string]interface{}, error) {
+	switch cmd["command"] {
+	case "emergency_stop":
+		reason, _ := cmd["reason"].(string)
+		if reason == "" {
+			reason = fmt.Sprintf("requested by %s", name)
+		}
+		err := controller.EmergencyStop(ctx, reason)
+		result := controller.EmergencyStopStatus()
+		result["success"] = err == nil
+		if err != nil {
+			result["error"] = err.Error()
+		}
+		return result, nil
+	case "clear_emergency_stop":
+		controller.ClearEmergencyStop()
+		result := controller.EmergencyStopStatus()
+		result["success"] = true
+		return result, nil
+	default:
+		return controller.EmergencyStopStatus(), nil
+	}
+}
+
+// watchEstopSwitch triggers the emergency stop whenever the switch is in any position other
+// than 0. An engaged switch re-latches the stop after a clear until it's released.
+func watchEstopSwitch(ctx context.Context, sw toggleswitch.Switch, controller *SafeSoArmController, logger logging.Logger) {
+	ticker := time.NewTicker(estopSwitchPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		position, err := sw.GetPosition(ctx, nil)
+		if err != nil {
+			logger.Debugf("Failed to read e-stop switch: %v", err)
+			continue
+		}
+		if position != 0 && controller.estop.check() == nil {
+			if err := controller.EmergencyStop(ctx, fmt.Sprintf("switch %s engaged", sw.Name().ShortName())); err != nil {
+				logger.Warnf("Emergency stop from switch failed: %v", err)
+			}
+		}
+	}
+}
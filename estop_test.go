@@ -0,0 +1,71 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmergencyStopLatch(t *testing.T) {
+	var missing *emergencyStop
+	assert.NoError(t, missing.check())
+	assert.Equal(t, false, missing.status()["active"])
+
+	e := &emergencyStop{}
+	assert.NoError(t, e.check())
+	assert.True(t, e.latch("test"))
+	assert.False(t, e.latch("again"))
+	assert.ErrorIs(t, e.check(), ErrEmergencyStop)
+	assert.Equal(t, "test", e.status()["reason"])
+
+	e.clear()
+	assert.NoError(t, e.check())
+	assert.NotContains(t, e.status(), "reason")
+}
+
+func TestStartsMotion(t *testing.T) {
+	assert.True(t, startsMotion("goal_position", []byte{0, 0}))
+	assert.False(t, startsMotion("goal_velocity", []byte{0, 0}))
+	assert.True(t, startsMotion("goal_velocity", []byte{10, 0}))
+	assert.True(t, startsMotion("torque_enable", []byte{1}))
+	assert.False(t, startsMotion("torque_enable", []byte{0}))
+	assert.False(t, startsMotion("torque_enable", []byte{128}))
+	assert.False(t, startsMotion("torque_limit", []byte{0xE8, 0x03}))
+}
+
+func TestOperatingModeUnderEmergencyStop(t *testing.T) {
+	e := &emergencyStop{}
+	e.latch("test")
+	controller := &SafeSoArmController{estop: e, group: feetech.NewServoGroup(nil)}
+
+	// Spinning a joint up is motion, switching it back to holding position isn't
+	assert.ErrorIs(t, controller.SetServoOperatingMode(context.Background(), 1, feetech.ModeVelocity), ErrEmergencyStop)
+	err := controller.SetServoOperatingMode(context.Background(), 1, feetech.ModePosition)
+	assert.ErrorContains(t, err, "not available")
+}
+
+func TestEmergencyStopDisablesTorqueFirst(t *testing.T) {
+	ctx := context.Background()
+	ids := []int{1, 2, 3, 4, 5, 6}
+	controller, fake := newFakeController(t, ids...)
+	controller.estop = &emergencyStop{}
+	controller.velocityModes = &velocityModes{}
+	assert.NoError(t, controller.SetServoOperatingMode(ctx, 1, feetech.ModeVelocity))
+	before := len(fake.writes)
+
+	assert.NoError(t, controller.EmergencyStop(ctx, "test"))
+	writes := fake.writes[before:]
+
+	// Torque goes off on every servo before anything else reaches the bus
+	disabled := []int{}
+	for _, w := range writes[:len(ids)] {
+		assert.Equal(t, feetech.RegTorqueEnable.Address, w.address)
+		assert.Equal(t, []byte{0}, w.data)
+		disabled = append(disabled, w.id)
+	}
+	assert.ElementsMatch(t, ids, disabled)
+	// Only the spinning servo gets a zero goal velocity, elsewhere it would mean full speed
+	assert.Equal(t, []fakeWrite{{id: 1, address: feetech.RegGoalVelocity.Address, data: []byte{0, 0}}}, writes[len(ids):])
+}
@@ -0,0 +1,74 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxRecentEvents bounds the in-memory event history kept for support bundles
+const maxRecentEvents = 100
+
+// Event kinds
+const (
+	eventWarning = "warning"
+	eventError   = "error"
+	eventInfo    = "info"
+)
+
+type event struct {
+	Time    time.Time
+	Kind    string
+	Message string
+}
+
+// eventLog keeps the most recent notable events: logged warnings, failed moves, and operator
+// actions like an emergency stop
+type eventLog struct {
+	mu     sync.Mutex
+	events []event
+}
+
+// add records an event, dropping the oldest once full
+func (l *eventLog) add(kind, format string, args ...interface{}) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event{Time: time.Now(), Kind: kind, Message: fmt.Sprintf(format, args...)})
+	if len(l.events) > maxRecentEvents {
+		l.events = l.events[len(l.events)-maxRecentEvents:]
+	}
+}
+
+// recordError records a failed operation, ignoring cancellations
+func (l *eventLog) recordError(op string, err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	l.add(eventError, "%s: %v", op, err)
+}
+
+// recent returns events oldest first, optionally only those of one kind
+func (l *eventLog) recent(kind string) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	if l == nil {
+		return result
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.events {
+		if kind != "" && e.Kind != kind {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"time":    e.Time.UTC().Format(time.RFC3339Nano),
+			"kind":    e.Kind,
+			"message": e.Message,
+		})
+	}
+	return result
+}
@@ -0,0 +1,224 @@
+package so_arm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Factory reset. factory_reset_servo puts one servo's EEPROM back to its factory defaults with
+// the INST_RESET instruction, for a servo left in a bad state by an interrupted write, then
+// gives it back its SO-101 ID and baudrate the way motor setup does. Every servo comes out of a
+// reset as ID 1, so like motor setup only that servo may be connected. The first call returns
+// a token that a second call must send back as "confirm" within factoryResetTokenTTL.
+const (
+	factoryResetID       = 1
+	factoryResetBaudrate = 1000000
+	factoryResetSettle   = 500 * time.Millisecond
+	factoryResetTokenTTL = time.Minute
+)
+
+// pendingFactoryReset is a factory_reset_servo waiting for its confirmation
+type pendingFactoryReset struct {
+	servoID int
+	token   string
+	expires time.Time
+}
+
+// newFactoryResetToken returns a random confirmation token
+func newFactoryResetToken() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// sendServoReset writes an INST_RESET packet for one servo and checks the status it replies with
+func sendServoReset(ctx context.Context, transport feetech.Transport, protocol *feetech.Protocol, servoID int, timeout time.Duration) error {
+	if err := transport.Flush(); err != nil {
+		return fmt.Errorf("failed to flush serial port: %w", err)
+	}
+	packet := protocol.Encode(feetech.Packet{ID: byte(servoID), Instruction: feetech.InstReset})
+	if _, err := transport.Write(packet); err != nil {
+		return fmt.Errorf("failed to send reset: %w", err)
+	}
+
+	const statusLen = 6
+	buf := make([]byte, statusLen)
+	read := 0
+	deadline := time.Now().Add(timeout)
+	for read < statusLen {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("servo %d did not acknowledge the reset: %w", servoID, feetech.ErrNoResponse)
+		}
+		if err := transport.SetReadTimeout(max(time.Until(deadline), 10*time.Millisecond)); err != nil {
+			return fmt.Errorf("failed to set read timeout: %w", err)
+		}
+		n, err := transport.Read(buf[read:])
+		if n == 0 && err != nil {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		read += n
+	}
+
+	status, _, err := protocol.Decode(buf)
+	if err != nil {
+		return fmt.Errorf("bad reset status from servo %d: %w", servoID, err)
+	}
+	if int(status.ID) != servoID {
+		return fmt.Errorf("wrong servo ID in reset status: expected %d, got %d", servoID, status.ID)
+	}
+	if status.Error.HasError() {
+		return &feetech.ServoError{ID: servoID, Op: "reset", Status: status.Error}
+	}
+	return nil
+}
+
+// ResetServo sends INST_RESET to one servo on a port. The feetech bus has no way to send that
+// instruction, so the bus is closed while the packet goes out on a serial port of its own, then
+// reopened and handed to every component on the port as after a reconnect.
+func (r *ControllerRegistry) ResetServo(ctx context.Context, portPath string, servoID int) error {
+	r.mu.RLock()
+	entry, exists := r.entries[portPath]
+	r.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("no controller for port %s", portPath)
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.controller == nil {
+		return fmt.Errorf("controller not available for port %s", portPath)
+	}
+	busConfig := entry.busConfig
+	if m := entry.monitor; m != nil {
+		if !m.isConnected() {
+			return fmt.Errorf("serial port %s is disconnected", portPath)
+		}
+		m.mu.Lock()
+		busConfig.Port = m.devicePath
+		m.mu.Unlock()
+	}
+
+	if err := entry.controller.bus.Close(); err != nil {
+		return fmt.Errorf("failed to close bus for reset: %w", err)
+	}
+	transport, err := feetech.OpenSerial(feetech.SerialConfig{
+		Port:     busConfig.Port,
+		BaudRate: busConfig.BaudRate,
+		Timeout:  busConfig.Timeout,
+	})
+	var resetErr error
+	if err != nil {
+		resetErr = fmt.Errorf("failed to open serial port for reset: %w", err)
+	} else {
+		resetErr = sendServoReset(ctx, transport, feetech.NewProtocol(busConfig.Protocol), servoID, busConfig.Timeout)
+		if err := transport.Close(); err != nil && resetErr == nil {
+			resetErr = fmt.Errorf("failed to close serial port after reset: %w", err)
+		}
+	}
+
+	bus, err := feetech.NewBus(busConfig)
+	if err != nil {
+		return fmt.Errorf("failed to reopen serial port %s after reset: %w", busConfig.Port, err)
+	}
+	entry.useBus(bus)
+	return resetErr
+}
+
+// factoryResetServo handles the factory_reset_servo DoCommand
+func (cs *so101CalibrationSensor) factoryResetServo(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+	if cs.calibrationInProgress() {
+		return map[string]any{"success": false},
+			fmt.Errorf("calibration in progress (state: %s), abort it before a factory reset", cs.state.String())
+	}
+	if cs.setupInProgress {
+		return map[string]any{"success": false},
+			fmt.Errorf("motor setup in progress, use 'motor_setup_reset_status' before a factory reset")
+	}
+	id, ok := cmd["servo_id"].(float64)
+	if !ok {
+		return map[string]any{"success": false}, fmt.Errorf("factory_reset_servo requires 'servo_id' number parameter")
+	}
+	servoID := int(id)
+	i := slices.IndexFunc(SO101MotorConfigs, func(m MotorSetupConfig) bool { return m.TargetID == servoID })
+	if i < 0 || !slices.Contains(cs.cfg.ServoIDs, servoID) {
+		return map[string]any{"success": false},
+			fmt.Errorf("servo %d is not one of the configured servos %v", servoID, cs.cfg.ServoIDs)
+	}
+	motor := SO101MotorConfigs[i]
+
+	token, _ := cmd["confirm"].(string)
+	if token == "" {
+		token, err := newFactoryResetToken()
+		if err != nil {
+			return map[string]any{"success": false}, err
+		}
+		cs.pendingReset = &pendingFactoryReset{servoID: servoID, token: token, expires: time.Now().Add(factoryResetTokenTTL)}
+		return map[string]any{
+			"success":    true,
+			"confirmed":  false,
+			"servo_id":   servoID,
+			"motor_name": motor.Name,
+			"token":      token,
+			"expires_in": factoryResetTokenTTL.Seconds(),
+			"message": fmt.Sprintf("This erases every setting of the %s servo, including its calibration. Connect only that servo, then send factory_reset_servo again with \"confirm\": %q.",
+				motor.Name, token),
+		}, nil
+	}
+	pending := cs.pendingReset
+	cs.pendingReset = nil
+	if pending == nil || pending.servoID != servoID || pending.token != token || time.Now().After(pending.expires) {
+		return map[string]any{"success": false},
+			fmt.Errorf("confirmation token doesn't match a pending reset of servo %d, send factory_reset_servo without 'confirm' for a new one", servoID)
+	}
+
+	// The servo may not answer to its SO-101 ID any more, find it as the only one on the bus
+	found, baudrate, err := cs.discoverOneMotor(ctx, motor.Model)
+	if err != nil {
+		return map[string]any{"success": false}, fmt.Errorf("connect only the %s servo before a factory reset: %w", motor.Name, err)
+	}
+	if baudrate != factoryResetBaudrate {
+		return map[string]any{"success": false},
+			fmt.Errorf("the bus runs at %d baud, but a reset servo answers at %d, so it couldn't be set up again", baudrate, factoryResetBaudrate)
+	}
+	cs.logger.Infof("Factory resetting the %s servo (found as ID %d)", motor.Name, found.ID)
+	if err := ResetSharedServo(ctx, cs.cfg.Port, found.ID); err != nil {
+		return map[string]any{"success": false}, fmt.Errorf("factory reset of %s failed: %w", motor.Name, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return map[string]any{"success": false}, ctx.Err()
+	case <-time.After(factoryResetSettle):
+	}
+	if err := cs.assignMotorIDAndBaudrate(factoryResetID, motor.TargetID, factoryResetBaudrate, motorSetupTargetBaudrate); err != nil {
+		return map[string]any{"success": false, "reset": true},
+			fmt.Errorf("%s was reset to ID %d at %d baud but setting it up again failed, use 'motor_setup_assign_id': %w",
+				motor.Name, factoryResetID, factoryResetBaudrate, err)
+	}
+
+	cs.setupStatus = fmt.Sprintf("Factory reset the %s servo and set it up as ID %d", motor.Name, motor.TargetID)
+	cs.logger.Infof("Motor setup: %s", cs.setupStatus)
+	return map[string]any{
+		"success":    true,
+		"confirmed":  true,
+		"motor_name": motor.Name,
+		"old_id":     found.ID,
+		"new_id":     motor.TargetID,
+		"status":     cs.setupStatus,
+		"message":    fmt.Sprintf("Its calibration was erased too. Use 'calibrate_joint' with servo_id %d to calibrate it again.", motor.TargetID),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendServoReset(t *testing.T) {
+	proto := feetech.NewProtocol(feetech.ProtocolSTS)
+	ctx := context.Background()
+
+	transport := &feetech.MockTransport{ReadData: proto.Encode(feetech.Packet{ID: 3})}
+	assert.NoError(t, sendServoReset(ctx, transport, proto, 3, 100*time.Millisecond))
+	assert.Equal(t, []byte{0xFF, 0xFF, 3, 2, feetech.InstReset, 0xF4}, transport.WriteData)
+
+	// A status with an error flag fails the reset
+	transport = &feetech.MockTransport{ReadData: proto.Encode(feetech.Packet{ID: 3, Instruction: byte(feetech.ErrOverload)})}
+	var servoErr *feetech.ServoError
+	assert.True(t, errors.As(sendServoReset(ctx, transport, proto, 3, 100*time.Millisecond), &servoErr))
+
+	transport = &feetech.MockTransport{ReadData: proto.Encode(feetech.Packet{ID: 4})}
+	assert.ErrorContains(t, sendServoReset(ctx, transport, proto, 3, 100*time.Millisecond), "wrong servo ID")
+
+	transport = &feetech.MockTransport{}
+	assert.ErrorIs(t, sendServoReset(ctx, transport, proto, 3, 20*time.Millisecond), feetech.ErrNoResponse)
+}
@@ -0,0 +1,161 @@
+package so_arm
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Warm standby. With secondary_port set, a second adapter wired to the same bus is opened and
+// locked alongside the primary but carries no traffic. When the active port keeps failing or
+// its adapter disappears, the controller and every view switch over to it. Failover is one
+// way: the failed adapter isn't used again until the components are rebuilt.
+const defaultFailoverAfterFailures = 10
+
+func validateFailover(port, secondaryPort string, failoverAfter int) error {
+	if secondaryPort != "" && secondaryPort == port {
+		return fmt.Errorf("secondary_port must be a different port than port %s", port)
+	}
+	if failoverAfter < 0 {
+		return fmt.Errorf("failover_after_failures can't be negative, got %d", failoverAfter)
+	}
+	if failoverAfter != 0 && secondaryPort == "" {
+		return fmt.Errorf("failover_after_failures requires secondary_port")
+	}
+	return nil
+}
+
+// standbyPort is an open, idle bus on the secondary adapter
+type standbyPort struct {
+	path string
+	bus  *feetech.Bus
+	lock *portLock
+}
+
+// openStandbyPort opens path with the settings of the primary bus
+func openStandbyPort(busConfig feetech.BusConfig, path string) (*standbyPort, error) {
+	lock, err := acquirePortLock(path)
+	if err != nil {
+		return nil, err
+	}
+	busConfig.Port = path
+	bus, err := feetech.NewBus(busConfig)
+	if err != nil {
+		lock.Release()
+		return nil, fmt.Errorf("failed to open secondary port %s: %w", path, err)
+	}
+	return &standbyPort{path: path, bus: bus, lock: lock}, nil
+}
+
+func (p *standbyPort) close() error {
+	if p == nil {
+		return nil
+	}
+	err := p.bus.Close()
+	if lockErr := p.lock.Release(); err == nil {
+		err = lockErr
+	}
+	return err
+}
+
+// openStandby opens config.SecondaryPort, if set, for the entry to fail over to. A missing
+// secondary adapter is logged and leaves the port without failover. The entry lock must be held.
+func (e *ControllerEntry) openStandby(config *SoArm101Config) {
+	if config.SecondaryPort == "" {
+		return
+	}
+	e.failoverAfter = config.FailoverAfterFailures
+	if e.failoverAfter == 0 {
+		e.failoverAfter = defaultFailoverAfterFailures
+	}
+	standby, err := openStandbyPort(e.busConfig, config.SecondaryPort)
+	if err != nil && config.Logger != nil {
+		config.Logger.Warnf("Secondary port %s is not available for failover: %v", config.SecondaryPort, err)
+	}
+	e.standby = standby
+	e.monitor.setStandby(config.SecondaryPort, err)
+}
+
+// addStandby opens a secondary port requested by a component that joined an open port, so
+// failover doesn't depend on which component opened it. The entry lock must be held.
+func (r *ControllerRegistry) addStandby(e *ControllerEntry, config *SoArm101Config) {
+	if config.SecondaryPort == "" || e.standby != nil || e.monitor.failedOver() {
+		return
+	}
+	if e.monitor.startWatching() {
+		go r.watchConnection(e, e.monitor)
+	}
+	e.openStandby(config)
+}
+
+// failoverDue reports whether the active port should be swapped for the standby, because its
+// adapter is gone or its transactions keep failing
+func (e *ControllerEntry) failoverDue(m *portMonitor) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.standby == nil || e.controller == nil {
+		return false
+	}
+	return !m.isConnected() || e.controller.busHealth.failureStreak() >= int64(e.failoverAfter)
+}
+
+// failover moves the controller and every view to the standby bus
+func (e *ControllerEntry) failover(m *portMonitor) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if m.stopped() || e.controller == nil || e.standby == nil {
+		return nil
+	}
+
+	// A disconnected port's bus is already closed
+	if m.isConnected() {
+		if err := e.controller.bus.Close(); err != nil {
+			m.setError(err)
+		}
+		e.releaseLock()
+	}
+	standby := e.standby
+	e.standby = nil
+	e.lock = standby.lock
+	e.useBus(standby.bus)
+	e.controller.busHealth.clearFailureStreak()
+	m.markFailedOver(standby.path)
+	if e.config != nil && e.config.Logger != nil {
+		e.config.Logger.Warnf("Serial port %s failed, switched to secondary port %s", e.config.Port, standby.path)
+	}
+	return nil
+}
+
+func (m *portMonitor) setStandby(path string, err error) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.standbyPath = path
+	m.standbyErr = err
+}
+
+func (m *portMonitor) failedOver() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failovers > 0
+}
+
+func (m *portMonitor) markFailedOver(devicePath string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connected = true
+	m.devicePath = devicePath
+	m.device, _ = os.Stat(devicePath)
+	m.usbSerial = usbSerialForPort(devicePath)
+	m.standbyPath = ""
+	m.failovers++
+	m.lastFailover = time.Now()
+	m.lastErr = nil
+}
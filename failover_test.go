@@ -0,0 +1,72 @@
+package so_arm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFailover(t *testing.T) {
+	assert.NoError(t, validateFailover("/dev/ttyACM0", "", 0))
+	assert.NoError(t, validateFailover("/dev/ttyACM0", "/dev/ttyACM1", 5))
+	assert.Error(t, validateFailover("/dev/ttyACM0", "/dev/ttyACM0", 0))
+	assert.Error(t, validateFailover("/dev/ttyACM0", "/dev/ttyACM1", -1))
+	assert.Error(t, validateFailover("/dev/ttyACM0", "", 5))
+}
+
+func TestFailover(t *testing.T) {
+	newTestBus := func() *feetech.Bus {
+		bus, err := feetech.NewBus(feetech.BusConfig{Transport: &feetech.MockTransport{}})
+		assert.NoError(t, err)
+		return bus
+	}
+
+	calibratedServos := map[int]*CalibratedServo{}
+	for id := 1; id <= 6; id++ {
+		calibratedServos[id] = NewCalibratedServo(nil, &MotorCalibration{ID: id})
+	}
+	primary := newTestBus()
+	controller := &SafeSoArmController{bus: primary, calibratedServos: calibratedServos, busHealth: newBusHealth()}
+	view := &SafeSoArmController{bus: primary, calibratedServos: calibratedServos, busHealth: controller.busHealth}
+	standbyBus := newTestBus()
+	m := newPortMonitor("COM3")
+	m.startWatching()
+	entry := &ControllerEntry{
+		controller:    controller,
+		views:         []*SafeSoArmController{view},
+		monitor:       m,
+		standby:       &standbyPort{path: "COM4", bus: standbyBus},
+		failoverAfter: 3,
+	}
+	m.setStandby("COM4", nil)
+
+	// A port without a device node is never reported gone
+	assert.False(t, m.deviceGone())
+
+	for range 2 {
+		controller.busHealth.record(time.Millisecond, errors.New("timeout"))
+	}
+	controller.busHealth.record(time.Millisecond, nil)
+	controller.busHealth.record(time.Millisecond, errors.New("timeout"))
+	assert.False(t, entry.failoverDue(m))
+	for range 2 {
+		controller.busHealth.record(time.Millisecond, errors.New("timeout"))
+	}
+	assert.True(t, entry.failoverDue(m))
+
+	assert.NoError(t, entry.failover(m))
+	assert.Same(t, standbyBus, controller.bus)
+	assert.Same(t, standbyBus, view.bus)
+	assert.Nil(t, entry.standby)
+	assert.False(t, entry.failoverDue(m))
+
+	status := controller.busHealth.status()
+	assert.Equal(t, int64(0), status["failure_streak"])
+	connection := m.status()
+	assert.Equal(t, "COM4", connection["device"])
+	assert.Equal(t, 1, connection["failovers"])
+	assert.Equal(t, true, connection["connected"])
+}
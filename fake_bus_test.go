@@ -0,0 +1,181 @@
+package so_arm
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServoBus is a transport that answers like STS servos, each with its own control table
+type fakeServoBus struct {
+	mu      sync.Mutex
+	proto   *feetech.Protocol
+	tables  map[byte][]byte
+	writes  []fakeWrite
+	pending []byte
+	// Register addresses whose reads go unanswered
+	unreadable map[byte]bool
+	// Instructions left to ignore before answering again
+	dropped int
+}
+
+// fakeWrite is one register write a servo received, directly or through a sync write
+type fakeWrite struct {
+	id      int
+	address byte
+	data    []byte
+}
+
+// newFakeController returns a controller whose bus is answered by a fakeServoBus with the
+// given servos
+func newFakeController(t *testing.T, ids ...int) (*SafeSoArmController, *fakeServoBus) {
+	fake := &fakeServoBus{proto: feetech.NewProtocol(feetech.ProtocolSTS), tables: map[byte][]byte{}}
+	bus, err := feetech.NewBus(feetech.BusConfig{Transport: fake, Timeout: 20 * time.Millisecond})
+	assert.NoError(t, err)
+
+	servos := make([]*feetech.Servo, 0, len(ids))
+	calibratedServos := map[int]*CalibratedServo{}
+	for _, id := range ids {
+		fake.tables[byte(id)] = make([]byte, controlTableSize)
+		servo := feetech.NewServo(bus, id, &feetech.ModelSTS3215)
+		servos = append(servos, servo)
+		calibratedServos[id] = NewCalibratedServo(servo, &MotorCalibration{ID: id})
+	}
+	return &SafeSoArmController{
+		bus:              bus,
+		group:            feetech.NewServoGroup(bus, servos...),
+		calibratedServos: calibratedServos,
+		calibration:      DefaultSO101FullCalibration,
+		busHealth:        newBusHealth(),
+		torque:           &torqueState{},
+	}, fake
+}
+
+// register returns a servo's register contents
+func (f *fakeServoBus) register(id int, reg feetech.Register) []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]byte(nil), f.tables[byte(id)][reg.Address:int(reg.Address)+reg.Size]...)
+}
+
+// setRegister sets a servo's register contents
+func (f *fakeServoBus) setRegister(id int, reg feetech.Register, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copy(f.tables[byte(id)][reg.Address:], data)
+}
+
+// failReads makes reads starting at a register go unanswered
+func (f *fakeServoBus) failReads(reg feetech.Register) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unreadable == nil {
+		f.unreadable = map[byte]bool{}
+	}
+	f.unreadable[reg.Address] = true
+}
+
+// drop makes the bus ignore the next n instructions, like a burst of line noise
+func (f *fakeServoBus) drop(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropped = n
+}
+
+// writesTo returns the data written to one register of a servo, in order
+func (f *fakeServoBus) writesTo(id int, reg feetech.Register) [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var data [][]byte
+	for _, w := range f.writes {
+		if w.id == id && w.address == reg.Address {
+			data = append(data, w.data)
+		}
+	}
+	return data
+}
+
+func (f *fakeServoBus) write(id byte, address byte, data []byte) {
+	table, ok := f.tables[id]
+	if !ok {
+		return
+	}
+	copy(table[address:], data)
+	f.writes = append(f.writes, fakeWrite{id: int(id), address: address, data: append([]byte(nil), data...)})
+}
+
+func (f *fakeServoBus) reply(id byte, params []byte) {
+	f.pending = append(f.pending, f.proto.Encode(feetech.Packet{ID: id, Parameters: params})...)
+}
+
+func (f *fakeServoBus) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// An instruction decodes like a status with the instruction in place of the error
+	pkt, _, err := f.proto.Decode(p)
+	if err != nil {
+		return len(p), nil
+	}
+	if f.dropped > 0 {
+		f.dropped--
+		return len(p), nil
+	}
+	params := pkt.Parameters
+	switch byte(pkt.Error) {
+	case feetech.InstPing:
+		if _, ok := f.tables[pkt.ID]; ok {
+			f.reply(pkt.ID, nil)
+		}
+	case feetech.InstWrite:
+		if _, ok := f.tables[pkt.ID]; ok {
+			f.write(pkt.ID, params[0], params[1:])
+			f.reply(pkt.ID, nil)
+		}
+	case feetech.InstRead:
+		if table, ok := f.tables[pkt.ID]; ok && !f.unreadable[params[0]] {
+			f.reply(pkt.ID, table[params[0]:params[0]+params[1]])
+		}
+	case feetech.InstSyncWrite:
+		size := int(params[1])
+		for rest := params[2:]; len(rest) > size; rest = rest[1+size:] {
+			f.write(rest[0], params[0], rest[1:1+size])
+		}
+	case feetech.InstSyncRead:
+		if f.unreadable[params[0]] {
+			break
+		}
+		for _, id := range params[2:] {
+			if table, ok := f.tables[id]; ok {
+				f.reply(id, table[params[0]:params[0]+params[1]])
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (f *fakeServoBus) Read(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.pending) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, f.pending)
+	f.pending = f.pending[n:]
+	return n, nil
+}
+
+func (f *fakeServoBus) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending = nil
+	return nil
+}
+
+func (f *fakeServoBus) SetReadTimeout(time.Duration) error { return nil }
+
+func (f *fakeServoBus) Close() error { return nil }
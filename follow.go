@@ -0,0 +1,302 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// Follow mode defaults and limits
+const (
+	followDefaultRateHz = 10.0
+	followMaxRateHz     = 50.0
+	// Consecutive failed reads of the source or the joints that end follow mode
+	followMaxFailures = 5
+)
+
+// followState tracks follow mode, where the arm continuously moves toward a target read from
+// follow_source. The loop holds moveLock, so other moves wait until following stops.
+type followState struct {
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	done     chan struct{}
+	started  time.Time
+	updates  int64
+	limited  int64
+	lastErr  string
+	stopped  string
+	stoppedT time.Time
+}
+
+func (f *followState) begin(cancel context.CancelFunc) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cancel != nil {
+		return false
+	}
+	f.cancel = cancel
+	f.done = make(chan struct{})
+	f.started = time.Now()
+	f.updates, f.limited, f.lastErr, f.stopped = 0, 0, "", ""
+	return true
+}
+
+func (f *followState) record(limited bool, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err != nil {
+		f.lastErr = err.Error()
+		return
+	}
+	f.updates++
+	if limited {
+		f.limited++
+	}
+}
+
+func (f *followState) finish(reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancel()
+	f.cancel = nil
+	close(f.done)
+	f.stopped = reason
+	f.stoppedT = time.Now()
+}
+
+// end stops the loop and waits for it to release moveLock
+func (f *followState) end() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	cancel, done := f.cancel, f.done
+	f.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (f *followState) status() map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := map[string]interface{}{
+		"active":  f.cancel != nil,
+		"updates": f.updates,
+		// Updates where a joint was held back by the speed limit or a joint limit
+		"limited_updates": f.limited,
+	}
+	if !f.started.IsZero() {
+		result["started"] = f.started.Format(time.RFC3339)
+	}
+	if f.lastErr != "" {
+		result["last_error"] = f.lastErr
+	}
+	if f.stopped != "" {
+		result["stopped_reason"] = f.stopped
+		result["stopped"] = f.stoppedT.Format(time.RFC3339)
+	}
+	return result
+}
+
+// followTarget reads the target from the source's readings, either joint_positions_degs or
+// an end effector pose as x, y, z in millimeters with o_x, o_y, o_z and theta in degrees.
+// Pose targets are solved with the local IK solver.
+func (s *so101) followTarget(ctx context.Context, source sensor.Sensor) ([]float64, error) {
+	servoIDs := s.servoIDs()
+	readings, err := source.Readings(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read follow_source: %w", err)
+	}
+	if raw, ok := readings["joint_positions_degs"]; ok {
+		degs, err := readingFloats(raw)
+		if err != nil {
+			return nil, fmt.Errorf("joint_positions_degs: %w", err)
+		}
+		if len(degs) != len(servoIDs) {
+			return nil, fmt.Errorf("joint_positions_degs has %d values, expected %d", len(degs), len(servoIDs))
+		}
+		positions := make([]float64, len(degs))
+		for i, deg := range degs {
+			positions[i] = DegreesToRadians(deg)
+		}
+		return positions, nil
+	}
+
+	keys := []string{"x", "y", "z", "o_x", "o_y", "o_z", "theta"}
+	values := make([]float64, len(keys))
+	for i, key := range keys {
+		v, ok := readings[key].(float64)
+		if !ok {
+			return nil, fmt.Errorf("follow_source readings need joint_positions_degs or numeric x, y, z, o_x, o_y, o_z and theta, %q is missing", key)
+		}
+		values[i] = v
+	}
+	pose := spatialmath.NewPose(
+		r3.Vector{X: values[0], Y: values[1], Z: values[2]},
+		&spatialmath.OrientationVectorDegrees{OX: values[3], OY: values[4], OZ: values[5], Theta: values[6]},
+	)
+	return s.solveLocalIK(ctx, pose, nil)
+}
+
+// readingFloats converts a list reading, which is []interface{} when it came over the network
+func readingFloats(v interface{}) ([]float64, error) {
+	if values, ok := v.([]float64); ok {
+		return values, nil
+	}
+	return floatList(v, "reading")
+}
+
+// followStep moves each joint from current toward target by at most maxStep radians and
+// keeps it inside limits. limited reports whether any joint fell short of its target.
+func followStep(current, target []float64, maxStep float64, limits [][2]float64) ([]float64, bool) {
+	next := make([]float64, len(target))
+	limited := false
+	for i := range target {
+		goal := clampFloat(target[i], limits[i][0], limits[i][1])
+		next[i] = current[i] + clampFloat(goal-current[i], -maxStep, maxStep)
+		if goal != target[i] || math.Abs(goal-current[i]) > maxStep {
+			limited = true
+		}
+	}
+	return next, limited
+}
+
+// setFollow handles the follow DoCommand, starting or stopping follow mode
+func (s *so101) setFollow(cmd map[string]interface{}) (map[string]interface{}, error) {
+	enable, ok := cmd["enable"].(bool)
+	if !ok {
+		return nil, errors.New("follow requires 'enable' boolean parameter")
+	}
+	if !enable {
+		s.follow.end()
+		result := s.follow.status()
+		result["success"] = true
+		return result, nil
+	}
+	if s.followSource == nil {
+		return map[string]interface{}{"success": false, "error": "no follow_source configured"}, nil
+	}
+	if err := s.checkMotionAllowed(); err != nil {
+		return map[string]interface{}{"success": false, "error": err.Error()}, nil
+	}
+	if s.complianceEnabled() {
+		return map[string]interface{}{"success": false, "error": "compliance mode is enabled, disable it with compliance_mode before following"}, nil
+	}
+	if ids := s.velocityModeServos(); len(ids) > 0 {
+		return map[string]interface{}{"success": false, "error": fmt.Sprintf("servos %v are in velocity mode, disable it with set_velocity_mode before following", ids)}, nil
+	}
+
+	ctx, cancel := context.WithCancel(s.cancelCtx)
+	if !s.follow.begin(cancel) {
+		cancel()
+		return map[string]interface{}{"success": false, "error": "already following"}, nil
+	}
+	stopCount := s.stopCount.Load()
+	go func() {
+		reason := s.runFollow(ctx, stopCount)
+		if reason != "disabled" {
+			s.logger.Warnf("Follow mode stopped: %s", reason)
+			s.events.add(eventWarning, "follow mode stopped: %s", reason)
+		}
+		s.follow.finish(reason)
+	}()
+
+	result := s.follow.status()
+	result["success"] = true
+	return result, nil
+}
+
+// runFollow tracks follow_source until ctx is done, Stop is called, motion is refused or
+// reads keep failing, and returns why it stopped
+func (s *so101) runFollow(ctx context.Context, stopCount int64) string {
+	servoIDs := s.servoIDs()
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	s.isMoving.Store(true)
+	defer s.isMoving.Store(false)
+
+	s.mu.RLock()
+	cfg := s.cfg
+	speed := float64(s.defaultSpeed)
+	acc := float64(s.defaultAcc)
+	s.mu.RUnlock()
+	rate := cfg.FollowRateHz
+	if rate == 0 {
+		rate = followDefaultRateHz
+	}
+	if cfg.FollowMaxSpeedDegsPerSec > 0 {
+		speed = cfg.FollowMaxSpeedDegsPerSec
+	}
+	maxStep := DegreesToRadians(speed / rate)
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	failures := 0
+	fail := func(err error) string {
+		s.follow.record(false, err)
+		s.logs.Warnf("follow", "Follow mode update failed: %v", err)
+		failures++
+		if failures >= followMaxFailures {
+			return fmt.Sprintf("%d updates in a row failed, last error: %v", failures, err)
+		}
+		return ""
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "disabled"
+		case <-ticker.C:
+		}
+		if s.stopCount.Load() != stopCount {
+			return "stopped"
+		}
+		if err := s.checkMotionAllowed(); err != nil {
+			return err.Error()
+		}
+		if err := s.decalibration.checkSpeed(speed); err != nil {
+			return err.Error()
+		}
+
+		target, err := s.followTarget(ctx, s.followSource)
+		if err != nil {
+			if reason := fail(err); reason != "" {
+				return reason
+			}
+			continue
+		}
+		current, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+		if err != nil {
+			if reason := fail(fmt.Errorf("failed to read joint positions: %w", err)); reason != "" {
+				return reason
+			}
+			continue
+		}
+
+		next, limited := followStep(current, target, maxStep, s.calculateJointLimits())
+		if err := s.controller.MoveServosToPositions(ctx, servoIDs, next, int(math.Ceil(speed)), int(math.Round(acc))); err != nil {
+			if ctx.Err() != nil {
+				return "disabled"
+			}
+			if reason := fail(fmt.Errorf("failed to move: %w", err)); reason != "" {
+				return reason
+			}
+			continue
+		}
+		failures = 0
+		s.usage.addTravel(servoIDs, current, next)
+		s.follow.record(limited, nil)
+	}
+}
@@ -0,0 +1,96 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+type fakeSensor struct {
+	resource.Named
+	resource.TriviallyReconfigurable
+	resource.TriviallyCloseable
+	ReadingsFunc func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error)
+}
+
+func newFakeSensor(name string) *fakeSensor {
+	return &fakeSensor{Named: sensor.Named(name).AsNamed()}
+}
+
+func (f *fakeSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	return f.ReadingsFunc(ctx, extra)
+}
+
+func TestFollowStep(t *testing.T) {
+	limits := [][2]float64{{-1, 1}, {-1, 1}, {-1, 1}}
+	next, limited := followStep([]float64{0, 0, 0.5}, []float64{0.05, -0.5, 2}, 0.1, limits)
+	assert.InDeltaSlice(t, []float64{0.05, -0.1, 0.6}, next, 1e-9)
+	assert.True(t, limited)
+
+	next, limited = followStep([]float64{0, 0, 0.95}, []float64{0.05, -0.05, 1}, 0.1, limits)
+	assert.InDeltaSlice(t, []float64{0.05, -0.05, 1}, next, 1e-9)
+	assert.False(t, limited)
+}
+
+func TestFollowTarget(t *testing.T) {
+	s := &so101{armServoIDs: []int{1, 2, 3, 4, 5}}
+	source := newFakeSensor("targets")
+
+	source.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"joint_positions_degs": []interface{}{0.0, -90.0, 90.0, 60.0, 180.0}}, nil
+	}
+	target, err := s.followTarget(context.Background(), source)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Pi, target[4], 1e-9)
+
+	source.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"joint_positions_degs": []float64{0, 0}}, nil
+	}
+	_, err = s.followTarget(context.Background(), source)
+	assert.Error(t, err)
+
+	source.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"x": 200.0, "y": 0.0}, nil
+	}
+	_, err = s.followTarget(context.Background(), source)
+	assert.ErrorContains(t, err, "o_x")
+}
+
+func TestFollowStopsAfterFailures(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	source := newFakeSensor("targets")
+	source.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		return nil, errors.New("planner offline")
+	}
+	s := &so101{
+		cfg:          &SO101ArmConfig{FollowRateHz: followMaxRateHz},
+		logger:       logger,
+		logs:         newRateLimitedLogger(logger, 0),
+		events:       &eventLog{},
+		maintenance:  &maintenanceMode{},
+		follow:       &followState{},
+		followSource: source,
+		armServoIDs:  []int{1, 2, 3, 4, 5},
+		defaultSpeed: 50,
+		defaultAcc:   100,
+		cancelCtx:    context.Background(),
+	}
+
+	result, err := s.setFollow(map[string]interface{}{"enable": true})
+	assert.NoError(t, err)
+	assert.Equal(t, true, result["success"])
+	result, _ = s.setFollow(map[string]interface{}{"enable": true})
+	assert.Equal(t, false, result["success"])
+
+	assert.Eventually(t, func() bool { return s.follow.status()["active"] == false }, 2*time.Second, 10*time.Millisecond)
+	status := s.follow.status()
+	assert.Contains(t, status["stopped_reason"], "planner offline")
+	assert.Equal(t, int64(0), status["updates"])
+}
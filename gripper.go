@@ -30,16 +30,66 @@ type SO101GripperConfig struct {
 
 	Timeout time.Duration `json:"timeout,omitempty"`
 
+	// Bus protocol, "sts" (default) or "scs", and servos that speak SCS on an STS bus
+	Protocol    string `json:"protocol,omitempty"`
+	SCSServoIDs []int  `json:"scs_servo_ids,omitempty"`
+
+	// How serial reads, writes and pings are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
 	// Shared with arm
 	CalibrationFile string `json:"calibration_file,omitempty"`
+
+	// Reload calibration automatically when the file changes
+	WatchCalibrationFile bool `json:"watch_calibration_file,omitempty"`
+
+	// Overload protection while holding: when the load stays above overload_load_percent for
+	// overload_duration_sec, the gripper opens by overload_backoff_percent
+	OverloadLoadPercent    float64 `json:"overload_load_percent,omitempty"`
+	OverloadDurationSec    float64 `json:"overload_duration_sec,omitempty"`
+	OverloadBackoffPercent float64 `json:"overload_backoff_percent,omitempty"`
+	DisableOverloadBackoff bool    `json:"disable_overload_backoff,omitempty"`
+
+	// How Grab detects and holds an object
+	GrabPositionThresholdPercent float64 `json:"grab_position_threshold_percent,omitempty"`
+	GripLoadThresholdPercent     float64 `json:"grip_load_threshold_percent,omitempty"`
+	HoldTorquePercent            float64 `json:"hold_torque_percent,omitempty"`
+	GrabBackoffPercent           float64 `json:"grab_backoff_percent,omitempty"`
+
+	// Jaw geometry for width estimates: the tip gap when fully open and how far the moving jaw swings
+	MaxOpeningMM float64 `json:"max_opening_mm,omitempty"`
+	JawSwingDegs float64 `json:"jaw_swing_degs,omitempty"`
+}
+
+// grabSettings tune how Grab decides it is holding something and how hard it then holds
+type grabSettings struct {
+	// Grabbed when the gripper stops at least this far from closed
+	PositionThresholdPercent float64
+	// Also grabbed when the load reaches this, zero disables the load check
+	LoadThresholdPercent float64
+	// Torque limit while holding, zero keeps the servo's limit
+	HoldTorquePercent float64
+	// Opening applied after the grab to relieve squeeze, zero disables
+	BackoffPercent float64
 }
 
+// defaultGrabPositionThresholdPercent is how far from closed the gripper must stop for Grab
+// to report an object
+const defaultGrabPositionThresholdPercent = 15.0
+
 // Validate ensures all parts of the config are valid
 func (cfg *SO101GripperConfig) Validate(path string) ([]string, []string, error) {
 	if cfg.Port == "" {
 		return nil, nil, fmt.Errorf("must specify port for serial communication")
 	}
 
+	if err := validateBusProtocol(cfg.Protocol, cfg.SCSServoIDs); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Retry.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	if cfg.ServoID == 0 {
 		cfg.ServoID = 6
 	}
@@ -52,17 +102,49 @@ func (cfg *SO101GripperConfig) Validate(path string) ([]string, []string, error)
 		cfg.Baudrate = 1000000
 	}
 
+	if cfg.OverloadLoadPercent < 0 || cfg.OverloadLoadPercent > 100 {
+		return nil, nil, fmt.Errorf("overload_load_percent must be between 0 and 100, got %.1f", cfg.OverloadLoadPercent)
+	}
+	if cfg.OverloadDurationSec < 0 {
+		return nil, nil, fmt.Errorf("overload_duration_sec must not be negative, got %.1f", cfg.OverloadDurationSec)
+	}
+	if cfg.OverloadBackoffPercent < 0 || cfg.OverloadBackoffPercent > 50 {
+		return nil, nil, fmt.Errorf("overload_backoff_percent must be between 0 and 50, got %.1f", cfg.OverloadBackoffPercent)
+	}
+	if cfg.GrabPositionThresholdPercent < 0 || cfg.GrabPositionThresholdPercent > 100 {
+		return nil, nil, fmt.Errorf("grab_position_threshold_percent must be between 0 and 100, got %.1f", cfg.GrabPositionThresholdPercent)
+	}
+	if cfg.GripLoadThresholdPercent < 0 || cfg.GripLoadThresholdPercent > 100 {
+		return nil, nil, fmt.Errorf("grip_load_threshold_percent must be between 0 and 100, got %.1f", cfg.GripLoadThresholdPercent)
+	}
+	if cfg.HoldTorquePercent < 0 || cfg.HoldTorquePercent > 100 {
+		return nil, nil, fmt.Errorf("hold_torque_percent must be between 0 and 100, got %.1f", cfg.HoldTorquePercent)
+	}
+	if cfg.GrabBackoffPercent < 0 || cfg.GrabBackoffPercent > 50 {
+		return nil, nil, fmt.Errorf("grab_backoff_percent must be between 0 and 50, got %.1f", cfg.GrabBackoffPercent)
+	}
+	if cfg.MaxOpeningMM < 0 {
+		return nil, nil, fmt.Errorf("max_opening_mm must not be negative, got %.1f", cfg.MaxOpeningMM)
+	}
+	if cfg.JawSwingDegs < 0 || cfg.JawSwingDegs > maxGripperJawSwingDegs {
+		return nil, nil, fmt.Errorf("jaw_swing_degs must be between 0 and %.0f, got %.1f", maxGripperJawSwingDegs, cfg.JawSwingDegs)
+	}
+
 	return nil, nil, nil
 }
 
 type so101Gripper struct {
-	resource.AlwaysRebuild
-
 	name       resource.Name
 	logger     logging.Logger
+	cfg        *SO101GripperConfig
 	controller *SafeSoArmController
 	geometries []spatialmath.Geometry
+	model      referenceframe.Model
 	servoID    int
+	port       string
+	baudrate   int
+
+	calibrationFile string
 
 	mu       sync.Mutex
 	isMoving atomic.Bool
@@ -73,6 +155,26 @@ type so101Gripper struct {
 
 	speed        float32
 	acceleration float32
+
+	overloadLoadPercent    float64
+	overloadDurationSec    float64
+	overloadBackoffPercent float64
+	disableOverloadBackoff bool
+
+	grab     grabSettings
+	geometry gripperGeometry
+	lastGrab *grabRecord
+
+	holdMu           sync.Mutex
+	hold             *holdMonitor
+	backoffCount     int
+	lastBackoff      *overloadBackoff
+	savedTorqueLimit []byte // torque_limit before a force grip lowered it
+
+	forceChar forceCharacterization
+
+	cancelCtx  context.Context
+	cancelFunc func()
 }
 
 func init() {
@@ -91,24 +193,8 @@ func newSO101Gripper(ctx context.Context, deps resource.Dependencies, conf resou
 		return nil, err
 	}
 
-	if cfg.ServoID == 0 {
-		cfg.ServoID = 6
-	}
-
-	if cfg.Baudrate == 0 {
-		cfg.Baudrate = 1000000
-	}
-
-	controllerConfig := &SoArm101Config{
-		Port:            cfg.Port,
-		Baudrate:        cfg.Baudrate,
-		ServoIDs:        []int{1, 2, 3, 4, 5, 6},
-		Timeout:         cfg.Timeout,
-		CalibrationFile: cfg.CalibrationFile,
-		Logger:          logger,
-	}
-
-	controllerConfig.Validate(cfg.CalibrationFile)
+	applyGripperDefaults(cfg)
+	controllerConfig := gripperControllerConfig(cfg, logger)
 
 	fullCalibration, fromFile := controllerConfig.LoadCalibration(logger)
 
@@ -118,25 +204,59 @@ func newSO101Gripper(ctx context.Context, deps resource.Dependencies, conf resou
 		fullCalibration.Gripper.ID = cfg.ServoID
 	}
 
+	model, err := makeGripperModelFrame("so101_gripper", gripperJawSwingDegs(cfg))
+	if err != nil {
+		return nil, err
+	}
+
 	controller, err := GetSharedControllerWithCalibration(controllerConfig, fullCalibration, fromFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shared controller for gripper: %w", err)
 	}
+	RegisterSharedConsumer(cfg.Port, ConsumerInfo{
+		Name:            conf.ResourceName().ShortName(),
+		CalibrationFile: controllerConfig.CalibrationFile,
+		ServoIDs:        []int{cfg.ServoID},
+		Gripper:         true,
+	})
+
+	if cfg.WatchCalibrationFile && fromFile {
+		if err := WatchSharedCalibrationFile(cfg.Port, controllerConfig.CalibrationFile, logger); err != nil {
+			logger.Warnf("Calibration file will not be reloaded automatically: %v", err)
+		}
+	}
 
 	clawSize := r3.Vector{X: 67.0455, Y: 53.027, Z: 106.4}
 	claws, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: 0, Y: 0, Z: clawSize.Z / 2}), clawSize, "claws")
 	geometries := []spatialmath.Geometry{claws}
 
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
 	g := &so101Gripper{
-		name:           conf.ResourceName(),
-		logger:         logger,
-		controller:     controller,
-		geometries:     geometries,
-		servoID:        cfg.ServoID,
-		speed:          30,
-		acceleration:   50,
-		openPosition:   95.0,
-		closedPosition: 0.0,
+		name:            conf.ResourceName(),
+		logger:          logger,
+		cfg:             cfg,
+		controller:      controller,
+		geometries:      geometries,
+		model:           model,
+		servoID:         cfg.ServoID,
+		port:            cfg.Port,
+		calibrationFile: controllerConfig.CalibrationFile,
+		baudrate:        cfg.Baudrate,
+		speed:           30,
+		acceleration:    50,
+		openPosition:    95.0,
+		closedPosition:  0.0,
+
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+	}
+	g.applySettings(cfg)
+
+	if m, err := loadForceMap(forceMapPath(g.name.ShortName())); err != nil {
+		logger.Warnf("Ignoring saved gripper force map: %v", err)
+	} else {
+		g.forceChar.saved = m
 	}
 
 	logger.Debugf("SO-101 gripper initialized with servo ID %d, open=%.1f%%, closed=%.1f%%",
@@ -145,20 +265,111 @@ func newSO101Gripper(ctx context.Context, deps resource.Dependencies, conf resou
 	return g, nil
 }
 
+// applyGripperDefaults fills in the servo ID, baudrate and calibration file when they aren't
+// configured
+func applyGripperDefaults(cfg *SO101GripperConfig) {
+	if cfg.ServoID == 0 {
+		cfg.ServoID = 6
+	}
+	if cfg.Baudrate == 0 {
+		cfg.Baudrate = 1000000
+	}
+	if cfg.CalibrationFile == "" {
+		cfg.CalibrationFile = portCalibrationFile(cfg.Port)
+	}
+}
+
+// gripperControllerConfig returns the shared controller settings for a gripper config
+func gripperControllerConfig(cfg *SO101GripperConfig, logger logging.Logger) *SoArm101Config {
+	controllerConfig := &SoArm101Config{
+		Port:            cfg.Port,
+		Baudrate:        cfg.Baudrate,
+		ServoIDs:        []int{1, 2, 3, 4, 5, 6},
+		Timeout:         cfg.Timeout,
+		Protocol:        cfg.Protocol,
+		SCSServoIDs:     cfg.SCSServoIDs,
+		Retry:           cfg.Retry,
+		CalibrationFile: cfg.CalibrationFile,
+		Logger:          logger,
+	}
+	controllerConfig.Validate(cfg.CalibrationFile)
+	return controllerConfig
+}
+
+func gripperJawSwingDegs(cfg *SO101GripperConfig) float64 {
+	if cfg.JawSwingDegs == 0 {
+		return defaultGripperJawSwingDegs
+	}
+	return cfg.JawSwingDegs
+}
+
+// applySettings sets the grab, geometry and overload settings from cfg, with defaults for
+// anything not configured
+func (g *so101Gripper) applySettings(cfg *SO101GripperConfig) {
+	g.geometry = gripperGeometry{MaxOpeningMM: cfg.MaxOpeningMM, JawSwingDegs: gripperJawSwingDegs(cfg)}
+	if g.geometry.MaxOpeningMM == 0 {
+		g.geometry.MaxOpeningMM = defaultGripperMaxOpeningMM
+	}
+	g.grab = grabSettings{
+		PositionThresholdPercent: cfg.GrabPositionThresholdPercent,
+		LoadThresholdPercent:     cfg.GripLoadThresholdPercent,
+		HoldTorquePercent:        cfg.HoldTorquePercent,
+		BackoffPercent:           cfg.GrabBackoffPercent,
+	}
+	if g.grab.PositionThresholdPercent == 0 {
+		g.grab.PositionThresholdPercent = defaultGrabPositionThresholdPercent
+	}
+
+	g.holdMu.Lock()
+	defer g.holdMu.Unlock()
+	g.overloadLoadPercent = cfg.OverloadLoadPercent
+	g.overloadDurationSec = cfg.OverloadDurationSec
+	g.overloadBackoffPercent = cfg.OverloadBackoffPercent
+	g.disableOverloadBackoff = cfg.DisableOverloadBackoff
+	if g.overloadLoadPercent == 0 {
+		g.overloadLoadPercent = defaultOverloadLoadPercent
+	}
+	if g.overloadDurationSec == 0 {
+		g.overloadDurationSec = defaultOverloadDurationSec
+	}
+	if g.overloadBackoffPercent == 0 {
+		g.overloadBackoffPercent = defaultOverloadBackoffPercent
+	}
+}
+
 func (g *so101Gripper) Name() resource.Name {
 	return g.name
 }
 
 func (g *so101Gripper) Open(ctx context.Context, extra map[string]interface{}) error {
+	ctx, cancel, err := withRequestTimeout(ctx, extra)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	return requestTimeoutError(ctx, g.open(ctx, extra))
+}
+
+func (g *so101Gripper) open(ctx context.Context, extra map[string]interface{}) error {
+	target := g.openPosition
+	if percent, ok := extra["percentage"].(float64); ok {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("percentage must be between 0 and 100, got %.1f", percent)
+		}
+		target = percent
+	}
+
+	g.releaseHold(ctx)
+
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
 	g.isMoving.Store(true)
 	defer g.isMoving.Store(false)
 
-	g.logger.Debug("Opening gripper")
+	g.logger.Debugf("Opening gripper to %.1f%%", target)
 
-	if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{g.openPositionRadians()}, 0, 0); err != nil {
+	if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{g.percentToRadians(target)}, 0, 0); err != nil {
 		return fmt.Errorf("failed to open gripper: %w", err)
 	}
 
@@ -169,6 +380,23 @@ func (g *so101Gripper) Open(ctx context.Context, extra map[string]interface{}) e
 }
 
 func (g *so101Gripper) Grab(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	ctx, cancel, err := withRequestTimeout(ctx, extra)
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+	grabbed, err := g.grabObject(ctx, extra)
+	return grabbed, requestTimeoutError(ctx, err)
+}
+
+func (g *so101Gripper) grabObject(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	if force, ok := extra["force_percent"].(float64); ok {
+		result, err := g.grabWithForce(ctx, force)
+		return result.Grabbed, err
+	}
+
+	g.releaseHold(ctx)
+
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -197,12 +425,25 @@ func (g *so101Gripper) Grab(ctx context.Context, extra map[string]interface{}) (
 	currentPercent := g.radiansToPercent(currentPositions[0])
 
 	positionDifference := currentPercent - g.closedPosition
-	threshold := 15.0
+	grabbed := positionDifference > g.grab.PositionThresholdPercent
 
-	grabbed := positionDifference > threshold
+	if !grabbed && g.grab.LoadThresholdPercent > 0 {
+		loads, err := g.controller.GetServoLoads(ctx, []int{g.servoID})
+		if err != nil {
+			g.logger.Warnf("Failed to read gripper load after grab: %v", err)
+		} else {
+			grabbed = math.Abs(loads[g.servoID]) >= g.grab.LoadThresholdPercent
+		}
+	}
 
+	g.recordGrab(grabbed, currentPercent)
 	if grabbed {
-		g.logger.Debugf("Gripper successfully grabbed an object (position difference: %.1f%%)", positionDifference)
+		g.logger.Debugf("Gripper successfully grabbed an object (position difference: %.1f%%, about %.0f mm wide)",
+			positionDifference, g.geometry.openingMM(currentPercent))
+		if err := g.applyHoldSettings(ctx, currentPercent); err != nil {
+			g.logger.Warnf("Failed to apply gripper hold settings: %v", err)
+		}
+		g.startHoldMonitor()
 	} else {
 		g.logger.Debug("Gripper closed but may not have grabbed anything")
 	}
@@ -211,8 +452,9 @@ func (g *so101Gripper) Grab(ctx context.Context, extra map[string]interface{}) (
 }
 
 func (g *so101Gripper) Stop(ctx context.Context, extra map[string]interface{}) error {
+	g.releaseHold(ctx)
 	g.isMoving.Store(false)
-	return g.controller.Stop(ctx)
+	return g.controller.StopServos(ctx, []int{g.servoID})
 }
 
 func (g *so101Gripper) IsMoving(ctx context.Context) (bool, error) {
@@ -227,7 +469,22 @@ func (g *so101Gripper) Geometries(ctx context.Context, extra map[string]interfac
 }
 
 func (g *so101Gripper) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	ctx, cancel, err := withRequestTimeout(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	result, err := g.doCommand(ctx, cmd)
+	return result, requestTimeoutError(ctx, err)
+}
+
+func (g *so101Gripper) doCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	switch cmd["command"] {
+	case "get_torque":
+		ids := []int{g.servoID}
+		states, failed := g.controller.GetTorqueStates(ctx, ids)
+		return torqueReport(ids, states, failed), nil
+
 	case "get_position":
 		positions, err := g.controller.GetJointPositionsForServos(ctx, []int{g.servoID})
 		if err != nil {
@@ -242,6 +499,7 @@ func (g *so101Gripper) DoCommand(ctx context.Context, cmd map[string]interface{}
 		return map[string]interface{}{
 			"position_radians":    positions[0],
 			"position_percentage": percentPos,
+			"opening_mm":          g.geometry.openingMM(percentPos),
 			"open_position":       g.openPosition,
 			"closed_position":     g.closedPosition,
 		}, nil
@@ -257,7 +515,7 @@ func (g *so101Gripper) DoCommand(ctx context.Context, cmd map[string]interface{}
 				normalizedPos := (servoPos - float64(cal.RangeMin)) / float64(cal.RangeMax-cal.RangeMin)
 				targetPercent = normalizedPos * 100.0
 			} else {
-				targetPercent = (servoPos / 4095.0) * 100.0
+				targetPercent = (servoPos / ServoMaxPosition) * 100.0
 			}
 		} else {
 			return nil, fmt.Errorf("set_position command requires 'percentage' or 'servo_position' parameter")
@@ -270,6 +528,8 @@ func (g *so101Gripper) DoCommand(ctx context.Context, cmd map[string]interface{}
 			targetPercent = 100
 		}
 
+		g.releaseHold(ctx)
+
 		g.mu.Lock()
 		defer g.mu.Unlock()
 
@@ -280,14 +540,29 @@ func (g *so101Gripper) DoCommand(ctx context.Context, cmd map[string]interface{}
 		err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{targetRadians}, 0, 0)
 		return map[string]interface{}{"success": err == nil}, err
 
+	case "emergency_stop":
+		g.stopHoldMonitor()
+		g.isMoving.Store(false)
+		return estopCommand(ctx, g.controller, g.name.ShortName(), cmd)
+
+	case "clear_emergency_stop", "emergency_stop_status":
+		return estopCommand(ctx, g.controller, g.name.ShortName(), cmd)
+
+	case "auto_calibrate":
+		return g.autoCalibrate(ctx, cmd)
+
 	case "controller_status":
 		refCount, hasController, configSummary := GetControllerStatus()
-		return map[string]interface{}{
+		status := map[string]interface{}{
 			"ref_count":      refCount,
 			"has_controller": hasController,
 			"config":         configSummary,
 			"servo_id":       g.servoID,
-		}, nil
+		}
+		if g.controller != nil {
+			status["servo_versions"] = servoVersionsStatus(ctx, g.controller, []int{g.servoID})
+		}
+		return status, nil
 
 	case "calibrate_positions":
 		if openPos, ok := cmd["open_position"].(float64); ok {
@@ -333,28 +608,45 @@ func (g *so101Gripper) DoCommand(ctx context.Context, cmd map[string]interface{}
 			"acceleration": g.acceleration,
 		}, nil
 
+	case "get_object_width":
+		return g.objectWidth()
+
+	case "grab_with_force":
+		return g.forceGripCommand(ctx, cmd)
+
+	case "characterize_force":
+		return g.characterizeForceCommand(ctx, cmd)
+
+	case "get_overload_status":
+		return g.overloadStatus(), nil
+
+	case "lint_config":
+		self := ConsumerInfo{Name: g.name.ShortName(), ServoIDs: []int{g.servoID}}
+		for _, consumer := range GetSharedConsumers(g.port) {
+			if consumer.Name == self.Name {
+				self = consumer
+			}
+		}
+		issues := lintSharedConfig(ctx, g.controller, g.port, g.baudrate, self)
+		issues = append(issues, lintSpeed(self.Name, float64(g.speed), float64(g.acceleration))...)
+		return lintResult(issues), nil
+
+	case "client_snippets":
+		return clientSnippetsResponse(SO101GripperModel.String(), gripperClientSnippets), nil
+
 	default:
 		return nil, fmt.Errorf("unknown command: %v", cmd["command"])
 	}
 }
 
 func (g *so101Gripper) Close(ctx context.Context) error {
-	ReleaseSharedController()
+	g.releaseHold(ctx)
+	g.cancelFunc()
+	UnregisterSharedConsumer(g.port, g.name.ShortName())
+	ReleaseSharedControllerForPort(g.port, g.controller)
 	return nil
 }
 
-func (g *so101Gripper) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
-	return nil, errors.ErrUnsupported
-}
-
-func (g *so101Gripper) GoToInputs(ctx context.Context, inputs ...[]referenceframe.Input) error {
-	return errors.ErrUnsupported
-}
-
-func (g *so101Gripper) Kinematics(ctx context.Context) (referenceframe.Model, error) {
-	return nil, errors.ErrUnsupported
-}
-
 func (g *so101Gripper) IsHoldingSomething(ctx context.Context, extra map[string]interface{}) (gripper.HoldingStatus, error) {
 	return gripper.HoldingStatus{}, errors.ErrUnsupported
 }
@@ -367,58 +659,13 @@ func (g *so101Gripper) closedPositionRadians() float64 {
 	return g.percentToRadians(g.closedPosition)
 }
 
+// percentToRadians converts an opening percentage to the controller's radian representation.
+// Drive mode inversion is handled by the calibration when the value is denormalized.
 func (g *so101Gripper) percentToRadians(percent float64) float64 {
-	// Since the gripper calibration uses NormModeRange100 (0-100%),
-	// we can directly use the percentage value and let feetech-servo handle conversion
-	// But we need to convert to the expected format for the controller
-
-	// For now, we'll do a simple mapping based on the calibration range
-	cal := g.controller.getCalibrationForServo(g.servoID)
-	if cal == nil {
-		// Fallback to default behavior
-		return (percent - 50.0) / 50.0 * math.Pi
-	}
-
-	// Convert percentage to normalized position within the calibrated range
-	normalizedPos := percent / 100.0
-
-	// Convert to radians (assuming ±π range)
-	radians := (normalizedPos*2.0 - 1.0) * math.Pi // Convert 0-1 to -π to +π
-
-	// Apply drive mode if needed
-	if cal.DriveMode != 0 {
-		radians = -radians
-	}
-
-	return radians
+	return GripperPercentToRadians(percent)
 }
 
+// radiansToPercent converts the controller's radian representation to an opening percentage
 func (g *so101Gripper) radiansToPercent(radians float64) float64 {
-	cal := g.controller.getCalibrationForServo(g.servoID)
-	if cal == nil {
-		// Fallback to default behavior
-		return (radians/math.Pi)*50.0 + 50.0
-	}
-
-	// Apply drive mode if needed
-	adjustedRadians := radians
-	if cal.DriveMode != 0 {
-		adjustedRadians = -radians
-	}
-
-	// Convert radians to normalized position (-1 to 1)
-	normalizedPos := adjustedRadians / math.Pi
-
-	// Convert to percentage (0-100)
-	percent := (normalizedPos + 1.0) / 2.0 * 100.0
-
-	// Clamp to valid range
-	if percent < 0 {
-		percent = 0
-	}
-	if percent > 100 {
-		percent = 100
-	}
-
-	return percent
+	return math.Max(0, math.Min(100, GripperRadiansToPercent(radians)))
 }
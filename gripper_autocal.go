@@ -0,0 +1,140 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Gripper self-calibration. The jaw is walked toward each end in small raw steps with a
+// reduced torque limit, and the stop is wherever the load spikes or the servo stops keeping
+// up with its goal.
+const (
+	autoCalibrateTorquePercent    = 30.0
+	autoCalibrateStallLoadPercent = 25.0
+	autoCalibrateStepSteps        = 8
+	autoCalibrateStepInterval     = 30 * time.Millisecond
+	autoCalibrateLagSteps         = 80
+	autoCalibrateMarginPercent    = 2.0
+	autoCalibrateTimeout          = 15 * time.Second
+)
+
+// autoCalibrate handles the auto_calibrate DoCommand. It finds the closed and open stops,
+// sets closed_position and open_position just inside them, and with save rewrites the
+// gripper's range in the calibration file so the stops become 0% and 100%.
+func (g *so101Gripper) autoCalibrate(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	save, _ := cmd["save"].(bool)
+	if save && g.calibrationFile == "" {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "No calibration file configured",
+		}, nil
+	}
+
+	cal := g.controller.getCalibrationForServo(g.servoID)
+	if cal == nil {
+		return nil, fmt.Errorf("no calibration for gripper servo %d", g.servoID)
+	}
+	closedRaw, err := cal.Denormalize(0)
+	if err != nil {
+		return nil, err
+	}
+	openRaw, err := cal.Denormalize(100)
+	if err != nil {
+		return nil, err
+	}
+	closingDirection := 1
+	if closedRaw < openRaw {
+		closingDirection = -1
+	}
+
+	g.releaseHold(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.isMoving.Store(true)
+	defer g.isMoving.Store(false)
+
+	savedLimit, err := g.controller.ReadServoRegister(ctx, g.servoID, "torque_limit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gripper torque limit: %w", err)
+	}
+	limit := int(math.Round(autoCalibrateTorquePercent * 10))
+	if err := g.controller.WriteServoRegister(ctx, g.servoID, "torque_limit", g.controller.protocolFor(g.servoID).EncodeWord(uint16(limit))); err != nil {
+		return nil, fmt.Errorf("failed to lower gripper torque limit: %w", err)
+	}
+	defer func() {
+		if err := g.controller.WriteServoRegister(ctx, g.servoID, "torque_limit", savedLimit); err != nil {
+			g.logger.Warnf("Failed to restore gripper torque limit after auto calibration: %v", err)
+		}
+	}()
+
+	closedStop, err := g.findStop(ctx, closingDirection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed stop: %w", err)
+	}
+	openStop, err := g.findStop(ctx, -closingDirection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open stop: %w", err)
+	}
+	g.logger.Debugf("Gripper stops found at raw %d (closed) and %d (open)", closedStop, openStop)
+
+	if save {
+		updated := *cal
+		updated.RangeMin = min(closedStop, openStop)
+		updated.RangeMax = max(closedStop, openStop)
+		if err := updated.Validate(); err != nil {
+			return nil, fmt.Errorf("measured gripper range is invalid: %w", err)
+		}
+		calibration := g.controller.GetCalibration()
+		calibration.Gripper = &updated
+		if err := SaveFullCalibrationToFile(g.calibrationFile, calibration); err != nil {
+			return nil, fmt.Errorf("failed to save calibration: %w", err)
+		}
+		if err := ApplySharedCalibration(g.port, calibration); err != nil {
+			return nil, fmt.Errorf("failed to apply calibration: %w", err)
+		}
+		cal = &updated
+	}
+
+	closedPercent, err := cal.Normalize(closedStop)
+	if err != nil {
+		return nil, err
+	}
+	openPercent, err := cal.Normalize(openStop)
+	if err != nil {
+		return nil, err
+	}
+	g.closedPosition = clampFloat(closedPercent+autoCalibrateMarginPercent, 0, 100)
+	g.openPosition = clampFloat(openPercent-autoCalibrateMarginPercent, 0, 100)
+
+	if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{g.openPositionRadians()}, 0, 0); err != nil {
+		g.logger.Warnf("Failed to open gripper after auto calibration: %v", err)
+	}
+
+	return map[string]interface{}{
+		"success":         true,
+		"closed_raw":      closedStop,
+		"open_raw":        openStop,
+		"closed_position": g.closedPosition,
+		"open_position":   g.openPosition,
+		"saved":           save,
+	}, nil
+}
+
+// gripperStallSearch walks the jaw toward a stop for auto_calibrate
+var gripperStallSearch = stallSearch{
+	StepSteps:        autoCalibrateStepSteps,
+	StepInterval:     autoCalibrateStepInterval,
+	LagSteps:         autoCalibrateLagSteps,
+	StallLoadPercent: autoCalibrateStallLoadPercent,
+	Timeout:          autoCalibrateTimeout,
+}
+
+// findStop walks the gripper toward a stop in direction (+1 or -1 in raw steps) and returns
+// the raw position it stalled at. The caller must hold g.mu.
+func (g *so101Gripper) findStop(ctx context.Context, direction int) (int, error) {
+	return findStallPosition(ctx, g.controller, g.servoID, direction, gripperStallSearch, g.logger)
+}
@@ -0,0 +1,162 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Force grip settings. The grip counts as made once the load is within
+// forceGripTolerancePercent of the target.
+const (
+	minGripForcePercent       = 1.0
+	maxGripForcePercent       = 100.0
+	forceGripTolerancePercent = 2.0
+	forceGripPollInterval     = 20 * time.Millisecond
+	forceGripTimeout          = 3 * time.Second
+)
+
+// forceGripResult describes how a force-targeted grab ended
+type forceGripResult struct {
+	Grabbed         bool
+	LoadPercent     float64
+	PositionPercent float64
+}
+
+// grabWithForce closes the gripper until its load reaches forcePercent of stall torque and
+// holds there. The torque limit is lowered to the target so the servo can't squeeze harder
+// than asked, even between polls, and stays lowered until the gripper is commanded again.
+func (g *so101Gripper) grabWithForce(ctx context.Context, forcePercent float64) (forceGripResult, error) {
+	if forcePercent < minGripForcePercent || forcePercent > maxGripForcePercent {
+		return forceGripResult{}, fmt.Errorf("force_percent must be between %.0f and %.0f, got %.1f", minGripForcePercent, maxGripForcePercent, forcePercent)
+	}
+
+	g.releaseHold(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.isMoving.Store(true)
+	defer g.isMoving.Store(false)
+
+	saved, err := g.controller.ReadServoRegister(ctx, g.servoID, "torque_limit")
+	if err != nil {
+		return forceGripResult{}, fmt.Errorf("failed to read gripper torque limit: %w", err)
+	}
+	limit := int(math.Round(forcePercent * 10)) // torque_limit is in 0.1% of stall torque
+	if err := g.controller.WriteServoRegister(ctx, g.servoID, "torque_limit", g.controller.protocolFor(g.servoID).EncodeWord(uint16(limit))); err != nil {
+		return forceGripResult{}, fmt.Errorf("failed to set gripper torque limit: %w", err)
+	}
+	g.holdMu.Lock()
+	g.savedTorqueLimit = saved
+	g.holdMu.Unlock()
+
+	if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{g.closedPositionRadians()}, 0, 0); err != nil {
+		return forceGripResult{}, fmt.Errorf("failed to close gripper: %w", err)
+	}
+
+	var result forceGripResult
+	var loadErr error
+	deadline := time.Now().Add(forceGripTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(forceGripPollInterval):
+		}
+
+		loads, err := g.controller.GetServoLoads(ctx, []int{g.servoID})
+		if loadErr = err; err != nil {
+			continue
+		}
+		result.LoadPercent = math.Abs(loads[g.servoID])
+		positions, err := g.controller.GetJointPositionsForServos(ctx, []int{g.servoID})
+		if err == nil && len(positions) > 0 {
+			result.PositionPercent = g.radiansToPercent(positions[0])
+		}
+
+		if result.LoadPercent >= forcePercent-forceGripTolerancePercent {
+			result.Grabbed = true
+			break
+		}
+		if result.PositionPercent-g.closedPosition < 1 {
+			// Fully closed without meeting resistance, nothing in the gripper
+			break
+		}
+	}
+
+	if loadErr != nil {
+		// Without a load reading the grip can't be judged either way
+		return result, fmt.Errorf("failed to read gripper load: %w", loadErr)
+	}
+
+	g.recordGrab(result.Grabbed, result.PositionPercent)
+	if result.Grabbed {
+		g.logger.Debugf("Gripper holding at %.1f%% load, %.1f%% open", result.LoadPercent, result.PositionPercent)
+	} else {
+		g.logger.Debugf("Gripper did not reach %.1f%% load (last %.1f%%, %.1f%% open)", forcePercent, result.LoadPercent, result.PositionPercent)
+	}
+	return result, nil
+}
+
+// applyHoldSettings lowers the torque limit and backs off the grip after a successful Grab,
+// as configured by hold_torque_percent and grab_backoff_percent. The caller must hold g.mu.
+func (g *so101Gripper) applyHoldSettings(ctx context.Context, currentPercent float64) error {
+	if g.grab.HoldTorquePercent > 0 {
+		saved, err := g.controller.ReadServoRegister(ctx, g.servoID, "torque_limit")
+		if err != nil {
+			return fmt.Errorf("failed to read gripper torque limit: %w", err)
+		}
+		limit := int(math.Round(g.grab.HoldTorquePercent * 10))
+		if err := g.controller.WriteServoRegister(ctx, g.servoID, "torque_limit", g.controller.protocolFor(g.servoID).EncodeWord(uint16(limit))); err != nil {
+			return fmt.Errorf("failed to set hold torque: %w", err)
+		}
+		g.holdMu.Lock()
+		g.savedTorqueLimit = saved
+		g.holdMu.Unlock()
+	}
+
+	if g.grab.BackoffPercent > 0 {
+		target := math.Min(100, currentPercent+g.grab.BackoffPercent)
+		if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{g.percentToRadians(target)}, 0, 0); err != nil {
+			return fmt.Errorf("failed to back off grip: %w", err)
+		}
+	}
+	return nil
+}
+
+// releaseHold stops overload monitoring and restores a torque limit lowered by a force grip
+func (g *so101Gripper) releaseHold(ctx context.Context) {
+	g.stopHoldMonitor()
+
+	g.holdMu.Lock()
+	saved := g.savedTorqueLimit
+	g.savedTorqueLimit = nil
+	g.holdMu.Unlock()
+	if saved == nil {
+		return
+	}
+	if err := g.controller.WriteServoRegister(ctx, g.servoID, "torque_limit", saved); err != nil {
+		g.logger.Warnf("Failed to restore gripper torque limit after force grip: %v", err)
+	}
+}
+
+// forceGripCommand handles the grab_with_force DoCommand
+func (g *so101Gripper) forceGripCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	force, ok := cmd["force_percent"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("grab_with_force requires 'force_percent' number parameter")
+	}
+	result, err := g.grabWithForce(ctx, force)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"success":          true,
+		"grabbed":          result.Grabbed,
+		"load_percent":     result.LoadPercent,
+		"position_percent": result.PositionPercent,
+		"width_mm":         g.geometry.openingMM(result.PositionPercent),
+	}, nil
+}
@@ -0,0 +1,278 @@
+package so_arm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Force characterization. The load register is a percentage of stall torque, and the force
+// that puts on the jaw tips changes with the aperture because the moving jaw is a lever. The
+// characterize_force routine closes on a force gauge at several apertures, the user enters
+// each gauge reading, and the fitted mapping is kept in module data so grips can later be
+// asked for in newtons.
+const (
+	defaultCharacterizeForcePercent = 30.0
+	minForceMapSamples              = 2
+)
+
+// forceSample is one gauge reading taken by characterize_force
+type forceSample struct {
+	PositionPercent float64 `json:"position_percent"`
+	WidthMM         float64 `json:"width_mm"`
+	LoadPercent     float64 `json:"load_percent"`
+	ForceN          float64 `json:"force_n"`
+}
+
+// forceMap maps the gripper's load to the force at the jaw tips. The force per load percent
+// varies linearly with the opening:
+//
+//	force_n = load_percent * (newtons_per_load_percent + aperture_slope * position_percent / 100)
+type forceMap struct {
+	NewtonsPerLoadPercent float64       `json:"newtons_per_load_percent"`
+	ApertureSlope         float64       `json:"aperture_slope"`
+	Samples               []forceSample `json:"samples"`
+	CreatedAt             time.Time     `json:"created_at"`
+}
+
+// forceN estimates the force at the jaw tips for a load and opening
+func (m *forceMap) forceN(loadPercent, positionPercent float64) float64 {
+	return loadPercent * m.gain(positionPercent)
+}
+
+// loadForForce returns the load that gives forceN at the jaw tips at an opening
+func (m *forceMap) loadForForce(forceN, positionPercent float64) (float64, error) {
+	gain := m.gain(positionPercent)
+	if gain <= 0 {
+		return 0, fmt.Errorf("force map has no usable gain at %.1f%% open", positionPercent)
+	}
+	return forceN / gain, nil
+}
+
+func (m *forceMap) gain(positionPercent float64) float64 {
+	return m.NewtonsPerLoadPercent + m.ApertureSlope*positionPercent/100
+}
+
+// fitForceMap fits a force map to gauge samples by least squares. When every sample was taken
+// at the same opening, the aperture slope can't be known and is left at zero.
+func fitForceMap(samples []forceSample) (*forceMap, error) {
+	if len(samples) < minForceMapSamples {
+		return nil, fmt.Errorf("need at least %d samples, have %d", minForceMapSamples, len(samples))
+	}
+
+	// Features are the load and the load scaled by the opening, with no intercept since no
+	// load means no force
+	var s11, s12, s22, s1f, s2f float64
+	for _, sample := range samples {
+		x1 := sample.LoadPercent
+		x2 := sample.LoadPercent * sample.PositionPercent / 100
+		s11 += x1 * x1
+		s12 += x1 * x2
+		s22 += x2 * x2
+		s1f += x1 * sample.ForceN
+		s2f += x2 * sample.ForceN
+	}
+	if s11 == 0 {
+		return nil, fmt.Errorf("every sample has zero load")
+	}
+
+	m := &forceMap{Samples: samples, CreatedAt: time.Now().UTC()}
+	det := s11*s22 - s12*s12
+	if det <= 1e-9*s11*s22 {
+		m.NewtonsPerLoadPercent = s1f / s11
+	} else {
+		m.NewtonsPerLoadPercent = (s1f*s22 - s2f*s12) / det
+		m.ApertureSlope = (s11*s2f - s12*s1f) / det
+	}
+	return m, nil
+}
+
+// forceMapPath returns where a gripper's force map is kept
+func forceMapPath(name string) string {
+	return filepath.Join(moduleDataDir(), name+"_force_map.json")
+}
+
+// loadForceMap reads a saved force map, returning nil if there isn't one
+func loadForceMap(path string) (*forceMap, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read force map: %w", err)
+	}
+	var m forceMap
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse force map %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// saveForceMap writes a force map, replacing any earlier one
+func saveForceMap(path string, m *forceMap) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create force map directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// forceCharacterization is the state of a characterize_force session
+type forceCharacterization struct {
+	mu      sync.Mutex
+	active  bool
+	pending *forceSample // closed on the gauge, waiting for its reading
+	samples []forceSample
+	saved   *forceMap
+}
+
+// characterizeForceCommand handles the characterize_force DoCommand. Each sample is a close
+// step that squeezes the gauge, then a record step with the reading in newtons.
+func (g *so101Gripper) characterizeForceCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	fc := &g.forceChar
+	step, _ := cmd["step"].(string)
+
+	switch step {
+	case "start":
+		fc.mu.Lock()
+		fc.active = true
+		fc.pending = nil
+		fc.samples = nil
+		fc.mu.Unlock()
+		if err := g.open(ctx, nil); err != nil {
+			return nil, err
+		}
+		return g.forceCharStatus("Place the force gauge between the jaws, then send step 'close'"), nil
+
+	case "close":
+		fc.mu.Lock()
+		active := fc.active
+		fc.mu.Unlock()
+		if !active {
+			return nil, fmt.Errorf("no force characterization in progress, send step 'start' first")
+		}
+		force := defaultCharacterizeForcePercent
+		if f, ok := cmd["force_percent"].(float64); ok {
+			force = f
+		}
+		result, err := g.grabWithForce(ctx, force)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Grabbed {
+			g.releaseHold(ctx)
+			return nil, fmt.Errorf("gripper closed to %.1f%% without reaching %.1f%% load, check the gauge is between the jaws", result.PositionPercent, force)
+		}
+		fc.mu.Lock()
+		fc.pending = &forceSample{
+			PositionPercent: result.PositionPercent,
+			WidthMM:         g.geometry.openingMM(result.PositionPercent),
+			LoadPercent:     result.LoadPercent,
+		}
+		fc.mu.Unlock()
+		return g.forceCharStatus("Read the gauge and send step 'record' with force_n"), nil
+
+	case "record":
+		forceN, ok := cmd["force_n"].(float64)
+		if !ok || forceN <= 0 {
+			return nil, fmt.Errorf("record requires a positive 'force_n' gauge reading")
+		}
+		fc.mu.Lock()
+		if fc.pending == nil {
+			fc.mu.Unlock()
+			return nil, fmt.Errorf("no gauge reading pending, send step 'close' first")
+		}
+		sample := *fc.pending
+		sample.ForceN = forceN
+		fc.samples = append(fc.samples, sample)
+		fc.pending = nil
+		fc.mu.Unlock()
+		if err := g.open(ctx, nil); err != nil {
+			return nil, err
+		}
+		return g.forceCharStatus("Move the gauge to another aperture and send step 'close', or send step 'finish'"), nil
+
+	case "finish":
+		fc.mu.Lock()
+		samples := append([]forceSample(nil), fc.samples...)
+		fc.mu.Unlock()
+		m, err := fitForceMap(samples)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveForceMap(forceMapPath(g.name.ShortName()), m); err != nil {
+			return nil, err
+		}
+		fc.mu.Lock()
+		fc.active = false
+		fc.pending = nil
+		fc.samples = nil
+		fc.saved = m
+		fc.mu.Unlock()
+		g.logger.Infof("Gripper force map saved from %d samples: %.3f N per load %% plus %.3f per opening",
+			len(samples), m.NewtonsPerLoadPercent, m.ApertureSlope)
+		return g.forceCharStatus("Force characterization saved"), nil
+
+	case "abort":
+		fc.mu.Lock()
+		fc.active = false
+		fc.pending = nil
+		fc.samples = nil
+		fc.mu.Unlock()
+		g.releaseHold(ctx)
+		return g.forceCharStatus("Force characterization aborted"), nil
+
+	case "", "status":
+		return g.forceCharStatus(""), nil
+
+	default:
+		return nil, fmt.Errorf("unknown characterize_force step %q, expected start, close, record, finish, abort or status", step)
+	}
+}
+
+// forceCharStatus reports the session and the saved force map
+func (g *so101Gripper) forceCharStatus(instruction string) map[string]interface{} {
+	fc := &g.forceChar
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	samples := make([]interface{}, 0, len(fc.samples))
+	for _, s := range fc.samples {
+		samples = append(samples, map[string]interface{}{
+			"position_percent": s.PositionPercent,
+			"width_mm":         s.WidthMM,
+			"load_percent":     s.LoadPercent,
+			"force_n":          s.ForceN,
+		})
+	}
+	result := map[string]interface{}{
+		"active":  fc.active,
+		"samples": samples,
+	}
+	if instruction != "" {
+		result["instruction"] = instruction
+	}
+	if fc.pending != nil {
+		result["pending"] = map[string]interface{}{
+			"position_percent": fc.pending.PositionPercent,
+			"width_mm":         fc.pending.WidthMM,
+			"load_percent":     fc.pending.LoadPercent,
+		}
+	}
+	if fc.saved != nil {
+		result["force_map"] = map[string]interface{}{
+			"newtons_per_load_percent": fc.saved.NewtonsPerLoadPercent,
+			"aperture_slope":           fc.saved.ApertureSlope,
+			"sample_count":             len(fc.saved.Samples),
+			"created_at":               fc.saved.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package so_arm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitForceMap(t *testing.T) {
+	// 0.2 N per load percent when closed, falling to 0.1 when fully open
+	truth := &forceMap{NewtonsPerLoadPercent: 0.2, ApertureSlope: -0.1}
+	var samples []forceSample
+	for _, s := range []struct{ load, pos float64 }{{20, 10}, {30, 40}, {25, 80}} {
+		samples = append(samples, forceSample{LoadPercent: s.load, PositionPercent: s.pos, ForceN: truth.forceN(s.load, s.pos)})
+	}
+
+	m, err := fitForceMap(samples)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.2, m.NewtonsPerLoadPercent, 1e-9)
+	assert.InDelta(t, -0.1, m.ApertureSlope, 1e-9)
+	load, err := m.loadForForce(3, 40)
+	assert.NoError(t, err)
+	assert.InDelta(t, 18.75, load, 1e-9)
+
+	// One aperture can't show how the gain changes with the opening
+	m, err = fitForceMap([]forceSample{{LoadPercent: 10, PositionPercent: 30, ForceN: 2}, {LoadPercent: 20, PositionPercent: 30, ForceN: 4}})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.2, m.NewtonsPerLoadPercent, 1e-9)
+	assert.Zero(t, m.ApertureSlope)
+
+	_, err = fitForceMap(samples[:1])
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "gripper_force_map.json")
+	assert.NoError(t, saveForceMap(path, m))
+	loaded, err := loadForceMap(path)
+	assert.NoError(t, err)
+	assert.Equal(t, m.NewtonsPerLoadPercent, loaded.NewtonsPerLoadPercent)
+	assert.Len(t, loaded.Samples, 2)
+
+	missing, err := loadForceMap(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,47 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestGrabWithForceValidation(t *testing.T) {
+	g := &so101Gripper{servoID: 6}
+	for _, force := range []float64{0, -5, 150} {
+		_, err := g.grabWithForce(context.Background(), force)
+		assert.ErrorContains(t, err, "force_percent")
+	}
+
+	_, err := g.forceGripCommand(context.Background(), map[string]interface{}{"command": "grab_with_force"})
+	assert.Error(t, err)
+}
+
+func TestGrabWithForceReadsLoadFromBus(t *testing.T) {
+	controller, fake := newFakeController(t, 6)
+	g := &so101Gripper{
+		logger:         logging.NewTestLogger(t),
+		controller:     controller,
+		servoID:        6,
+		closedPosition: 0,
+	}
+	ctx := context.Background()
+	fake.setRegister(6, feetech.RegTorqueLimit, []byte{0xE8, 0x03})
+	fake.setRegister(6, feetech.RegPresentPosition, []byte{0x00, 0x08})
+	fake.setRegister(6, feetech.RegPresentLoad, []byte{0x2C, 0x01})
+
+	// 30% load on an open jaw means it closed on something
+	result, err := g.grabWithForce(ctx, 30)
+	assert.NoError(t, err)
+	assert.True(t, result.Grabbed)
+	assert.Equal(t, 30.0, result.LoadPercent)
+	assert.Equal(t, []byte{0x2C, 0x01}, fake.register(6, feetech.RegTorqueLimit))
+
+	// A gripper whose load can't be read fails instead of reporting an empty grab
+	fake.failReads(feetech.RegPresentLoad)
+	_, err = g.grabWithForce(ctx, 30)
+	assert.ErrorContains(t, err, "failed to read gripper load")
+}
@@ -0,0 +1,106 @@
+package so_arm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+// Settling for GoToInputs, which has to block until the jaw reaches each step
+const (
+	gripperInputPollInterval = 20 * time.Millisecond
+	gripperInputTimeout      = 3 * time.Second
+)
+
+// makeGripperModelFrame builds a one-DOF model for the frame system. The single revolute joint
+// is the moving jaw's swing, from closed at 0 to fully open at swingDegs. The jaw moves inside
+// the gripper's own geometry, so the end frame doesn't move with it.
+func makeGripperModelFrame(name string, swingDegs float64) (referenceframe.Model, error) {
+	m := map[string]interface{}{
+		"name":                 name,
+		"kinematic_param_type": "SVA",
+		"joints": []map[string]interface{}{{
+			"id":     "jaw",
+			"parent": "world",
+			"type":   "revolute",
+			"axis":   map[string]float64{"x": 0, "y": 0, "z": 1},
+			"min":    0,
+			"max":    swingDegs,
+		}},
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build gripper model: %w", err)
+	}
+	return referenceframe.UnmarshalModelJSON(data, name)
+}
+
+// percentToInput converts an opening percentage to the jaw joint angle in radians
+func (g *so101Gripper) percentToInput(percent float64) referenceframe.Input {
+	return DegreesToRadians(g.geometry.JawSwingDegs * clampFloat(percent, 0, 100) / 100)
+}
+
+// inputToPercent converts a jaw joint angle in radians to an opening percentage
+func (g *so101Gripper) inputToPercent(input referenceframe.Input) float64 {
+	return clampFloat(RadiansToDegrees(input)/g.geometry.JawSwingDegs*100, 0, 100)
+}
+
+func (g *so101Gripper) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	positions, err := g.controller.GetJointPositionsForServos(ctx, []int{g.servoID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gripper position: %w", err)
+	}
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("no position data available")
+	}
+	return []referenceframe.Input{g.percentToInput(g.radiansToPercent(positions[0]))}, nil
+}
+
+func (g *so101Gripper) GoToInputs(ctx context.Context, inputs ...[]referenceframe.Input) error {
+	g.releaseHold(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.isMoving.Store(true)
+	defer g.isMoving.Store(false)
+
+	for _, step := range inputs {
+		if len(step) != 1 {
+			return fmt.Errorf("gripper has 1 input, got %d", len(step))
+		}
+		target := g.percentToRadians(g.inputToPercent(step[0]))
+		if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{target}, 0, 0); err != nil {
+			return fmt.Errorf("failed to move gripper: %w", err)
+		}
+		if err := g.waitForStop(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *so101Gripper) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return g.model, nil
+}
+
+// waitForStop blocks until the gripper servo stops moving. An object in the jaws stops it
+// short of the target, which still counts as done.
+func (g *so101Gripper) waitForStop(ctx context.Context) error {
+	deadline := time.Now().Add(gripperInputTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(gripperInputPollInterval):
+		}
+		moving, err := g.controller.ServosMoving(ctx, []int{g.servoID})
+		if err == nil && !moving {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGripperModelFrame(t *testing.T) {
+	model, err := makeGripperModelFrame("so101_gripper", 100)
+	assert.NoError(t, err)
+	limits := model.DoF()
+	if !assert.Len(t, limits, 1) {
+		return
+	}
+	assert.InDelta(t, 0, limits[0].Min, 1e-9)
+	assert.InDelta(t, DegreesToRadians(100), limits[0].Max, 1e-9)
+}
+
+func TestGripperInputConversion(t *testing.T) {
+	g := &so101Gripper{geometry: gripperGeometry{JawSwingDegs: 100}}
+	assert.InDelta(t, DegreesToRadians(50), g.percentToInput(50), 1e-9)
+	assert.InDelta(t, 50, g.inputToPercent(g.percentToInput(50)), 1e-9)
+	assert.InDelta(t, 100, g.inputToPercent(DegreesToRadians(120)), 1e-9)
+}
@@ -0,0 +1,140 @@
+package so_arm
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Overload protection defaults for a gripper holding an object
+const (
+	defaultOverloadLoadPercent    = 80.0
+	defaultOverloadDurationSec    = 3.0
+	defaultOverloadBackoffPercent = 5.0
+	overloadPollInterval          = 200 * time.Millisecond
+)
+
+// holdMonitor watches the gripper load while it holds an object
+type holdMonitor struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// overloadBackoff records one automatic back-off for get_overload_status
+type overloadBackoff struct {
+	Time            time.Time
+	LoadPercent     float64
+	PositionPercent float64
+}
+
+// startHoldMonitor begins watching for sustained overload after a successful grab
+func (g *so101Gripper) startHoldMonitor() {
+	if g.disableOverloadBackoff {
+		return
+	}
+	g.stopHoldMonitor()
+
+	ctx, cancel := context.WithCancel(g.cancelCtx)
+	monitor := &holdMonitor{cancel: cancel, done: make(chan struct{})}
+	g.holdMu.Lock()
+	g.hold = monitor
+	g.holdMu.Unlock()
+	go g.watchOverload(ctx, monitor.done)
+}
+
+// stopHoldMonitor stops watching the load, it's called whenever the gripper is commanded again
+func (g *so101Gripper) stopHoldMonitor() {
+	g.holdMu.Lock()
+	monitor := g.hold
+	g.hold = nil
+	g.holdMu.Unlock()
+	if monitor == nil {
+		return
+	}
+	monitor.cancel()
+	<-monitor.done
+}
+
+// watchOverload opens the gripper a little whenever its load stays above the threshold for
+// longer than the configured duration, so a long hold on a rigid object doesn't cook the servo
+func (g *so101Gripper) watchOverload(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(overloadPollInterval)
+	defer ticker.Stop()
+
+	duration := time.Duration(g.overloadDurationSec * float64(time.Second))
+	var overSince time.Time
+	loadFailing := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		loads, err := g.controller.GetServoLoads(ctx, []int{g.servoID})
+		if err != nil {
+			// Warn once per run of failures rather than on every poll
+			if !loadFailing {
+				g.logger.Warnf("Failed to read gripper load, overload protection is paused: %v", err)
+			}
+			loadFailing = true
+			continue
+		}
+		loadFailing = false
+		load := math.Abs(loads[g.servoID])
+		if load < g.overloadLoadPercent {
+			overSince = time.Time{}
+			continue
+		}
+		if overSince.IsZero() {
+			overSince = time.Now()
+			continue
+		}
+		if time.Since(overSince) < duration {
+			continue
+		}
+		overSince = time.Time{}
+
+		positions, err := g.controller.GetJointPositionsForServos(ctx, []int{g.servoID})
+		if err != nil || len(positions) == 0 {
+			continue
+		}
+		target := math.Min(100, g.radiansToPercent(positions[0])+g.overloadBackoffPercent)
+		if err := g.controller.MoveServosToPositions(ctx, []int{g.servoID}, []float64{g.percentToRadians(target)}, 0, 0); err != nil {
+			g.logger.Warnf("Failed to back off overloaded gripper: %v", err)
+			continue
+		}
+
+		g.holdMu.Lock()
+		g.backoffCount++
+		g.lastBackoff = &overloadBackoff{Time: time.Now(), LoadPercent: load, PositionPercent: target}
+		g.holdMu.Unlock()
+		g.logger.Warnf("Gripper load was %.0f%% for over %v, backed off to %.1f%% open to protect the servo",
+			load, duration, target)
+	}
+}
+
+// overloadStatus handles the get_overload_status DoCommand
+func (g *so101Gripper) overloadStatus() map[string]interface{} {
+	g.holdMu.Lock()
+	defer g.holdMu.Unlock()
+
+	result := map[string]interface{}{
+		"enabled":                  !g.disableOverloadBackoff,
+		"holding":                  g.hold != nil,
+		"backoff_count":            g.backoffCount,
+		"overload_load_percent":    g.overloadLoadPercent,
+		"overload_duration_sec":    g.overloadDurationSec,
+		"overload_backoff_percent": g.overloadBackoffPercent,
+	}
+	if g.lastBackoff != nil {
+		result["last_backoff"] = map[string]interface{}{
+			"time":             g.lastBackoff.Time.Format(time.RFC3339),
+			"load_percent":     g.lastBackoff.LoadPercent,
+			"position_percent": g.lastBackoff.PositionPercent,
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package so_arm
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// The SO-101 has one fixed jaw and one that swings on a pivot, so the gap at the tips is the
+// chord of the swing rather than a linear function of the servo position. The defaults match
+// the claw geometry's 67 mm width when fully open.
+const (
+	defaultGripperMaxOpeningMM = 67.0
+	defaultGripperJawSwingDegs = 100.0
+	maxGripperJawSwingDegs     = 180.0
+)
+
+// gripperGeometry converts an opening percentage to the gap between the jaw tips
+type gripperGeometry struct {
+	MaxOpeningMM float64
+	JawSwingDegs float64
+}
+
+// openingMM estimates the jaw gap in millimeters for an opening percentage
+func (geo gripperGeometry) openingMM(percent float64) float64 {
+	percent = clampFloat(percent, 0, 100)
+	half := DegreesToRadians(geo.JawSwingDegs) / 2
+	return geo.MaxOpeningMM * math.Sin(half*percent/100) / math.Sin(half)
+}
+
+// grabRecord is the outcome of the most recent grab, reported by get_object_width
+type grabRecord struct {
+	Time            time.Time
+	Grabbed         bool
+	PositionPercent float64
+}
+
+// recordGrab remembers the final opening of a grab for width estimation. A missed grab is also
+// counted on the port, so trajectory playback on the arm can abort on it.
+func (g *so101Gripper) recordGrab(grabbed bool, positionPercent float64) {
+	g.holdMu.Lock()
+	defer g.holdMu.Unlock()
+	g.lastGrab = &grabRecord{Time: time.Now(), Grabbed: grabbed, PositionPercent: positionPercent}
+	if !grabbed && g.controller != nil {
+		g.controller.grabFailures.add()
+	}
+}
+
+// grabFailures counts the grabs that missed on a port, shared by every component on it
+type grabFailures struct {
+	n atomic.Int64
+}
+
+func (f *grabFailures) add() {
+	if f != nil {
+		f.n.Add(1)
+	}
+}
+
+func (f *grabFailures) count() int64 {
+	if f == nil {
+		return 0
+	}
+	return f.n.Load()
+}
+
+// objectWidth handles the get_object_width DoCommand. The estimate comes from where the jaws
+// stopped on the last grab, so it's only as good as the object's contact with the jaw tips.
+func (g *so101Gripper) objectWidth() (map[string]interface{}, error) {
+	g.holdMu.Lock()
+	last := g.lastGrab
+	g.holdMu.Unlock()
+
+	if last == nil {
+		return nil, fmt.Errorf("no grab has been made yet")
+	}
+	result := map[string]interface{}{
+		"grabbed":          last.Grabbed,
+		"position_percent": last.PositionPercent,
+		"time":             last.Time.Format(time.RFC3339),
+	}
+	if last.Grabbed {
+		result["width_mm"] = g.geometry.openingMM(last.PositionPercent)
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGripperOpeningMM(t *testing.T) {
+	geo := gripperGeometry{MaxOpeningMM: defaultGripperMaxOpeningMM, JawSwingDegs: defaultGripperJawSwingDegs}
+	assert.InDelta(t, 0, geo.openingMM(0), 1e-9)
+	assert.InDelta(t, 67, geo.openingMM(100), 1e-9)
+	assert.InDelta(t, 67, geo.openingMM(150), 1e-9)
+
+	// The chord grows faster than linearly near closed
+	half := geo.openingMM(50)
+	assert.Greater(t, half, 67.0/2)
+	assert.Less(t, half, 67.0)
+}
+
+func TestObjectWidth(t *testing.T) {
+	g := &so101Gripper{geometry: gripperGeometry{MaxOpeningMM: 67, JawSwingDegs: 100}}
+	_, err := g.objectWidth()
+	assert.Error(t, err)
+
+	g.recordGrab(true, 40)
+	result, err := g.objectWidth()
+	assert.NoError(t, err)
+	assert.InDelta(t, g.geometry.openingMM(40), result["width_mm"], 1e-9)
+
+	g.recordGrab(false, 0)
+	result, err = g.objectWidth()
+	assert.NoError(t, err)
+	assert.NotContains(t, result, "width_mm")
+}
@@ -0,0 +1,75 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// defaultHomeToleranceDegs is how far a joint may settle from its center and still count as home
+const defaultHomeToleranceDegs = 2.0
+
+// home handles the home DoCommand. Every joint moves to the center of its calibrated range
+// (0°, or the nearest joint limit), then the positions are read back and compared.
+func (s *so101) home(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	servoIDs := s.servoIDs()
+	tolerance := defaultHomeToleranceDegs
+	if v, ok := cmd["tolerance_degs"].(float64); ok {
+		if v <= 0 {
+			return nil, fmt.Errorf("tolerance_degs must be positive, got %.1f", v)
+		}
+		tolerance = v
+	}
+	defaults := s.defaultMotionParams()
+	if v, ok := cmd["speed_degs_per_sec"].(float64); ok {
+		if v <= 0 {
+			return nil, fmt.Errorf("speed_degs_per_sec must be positive, got %.1f", v)
+		}
+		defaults.SpeedDegsPerSec = v
+	}
+	params, err := resolveMotionParams(defaults, nil, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	jointLimits := s.calculateJointLimits()
+	target := make([]float64, len(servoIDs))
+	for i := range target {
+		target[i] = math.Max(jointLimits[i][0], math.Min(jointLimits[i][1], 0))
+	}
+	if err := s.moveWithParams(ctx, target, params, "home"); err != nil {
+		return nil, fmt.Errorf("failed to move home: %w", err)
+	}
+
+	actual, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read back joint positions: %w", err)
+	}
+	return homeReport(servoIDs, target, actual, tolerance), nil
+}
+
+// homeReport compares read-back positions with the home target, all in radians
+func homeReport(servoIDs []int, target, actual []float64, toleranceDegs float64) map[string]interface{} {
+	joints := map[string]interface{}{}
+	failed := []string{}
+	for i, id := range servoIDs {
+		errDegs := RadiansToDegrees(actual[i] - target[i])
+		ok := math.Abs(errDegs) <= toleranceDegs
+		name := jointNameForServo(id)
+		joints[name] = map[string]interface{}{
+			"target_degs": RadiansToDegrees(target[i]),
+			"actual_degs": RadiansToDegrees(actual[i]),
+			"error_degs":  errDegs,
+			"ok":          ok,
+		}
+		if !ok {
+			failed = append(failed, name)
+		}
+	}
+	return map[string]interface{}{
+		"success":        len(failed) == 0,
+		"tolerance_degs": toleranceDegs,
+		"joints":         joints,
+		"failed_joints":  failed,
+	}
+}
@@ -0,0 +1,102 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/button"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+var (
+	SO101HomeButtonModel = resource.NewModel("devrel", "so101", "home-button")
+)
+
+func init() {
+	resource.RegisterComponent(button.API, SO101HomeButtonModel,
+		resource.Registration[button.Button, *SO101ControlConfig]{
+			Constructor: newSO101HomeButton,
+		},
+	)
+}
+
+// so101HomeButton moves the arm to its home pose from the app's button card. The move goes
+// through the arm component, so it waits for the arm's other moves and gets its joint limits
+// and motion checks.
+type so101HomeButton struct {
+	resource.AlwaysRebuild
+
+	name        resource.Name
+	logger      logging.Logger
+	arm         arm.Arm
+	controller  *SafeSoArmController
+	port        string
+	homeDegrees []float64
+	speed       float64
+}
+
+func newSO101HomeButton(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (button.Button, error) {
+	cfg, err := resource.NativeConfig[*SO101ControlConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Arm == "" {
+		return nil, fmt.Errorf("home button requires 'arm', the arm component to move home")
+	}
+	homeArm, err := arm.FromProvider(deps, cfg.Arm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get arm %q: %w", cfg.Arm, err)
+	}
+	controller, err := openControlController(cfg, conf.ResourceName().ShortName(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &so101HomeButton{
+		name:        conf.ResourceName(),
+		logger:      logger,
+		arm:         homeArm,
+		controller:  controller,
+		port:        cfg.Port,
+		homeDegrees: cfg.HomeDegrees,
+		speed:       cfg.SpeedDegsPerSec,
+	}
+	if b.homeDegrees == nil {
+		b.homeDegrees = make([]float64, 5)
+	}
+	if b.speed == 0 {
+		b.speed = defaultHomeSpeedDegsPerSec
+	}
+	return b, nil
+}
+
+func (b *so101HomeButton) Name() resource.Name {
+	return b.name
+}
+
+// Push moves the arm to home_degrees and returns once it arrives
+func (b *so101HomeButton) Push(ctx context.Context, extra map[string]interface{}) error {
+	radians := make([]referenceframe.Input, len(b.homeDegrees))
+	for i, degrees := range b.homeDegrees {
+		radians[i] = DegreesToRadians(degrees)
+	}
+	b.logger.Debugf("Moving arm home to %v degrees at %.0f deg/s", b.homeDegrees, b.speed)
+	options := &arm.MoveOptions{MaxVelRads: DegreesToRadians(b.speed)}
+	if err := b.arm.MoveThroughJointPositions(ctx, [][]referenceframe.Input{radians}, options, nil); err != nil {
+		return fmt.Errorf("failed to move arm home: %w", err)
+	}
+	return nil
+}
+
+func (b *so101HomeButton) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("unknown command: %v", cmd["command"])
+}
+
+func (b *so101HomeButton) Close(ctx context.Context) error {
+	UnregisterSharedConsumer(b.port, b.name.ShortName())
+	ReleaseSharedControllerForPort(b.port, b.controller)
+	return nil
+}
@@ -0,0 +1,23 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHomeReport(t *testing.T) {
+	target := []float64{0, 0}
+	actual := []float64{DegreesToRadians(1), DegreesToRadians(-3)}
+
+	report := homeReport([]int{1, 2}, target, actual, 2)
+	assert.Equal(t, false, report["success"])
+	assert.Equal(t, []string{jointNameForServo(2)}, report["failed_joints"])
+
+	joint := report["joints"].(map[string]interface{})[jointNameForServo(1)].(map[string]interface{})
+	assert.InDelta(t, 1, joint["error_degs"], 1e-9)
+	assert.Equal(t, true, joint["ok"])
+
+	report = homeReport([]int{1, 2}, target, actual, 5)
+	assert.Equal(t, true, report["success"])
+}
@@ -0,0 +1,173 @@
+package so_arm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.bug.st/serial/enumerator"
+)
+
+// identityFileName holds the identities of every arm this machine has seen
+const identityFileName = "so101_identities.json"
+
+// identityFileMu serializes read-modify-write of the identity file between arms
+var identityFileMu sync.Mutex
+
+// ArmIdentity is a user-assigned nameplate that follows a physical arm across port changes
+type ArmIdentity struct {
+	Name         string    `json:"name,omitempty"`
+	Serial       string    `json:"serial,omitempty"`
+	AssemblyDate string    `json:"assembly_date,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// identityKey identifies the physical arm on a port. The USB adapter's serial number moves
+// with the arm when ports shuffle, the port name is only a fallback for adapters without one.
+func identityKey(port string) string {
+	if serial := usbSerialForPort(port); serial != "" {
+		return "usb:" + serial
+	}
+	return "port:" + extractPortSuffix(port)
+}
+
+// usbSerialForPort returns the USB serial number of the adapter on port, empty if unknown
+func usbSerialForPort(port string) string {
+	ports, err := enumerator.GetDetailedPortsList()
+	if err != nil {
+		return ""
+	}
+	for _, p := range ports {
+		if p.Name == port && p.IsUSB {
+			return p.SerialNumber
+		}
+	}
+	return ""
+}
+
+func identityFilePath() string {
+	return filepath.Join(moduleDataDir(), identityFileName)
+}
+
+func loadIdentities(path string) (map[string]ArmIdentity, error) {
+	identities := map[string]ArmIdentity{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return identities, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity file: %w", err)
+	}
+	if err := json.Unmarshal(data, &identities); err != nil {
+		return nil, fmt.Errorf("failed to parse identity file: %w", err)
+	}
+	return identities, nil
+}
+
+// loadIdentity returns the stored identity for key, ok is false if none was set
+func loadIdentity(path, key string) (ArmIdentity, bool, error) {
+	identityFileMu.Lock()
+	defer identityFileMu.Unlock()
+
+	identities, err := loadIdentities(path)
+	if err != nil {
+		return ArmIdentity{}, false, err
+	}
+	identity, ok := identities[key]
+	return identity, ok, nil
+}
+
+// saveIdentity stores the identity for key, leaving other arms' identities untouched
+func saveIdentity(path, key string, identity ArmIdentity) error {
+	identityFileMu.Lock()
+	defer identityFileMu.Unlock()
+
+	identities, err := loadIdentities(path)
+	if err != nil {
+		return err
+	}
+	identity.UpdatedAt = time.Now().UTC()
+	identities[key] = identity
+
+	data, err := json.MarshalIndent(identities, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal identities: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write identity file: %w", err)
+	}
+	return nil
+}
+
+// identityResponse formats an identity for DoCommand responses
+func identityResponse(key string, identity ArmIdentity, ok bool) map[string]interface{} {
+	result := map[string]interface{}{
+		"key":          key,
+		"has_identity": ok,
+	}
+	if ok {
+		result["name"] = identity.Name
+		result["serial"] = identity.Serial
+		result["assembly_date"] = identity.AssemblyDate
+		result["updated_at"] = identity.UpdatedAt.Format(time.RFC3339)
+	}
+	return result
+}
+
+// getIdentity handles the get_identity DoCommand
+func (s *so101) getIdentity() (map[string]interface{}, error) {
+	key := identityKey(s.config().Port)
+	identity, ok, err := loadIdentity(identityFilePath(), key)
+	if err != nil {
+		return nil, err
+	}
+	return identityResponse(key, identity, ok), nil
+}
+
+// setIdentity handles the set_identity DoCommand. Fields that aren't given keep their stored values.
+func (s *so101) setIdentity(cmd map[string]interface{}) (map[string]interface{}, error) {
+	key := identityKey(s.config().Port)
+	path := identityFilePath()
+	identity, _, err := loadIdentity(path, key)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]*string{
+		"name":          &identity.Name,
+		"serial":        &identity.Serial,
+		"assembly_date": &identity.AssemblyDate,
+	}
+	changed := false
+	for field, dest := range fields {
+		if v, ok := cmd[field]; ok {
+			value, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a string", field)
+			}
+			*dest = value
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, fmt.Errorf("set_identity requires at least one of 'name', 'serial' or 'assembly_date'")
+	}
+	if identity.AssemblyDate != "" {
+		if _, err := time.Parse("2006-01-02", identity.AssemblyDate); err != nil {
+			return nil, fmt.Errorf("assembly_date must be YYYY-MM-DD, got %q", identity.AssemblyDate)
+		}
+	}
+
+	if err := saveIdentity(path, key, identity); err != nil {
+		return nil, err
+	}
+	identity, ok, err := loadIdentity(path, key)
+	if err != nil {
+		return nil, err
+	}
+	return identityResponse(key, identity, ok), nil
+}
@@ -0,0 +1,36 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), identityFileName)
+
+	_, ok, err := loadIdentity(path, "usb:ABC")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, saveIdentity(path, "usb:ABC", ArmIdentity{Name: "left", Serial: "SO-0001"}))
+	assert.NoError(t, saveIdentity(path, "usb:DEF", ArmIdentity{Name: "right"}))
+
+	identity, ok, err := loadIdentity(path, "usb:ABC")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "left", identity.Name)
+	assert.Equal(t, "SO-0001", identity.Serial)
+	assert.False(t, identity.UpdatedAt.IsZero())
+
+	identity, ok, err = loadIdentity(path, "usb:DEF")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "right", identity.Name)
+
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+	_, _, err = loadIdentity(path, "usb:ABC")
+	assert.Error(t, err)
+}
@@ -0,0 +1,284 @@
+package so_arm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// Local IK solver settings
+const (
+	ikMaxIterations    = 200
+	ikPositionTolMm    = 1.0
+	ikDamping          = 0.5   // Damping for the least-squares step, keeps steps sane near singularities
+	ikOrientationScale = 100.0 // mm of position error that one radian of orientation error is worth
+	ikJacobianStep     = 1e-4  // radians
+	ikMaxStepRadians   = 0.2
+)
+
+// MoveToPosition solvers
+const (
+	ikSolverMotion = "motion"
+	ikSolverLocal  = "local"
+)
+
+// Elbow preferences, by the sign of the elbow_flex joint angle
+const (
+	ikElbowPositive = "positive"
+	ikElbowNegative = "negative"
+)
+
+// ikOptions controls how the local IK solver resolves a Cartesian goal
+type ikOptions struct {
+	// Starting joint positions in radians, usually the current joint positions
+	Seed []float64
+	// Maximum orientation error in degrees, zero solves for position only
+	OrientationToleranceDegs float64
+	// Preferred sign of the elbow_flex angle, "positive", "negative" or empty for no preference
+	Elbow string
+	// Index of the elbow joint in the model inputs
+	ElbowIndex int
+}
+
+// solveIK finds joint positions that reach the goal pose using damped least squares.
+// Several seeds derived from opts.Seed are tried in a fixed order, so the same goal and
+// seed always produce the same solution. Among solutions that meet the elbow preference,
+// the one closest to the seed is returned.
+func solveIK(model referenceframe.Model, limits [][2]float64, goal spatialmath.Pose, opts ikOptions) ([]float64, error) {
+	if len(opts.Seed) != len(limits) {
+		return nil, fmt.Errorf("IK seed has %d joints, expected %d", len(opts.Seed), len(limits))
+	}
+
+	var best, fallback []float64
+	bestDist, fallbackDist := math.Inf(1), math.Inf(1)
+	for _, seed := range ikSeeds(opts, limits) {
+		solution, ok := solveIKFromSeed(model, limits, goal, seed, opts.OrientationToleranceDegs)
+		if !ok {
+			continue
+		}
+
+		dist := jointDistance(solution, opts.Seed)
+		if elbowMatches(solution, opts) {
+			if dist < bestDist {
+				best, bestDist = solution, dist
+			}
+		} else if dist < fallbackDist {
+			fallback, fallbackDist = solution, dist
+		}
+	}
+
+	if best != nil {
+		return best, nil
+	}
+	if fallback != nil {
+		if opts.Elbow != "" {
+			return fallback, fmt.Errorf("no IK solution with a %s elbow, closest solution has the opposite elbow", opts.Elbow)
+		}
+		return fallback, nil
+	}
+	return nil, fmt.Errorf("no IK solution found within %.1fmm", ikPositionTolMm)
+}
+
+// ikSeeds returns the seeds to try, starting with the caller's seed
+func ikSeeds(opts ikOptions, limits [][2]float64) [][]float64 {
+	seeds := [][]float64{append([]float64(nil), opts.Seed...)}
+
+	if opts.ElbowIndex >= 0 && opts.ElbowIndex < len(opts.Seed) {
+		mirrored := append([]float64(nil), opts.Seed...)
+		mirrored[opts.ElbowIndex] = -mirrored[opts.ElbowIndex]
+		seeds = append(seeds, mirrored)
+
+		// Bent elbow seeds help when the seed is near a straight-arm singularity
+		for _, elbow := range []float64{math.Pi / 2, -math.Pi / 2} {
+			bent := append([]float64(nil), opts.Seed...)
+			bent[opts.ElbowIndex] = elbow
+			seeds = append(seeds, bent)
+		}
+	}
+
+	seeds = append(seeds, make([]float64, len(limits)))
+
+	for _, seed := range seeds {
+		clampToLimits(seed, limits)
+	}
+	return seeds
+}
+
+// solveIKFromSeed runs damped least squares from a single seed
+func solveIKFromSeed(model referenceframe.Model, limits [][2]float64, goal spatialmath.Pose, seed []float64, orientationTolDegs float64) ([]float64, bool) {
+	useOrientation := orientationTolDegs > 0
+	q := append([]float64(nil), seed...)
+
+	for iter := 0; iter < ikMaxIterations; iter++ {
+		residual, err := ikResidual(model, q, goal, useOrientation)
+		if err != nil {
+			return nil, false
+		}
+		if ikConverged(residual, orientationTolDegs) {
+			return q, true
+		}
+
+		jacobian, err := ikJacobian(model, q, goal, useOrientation, residual)
+		if err != nil {
+			return nil, false
+		}
+
+		step, err := dampedLeastSquaresStep(jacobian, residual)
+		if err != nil {
+			return nil, false
+		}
+		for i := range q {
+			q[i] -= math.Max(-ikMaxStepRadians, math.Min(ikMaxStepRadians, step[i]))
+		}
+		clampToLimits(q, limits)
+	}
+
+	residual, err := ikResidual(model, q, goal, useOrientation)
+	if err != nil || !ikConverged(residual, orientationTolDegs) {
+		return nil, false
+	}
+	return q, true
+}
+
+// ikResidual is the error between the pose at q and the goal: position in mm followed by
+// the scaled orientation error if orientation is being solved for
+func ikResidual(model referenceframe.Model, q []float64, goal spatialmath.Pose, useOrientation bool) ([]float64, error) {
+	pose, err := referenceframe.ComputeOOBPosition(model, q)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := pose.Point().Sub(goal.Point())
+	residual := []float64{delta.X, delta.Y, delta.Z}
+	if useOrientation {
+		rot := orientationError(pose.Orientation(), goal.Orientation())
+		residual = append(residual, rot.X*ikOrientationScale, rot.Y*ikOrientationScale, rot.Z*ikOrientationScale)
+	}
+	return residual, nil
+}
+
+// orientationError returns the rotation from the goal to the current orientation as an axis-angle vector
+func orientationError(current, goal spatialmath.Orientation) r3.Vector {
+	return spatialmath.QuatToR3AA(spatialmath.OrientationBetween(goal, current).Quaternion())
+}
+
+func ikConverged(residual []float64, orientationTolDegs float64) bool {
+	if math.Sqrt(residual[0]*residual[0]+residual[1]*residual[1]+residual[2]*residual[2]) > ikPositionTolMm {
+		return false
+	}
+	if len(residual) == 6 {
+		rot := math.Sqrt(residual[3]*residual[3]+residual[4]*residual[4]+residual[5]*residual[5]) / ikOrientationScale
+		return RadiansToDegrees(rot) <= orientationTolDegs
+	}
+	return true
+}
+
+// ikJacobian computes the residual's Jacobian by forward differences
+func ikJacobian(model referenceframe.Model, q []float64, goal spatialmath.Pose, useOrientation bool, residual []float64) ([][]float64, error) {
+	jacobian := make([][]float64, len(residual))
+	for r := range jacobian {
+		jacobian[r] = make([]float64, len(q))
+	}
+
+	probe := append([]float64(nil), q...)
+	for c := range q {
+		probe[c] = q[c] + ikJacobianStep
+		shifted, err := ikResidual(model, probe, goal, useOrientation)
+		if err != nil {
+			return nil, err
+		}
+		probe[c] = q[c]
+
+		for r := range residual {
+			jacobian[r][c] = (shifted[r] - residual[r]) / ikJacobianStep
+		}
+	}
+	return jacobian, nil
+}
+
+// dampedLeastSquaresStep solves (JᵀJ + λ²I) step = Jᵀr
+func dampedLeastSquaresStep(jacobian [][]float64, residual []float64) ([]float64, error) {
+	n := len(jacobian[0])
+	a := make([][]float64, n)
+	b := make([]float64, n)
+	for i := 0; i < n; i++ {
+		a[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			for r := range jacobian {
+				a[i][j] += jacobian[r][i] * jacobian[r][j]
+			}
+		}
+		a[i][i] += ikDamping * ikDamping
+		for r := range jacobian {
+			b[i] += jacobian[r][i] * residual[r]
+		}
+	}
+	return solveLinear(a, b)
+}
+
+// solveLinear solves a small dense system with Gaussian elimination and partial pivoting
+func solveLinear(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, fmt.Errorf("singular matrix")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, nil
+}
+
+func clampToLimits(q []float64, limits [][2]float64) {
+	for i := range q {
+		q[i] = math.Max(limits[i][0], math.Min(limits[i][1], q[i]))
+	}
+}
+
+func jointDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+func elbowMatches(q []float64, opts ikOptions) bool {
+	if opts.ElbowIndex < 0 || opts.ElbowIndex >= len(q) {
+		return true
+	}
+	switch opts.Elbow {
+	case ikElbowPositive:
+		return q[opts.ElbowIndex] >= 0
+	case ikElbowNegative:
+		return q[opts.ElbowIndex] <= 0
+	default:
+		return true
+	}
+}
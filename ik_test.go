@@ -0,0 +1,59 @@
+package so_arm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestSolveIK(t *testing.T) {
+	model, err := makeSO101ModelFrame()
+	assert.NoError(t, err)
+
+	limits := make([][2]float64, len(model.DoF()))
+	for i := range limits {
+		limits[i] = [2]float64{-math.Pi / 2, math.Pi / 2}
+	}
+
+	target := []float64{0.3, -0.4, 0.6, 0.2, 0}
+	goal, err := referenceframe.ComputeOOBPosition(model, target)
+	assert.NoError(t, err)
+
+	t.Run("position only", func(t *testing.T) {
+		solution, err := solveIK(model, limits, goal, ikOptions{Seed: make([]float64, 5), ElbowIndex: 2})
+		assert.NoError(t, err)
+
+		pose, err := referenceframe.ComputeOOBPosition(model, solution)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0, pose.Point().Distance(goal.Point()), ikPositionTolMm)
+	})
+
+	t.Run("deterministic for the same seed", func(t *testing.T) {
+		opts := ikOptions{Seed: []float64{0.1, 0.1, 0.1, 0.1, 0.1}, ElbowIndex: 2}
+		first, err := solveIK(model, limits, goal, opts)
+		assert.NoError(t, err)
+		second, err := solveIK(model, limits, goal, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("orientation tolerance", func(t *testing.T) {
+		solution, err := solveIK(model, limits, goal, ikOptions{Seed: make([]float64, 5), ElbowIndex: 2, OrientationToleranceDegs: 2})
+		assert.NoError(t, err)
+
+		pose, err := referenceframe.ComputeOOBPosition(model, solution)
+		assert.NoError(t, err)
+		rot := orientationError(pose.Orientation(), goal.Orientation())
+		assert.LessOrEqual(t, RadiansToDegrees(rot.Norm()), 2.0)
+	})
+
+	t.Run("unreachable goal", func(t *testing.T) {
+		far := spatialmath.NewPoseFromPoint(r3.Vector{X: 5000})
+		_, err := solveIK(model, limits, far, ikOptions{Seed: make([]float64, 5), ElbowIndex: 2})
+		assert.Error(t, err)
+	})
+}
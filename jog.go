@@ -0,0 +1,103 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// Cartesian jog limits
+const (
+	jogMaxDistanceMm      = 50.0
+	jogDefaultSpeedMmPerS = 20.0
+	jogMaxSpeedMmPerS     = 100.0
+)
+
+// jogCartesian nudges the end effector along a direction in the arm's base frame. The step
+// is solved with the local IK solver from the current joint positions, and joint speed is
+// chosen so the move takes about distance/speed seconds.
+func (s *so101) jogCartesian(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	direction, err := floatList(cmd["direction"], "direction")
+	if err != nil {
+		return nil, err
+	}
+	if len(direction) != 3 {
+		return nil, fmt.Errorf("direction must have 3 components [x, y, z], got %d", len(direction))
+	}
+	dir := r3.Vector{X: direction[0], Y: direction[1], Z: direction[2]}
+	if dir.Norm() == 0 {
+		return nil, fmt.Errorf("direction must not be zero")
+	}
+
+	distance, ok := cmd["distance_mm"].(float64)
+	if !ok || distance <= 0 || distance > jogMaxDistanceMm {
+		return nil, fmt.Errorf("jog_cartesian requires 'distance_mm' between 0 and %.0f", jogMaxDistanceMm)
+	}
+
+	speed := jogDefaultSpeedMmPerS
+	if v, ok := cmd["speed_mm_per_sec"].(float64); ok {
+		if v <= 0 || v > jogMaxSpeedMmPerS {
+			return nil, fmt.Errorf("speed_mm_per_sec must be between 0 and %.0f, got %.1f", jogMaxSpeedMmPerS, v)
+		}
+		speed = v
+	}
+
+	// Refused before reading the pose or solving, like any other move
+	if err := s.checkMotionAllowed(); err != nil {
+		return nil, err
+	}
+	if err := s.controller.checkMotion(); err != nil {
+		return nil, err
+	}
+
+	current, err := s.EndPosition(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read current pose: %w", err)
+	}
+	goal := spatialmath.NewPose(current.Point().Add(dir.Normalize().Mul(distance)), current.Orientation())
+
+	start, err := s.controller.GetJointPositionsForServos(ctx, s.servoIDs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read joint positions: %w", err)
+	}
+	joints, err := s.solveLocalIK(ctx, goal, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	params := s.defaultMotionParams()
+	maxDelta := 0.0
+	for i := range joints {
+		maxDelta = math.Max(maxDelta, math.Abs(joints[i]-start[i]))
+	}
+	params.SpeedDegsPerSec = clampFloat(RadiansToDegrees(maxDelta)*speed/distance, minSpeedDegsPerSec, params.SpeedDegsPerSec)
+
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	// A limp arm is stiffened the same way set_torque does it
+	if !s.controller.TorqueEnabled() {
+		if err := s.enableTorque(ctx); err != nil {
+			return nil, fmt.Errorf("failed to enable torque for jog: %w", err)
+		}
+	}
+
+	s.isMoving.Store(true)
+	defer s.isMoving.Store(false)
+
+	if moveStart, err := s.moveToJointPositions(ctx, joints, params); err != nil {
+		if ctx.Err() != nil {
+			s.recoverFromCancel(moveStart)
+		}
+		return nil, err
+	}
+
+	point := goal.Point()
+	return map[string]interface{}{
+		"success": true,
+		"target":  map[string]interface{}{"x": point.X, "y": point.Y, "z": point.Z},
+	}, nil
+}
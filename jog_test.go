@@ -0,0 +1,53 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestJogRefusedWhileLatched(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, fake := newFakeController(t, ids...)
+	controller.estop = &emergencyStop{}
+	controller.maintenance = &maintenanceMode{}
+	s := &so101{
+		cfg:         &SO101ArmConfig{},
+		controller:  controller,
+		maintenance: controller.maintenance,
+		armServoIDs: ids,
+		cancelCtx:   context.Background(),
+		logger:      logger,
+		logs:        newRateLimitedLogger(logger, logRateLimitInterval(false)),
+	}
+	ctx := context.Background()
+	jog := map[string]interface{}{
+		"command":     "jog_cartesian",
+		"direction":   []interface{}{0.0, 0.0, 1.0},
+		"distance_mm": 10.0,
+	}
+
+	controller.maintenance.set(true, "replacing the wrist servo")
+	_, err := s.jogCartesian(ctx, jog)
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	result, err := s.doCommand(ctx, map[string]interface{}{"command": "set_torque", "enable": true})
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	assert.Equal(t, false, result["success"])
+	controller.maintenance.set(false, "")
+
+	controller.estop.latch("test")
+	_, err = s.jogCartesian(ctx, jog)
+	assert.ErrorIs(t, err, ErrEmergencyStop)
+
+	// Nothing reached the servos, not even the lowered limits of a torque ramp
+	assert.False(t, controller.TorqueEnabled())
+	for _, id := range ids {
+		assert.Empty(t, fake.writesTo(id, feetech.RegTorqueEnable))
+		assert.Empty(t, fake.writesTo(id, feetech.RegTorqueLimit))
+		assert.Empty(t, fake.writesTo(id, feetech.RegGoalPosition))
+	}
+}
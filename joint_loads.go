@@ -0,0 +1,39 @@
+package so_arm
+
+import (
+	"context"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// present_load holds the magnitude in 0.1% of stall torque in the low 10 bits and the
+// direction in bit 10
+const (
+	loadMagnitudeMask = 0x3FF
+	loadDirectionBit  = 1 << 10
+)
+
+// decodeServoLoad converts a present_load register value to a signed percentage of stall torque
+func decodeServoLoad(data []byte, proto *feetech.Protocol) float64 {
+	raw := int(proto.DecodeWord(data))
+	load := float64(raw&loadMagnitudeMask) / 10
+	if raw&loadDirectionBit != 0 {
+		load = -load
+	}
+	return load
+}
+
+// jointLoads handles the get_joint_loads DoCommand
+func (s *so101) jointLoads(ctx context.Context) (map[string]interface{}, error) {
+	servoIDs := s.servoIDs()
+	loads, err := s.controller.GetServoLoads(ctx, servoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	joints := map[string]interface{}{}
+	for _, id := range servoIDs {
+		joints[jointNameForServo(id)] = loads[id]
+	}
+	return map[string]interface{}{"loads_percent": joints}, nil
+}
@@ -0,0 +1,18 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeServoLoad(t *testing.T) {
+	proto := feetech.NewProtocol(feetech.ProtocolSTS)
+	assert.Equal(t, 0.0, decodeServoLoad([]byte{0, 0}, proto))
+	assert.Equal(t, 25.0, decodeServoLoad([]byte{250, 0}, proto))
+	// 1000 (100%) with the direction bit set
+	assert.Equal(t, -100.0, decodeServoLoad([]byte{0xE8, 0x07}, proto))
+	assert.Equal(t, -0.5, decodeServoLoad([]byte{5, 0x04}, proto))
+	assert.Equal(t, -100.0, decodeServoLoad([]byte{0x07, 0xE8}, scsProtocol))
+}
@@ -0,0 +1,107 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// jointReadCache keeps the last good position of each joint for degraded reads, along with
+// which joints are currently being served from it
+type jointReadCache struct {
+	mu        sync.Mutex
+	positions map[int]float64
+	readAt    map[int]time.Time
+	lastErr   map[int]string
+}
+
+func newJointReadCache() *jointReadCache {
+	return &jointReadCache{
+		positions: map[int]float64{},
+		readAt:    map[int]time.Time{},
+		lastErr:   map[int]string{},
+	}
+}
+
+// update stores fresh positions and returns the cached ones for failed servos. It errors if
+// a failed servo has never been read successfully.
+func (c *jointReadCache) update(servoIDs []int, positions map[int]float64, failed map[int]error) ([]float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	result := make([]float64, len(servoIDs))
+	for i, id := range servoIDs {
+		if pos, ok := positions[id]; ok {
+			c.positions[id] = pos
+			c.readAt[id] = now
+			delete(c.lastErr, id)
+			result[i] = pos
+			continue
+		}
+		pos, ok := c.positions[id]
+		if !ok {
+			return nil, fmt.Errorf("%s has no last known position: %w", jointNameForServo(id), failed[id])
+		}
+		c.lastErr[id] = failed[id].Error()
+		result[i] = pos
+	}
+	return result, nil
+}
+
+// reset forgets every cached position, for when the arm's servos change
+func (c *jointReadCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.positions = map[int]float64{}
+	c.readAt = map[int]time.Time{}
+	c.lastErr = map[int]string{}
+}
+
+// staleness reports each joint's age and whether its last read failed
+func (c *jointReadCache) staleness() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	joints := map[string]interface{}{}
+	for id, readAt := range c.readAt {
+		joint := map[string]interface{}{
+			"stale":  c.lastErr[id] != "",
+			"age_ms": time.Since(readAt).Milliseconds(),
+		}
+		if msg := c.lastErr[id]; msg != "" {
+			joint["error"] = msg
+		}
+		joints[jointNameForServo(id)] = joint
+	}
+	return joints
+}
+
+// staleJoints lists joints currently served from the cache
+func (c *jointReadCache) staleJoints() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stale := []string{}
+	for id := range c.lastErr {
+		stale = append(stale, jointNameForServo(id))
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// readJointPositionsDegraded reads the arm joints, substituting the last good value for any
+// servo that doesn't answer. The caller must hold mu.
+func (s *so101) readJointPositionsDegraded(ctx context.Context) ([]float64, error) {
+	positions, failed := s.controller.ReadJointPositionsPartial(ctx, s.armServoIDs)
+	for id, err := range failed {
+		s.logs.Warnf(fmt.Sprintf("degraded-read-%d", id), "Servo %d position read failed, using last known position: %v", id, err)
+	}
+	return s.jointCache.update(s.armServoIDs, positions, failed)
+}
@@ -0,0 +1,51 @@
+package so_arm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJointReadCache(t *testing.T) {
+	cache := newJointReadCache()
+	ids := []int{1, 2}
+	readErr := errors.New("no response")
+
+	// A joint that has never been read can't be served
+	_, err := cache.update(ids, map[int]float64{1: 0.1}, map[int]error{2: readErr})
+	assert.Error(t, err)
+
+	positions, err := cache.update(ids, map[int]float64{1: 0.1, 2: 0.2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.1, 0.2}, positions)
+	assert.Empty(t, cache.staleJoints())
+
+	positions, err = cache.update(ids, map[int]float64{1: 0.3}, map[int]error{2: readErr})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.3, 0.2}, positions)
+	assert.Equal(t, []string{jointNameForServo(2)}, cache.staleJoints())
+
+	joint := cache.staleness()[jointNameForServo(2)].(map[string]interface{})
+	assert.Equal(t, true, joint["stale"])
+	assert.Equal(t, "no response", joint["error"])
+
+	// A good read clears the staleness
+	_, err = cache.update(ids, map[int]float64{1: 0.3, 2: 0.4}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, cache.staleJoints())
+
+	var nilCache *jointReadCache
+	assert.Nil(t, nilCache.staleJoints())
+}
+
+func TestJointReadCacheReset(t *testing.T) {
+	cache := newJointReadCache()
+	_, err := cache.update([]int{1}, map[int]float64{1: 0.1}, nil)
+	assert.NoError(t, err)
+
+	cache.reset()
+	assert.Empty(t, cache.staleness())
+	_, err = cache.update([]int{1}, nil, map[int]error{1: errors.New("no response")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Speeds above these are accepted by the servos but make the SO-101 whip and overshoot
+const (
+	lintMaxSafeSpeedDegsPerSec     = 120
+	lintMaxSafeAccDegsPerSecPerSec = 300
+)
+
+// lintIssue is a single finding reported by lint_config
+type lintIssue struct {
+	Severity string
+	Check    string
+	Message  string
+}
+
+func (i lintIssue) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"severity": i.Severity,
+		"check":    i.Check,
+		"message":  i.Message,
+	}
+}
+
+// lintSharedConfig checks the settings that must agree between every component on a port
+func lintSharedConfig(ctx context.Context, controller *SafeSoArmController, port string, baudrate int, self ConsumerInfo) []lintIssue {
+	issues := []lintIssue{}
+
+	for _, other := range GetSharedConsumers(port) {
+		if other.Name == self.Name {
+			continue
+		}
+
+		if other.CalibrationFile != self.CalibrationFile {
+			issues = append(issues, lintIssue{
+				Severity: "error",
+				Check:    "calibration_file_mismatch",
+				Message: fmt.Sprintf("%s uses calibration file %q but %s uses %q on the same port %s",
+					self.Name, self.CalibrationFile, other.Name, other.CalibrationFile, port),
+			})
+		}
+
+		for _, id := range self.ServoIDs {
+			for _, otherID := range other.ServoIDs {
+				if id == otherID {
+					issues = append(issues, lintIssue{
+						Severity: "error",
+						Check:    "servo_id_overlap",
+						Message:  fmt.Sprintf("servo %d is controlled by both %s and %s", id, self.Name, other.Name),
+					})
+				}
+			}
+		}
+	}
+
+	// A servo only answers at the baudrate it is set to, so the bus can't read a mismatched
+	// rate back. Finding the servo's actual rate means reopening the port, which is left to the doctor.
+	for _, id := range self.ServoIDs {
+		if _, err := controller.ReadServoRegisterAt(ctx, id, feetech.RegModelNumber); err != nil {
+			issues = append(issues, lintIssue{
+				Severity: "warning",
+				Check:    "servo_unresponsive",
+				Message: fmt.Sprintf("servo %d did not respond at %d baud, check its wiring or run `soarm doctor` to find its baudrate: %v",
+					id, baudrate, err),
+			})
+		}
+	}
+
+	return issues
+}
+
+// lintSpeed flags motion parameters outside the safe envelope for the SO-101
+func lintSpeed(name string, speedDegsPerSec, accDegsPerSecPerSec float64) []lintIssue {
+	issues := []lintIssue{}
+	if speedDegsPerSec > lintMaxSafeSpeedDegsPerSec {
+		issues = append(issues, lintIssue{
+			Severity: "warning",
+			Check:    "unsafe_speed",
+			Message:  fmt.Sprintf("%s speed %.1f deg/s exceeds the safe limit of %d deg/s", name, speedDegsPerSec, lintMaxSafeSpeedDegsPerSec),
+		})
+	}
+	if accDegsPerSecPerSec > lintMaxSafeAccDegsPerSecPerSec {
+		issues = append(issues, lintIssue{
+			Severity: "warning",
+			Check:    "unsafe_acceleration",
+			Message: fmt.Sprintf("%s acceleration %.1f deg/s² exceeds the safe limit of %d deg/s²",
+				name, accDegsPerSecPerSec, lintMaxSafeAccDegsPerSecPerSec),
+		})
+	}
+	return issues
+}
+
+// lintResult builds the lint_config response, success is false only when errors were found
+func lintResult(issues []lintIssue) map[string]interface{} {
+	errorCount := 0
+	results := make([]interface{}, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			errorCount++
+		}
+		results = append(results, issue.toMap())
+	}
+	return map[string]interface{}{
+		"success":       errorCount == 0,
+		"issues":        results,
+		"error_count":   errorCount,
+		"warning_count": len(issues) - errorCount,
+	}
+}
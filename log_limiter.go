@@ -0,0 +1,148 @@
+package so_arm
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/logging"
+)
+
+// Repeated messages with the same key are logged at most once per interval, which keeps
+// teleop at 20+ Hz from flooding the machine logs with clamp and retry warnings
+const defaultLogRateLimitInterval = 10 * time.Second
+
+// logRateLimitInterval returns the rate limit interval for a component, verbose disables it
+func logRateLimitInterval(verbose bool) time.Duration {
+	if verbose {
+		return 0
+	}
+	return defaultLogRateLimitInterval
+}
+
+type rateLimitState struct {
+	lastLogged time.Time
+	suppressed int
+}
+
+// fallbackLogger is used by a nil rateLimitedLogger, so its messages are logged rather than dropped
+var fallbackLogger = sync.OnceValue(func() logging.Logger { return logging.NewLogger("so-arm") })
+
+// rateLimitedLogger logs each message key at most once per interval. Suppressed messages are
+// counted and the counts are summarized once per interval while anything is being suppressed,
+// with the next message for that key, or by Flush.
+type rateLimitedLogger struct {
+	logger   logging.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	states  map[string]*rateLimitState
+	summary *time.Timer
+
+	// Warnings that get logged are also recorded here when set
+	events *eventLog
+}
+
+// newRateLimitedLogger wraps logger, an interval of zero disables rate limiting
+func newRateLimitedLogger(logger logging.Logger, interval time.Duration) *rateLimitedLogger {
+	return &rateLimitedLogger{
+		logger:   logger,
+		interval: interval,
+		now:      time.Now,
+		states:   map[string]*rateLimitState{},
+	}
+}
+
+// setInterval changes the rate limit interval, for verbose_logging changes on reconfigure
+func (r *rateLimitedLogger) setInterval(interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interval = interval
+}
+
+// Warnf logs a warning unless the same key was logged within the interval
+func (r *rateLimitedLogger) Warnf(key, format string, args ...interface{}) {
+	if msg, ok := r.allow(key, format, args); ok {
+		r.plain().Warn(msg)
+		if r != nil {
+			r.events.add(eventWarning, "%s", msg)
+		}
+	}
+}
+
+// Infof logs an info message unless the same key was logged within the interval
+func (r *rateLimitedLogger) Infof(key, format string, args ...interface{}) {
+	if msg, ok := r.allow(key, format, args); ok {
+		r.plain().Info(msg)
+	}
+}
+
+// plain returns the logger messages are written to
+func (r *rateLimitedLogger) plain() logging.Logger {
+	if r == nil || r.logger == nil {
+		return fallbackLogger()
+	}
+	return r.logger
+}
+
+// Flush logs a summary for every key with suppressed messages and stops the summary timer
+func (r *rateLimitedLogger) Flush() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.summary != nil {
+		r.summary.Stop()
+		r.summary = nil
+	}
+
+	keys := make([]string, 0, len(r.states))
+	for key, state := range r.states {
+		if state.suppressed > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		r.plain().Infof("%d %q messages were suppressed", r.states[key].suppressed, key)
+		r.states[key].suppressed = 0
+	}
+}
+
+func (r *rateLimitedLogger) allow(key, format string, args []interface{}) (string, bool) {
+	msg := fmt.Sprintf(format, args...)
+	if r == nil {
+		return msg, true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.interval <= 0 {
+		return msg, true
+	}
+
+	now := r.now()
+	state, ok := r.states[key]
+	if !ok {
+		state = &rateLimitState{}
+		r.states[key] = state
+	} else if now.Sub(state.lastLogged) < r.interval {
+		state.suppressed++
+		// Keys that never get another message through are still summarized each interval
+		if r.summary == nil {
+			r.summary = time.AfterFunc(r.interval, r.Flush)
+		}
+		return "", false
+	}
+
+	if state.suppressed > 0 {
+		msg = fmt.Sprintf("%s (%d similar messages suppressed in the last %v)", msg, state.suppressed, now.Sub(state.lastLogged).Round(time.Second))
+	}
+	state.lastLogged = now
+	state.suppressed = 0
+	return msg, true
+}
@@ -0,0 +1,72 @@
+package so_arm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestRateLimitedLogger(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimitedLogger(logging.NewTestLogger(t), 10*time.Second)
+	limiter.now = func() time.Time { return now }
+
+	msg, ok := limiter.allow("clamp", "joint %d clamped", []interface{}{1})
+	assert.True(t, ok)
+	assert.Equal(t, "joint 1 clamped", msg)
+
+	// Repeats within the interval are suppressed, other keys are not
+	for i := 0; i < 5; i++ {
+		now = now.Add(time.Second)
+		_, ok = limiter.allow("clamp", "joint %d clamped", []interface{}{1})
+		assert.False(t, ok)
+	}
+	_, ok = limiter.allow("read", "read failed", nil)
+	assert.True(t, ok)
+
+	// The next message after the interval reports how many were dropped
+	now = now.Add(10 * time.Second)
+	msg, ok = limiter.allow("clamp", "joint %d clamped", []interface{}{2})
+	assert.True(t, ok)
+	assert.Equal(t, "joint 2 clamped (5 similar messages suppressed in the last 15s)", msg)
+
+	now = now.Add(time.Second)
+	_, ok = limiter.allow("clamp", "joint %d clamped", []interface{}{2})
+	assert.False(t, ok)
+	limiter.Flush()
+	assert.Equal(t, 0, limiter.states["clamp"].suppressed)
+}
+
+func TestRateLimitedLoggerDisabled(t *testing.T) {
+	limiter := newRateLimitedLogger(logging.NewTestLogger(t), 0)
+	for i := 0; i < 3; i++ {
+		_, ok := limiter.allow("clamp", "clamped", nil)
+		assert.True(t, ok)
+	}
+
+	// A nil limiter falls through to a plain logger instead of dropping messages
+	var nilLimiter *rateLimitedLogger
+	msg, ok := nilLimiter.allow("clamp", "clamped", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "clamped", msg)
+	nilLimiter.Warnf("clamp", "clamped")
+	nilLimiter.Flush()
+}
+
+func TestRateLimitedLoggerSummary(t *testing.T) {
+	logger, logs := logging.NewObservedTestLogger(t)
+	limiter := newRateLimitedLogger(logger, 20*time.Millisecond)
+	defer limiter.Flush()
+
+	limiter.Warnf("clamp", "clamped")
+	for i := 0; i < 3; i++ {
+		limiter.Warnf("clamp", "clamped")
+	}
+
+	// No further message for the key, the suppressed count is still reported once the interval passes
+	assert.Eventually(t, func() bool {
+		return logs.FilterMessage(`3 "clamp" messages were suppressed`).Len() == 1
+	}, time.Second, 5*time.Millisecond)
+}
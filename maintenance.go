@@ -0,0 +1,129 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenanceMode is returned for motion and torque commands while the arm is in
+// maintenance mode
+var ErrMaintenanceMode = errors.New("MAINTENANCE_MODE: the arm is being serviced, exit maintenance mode before moving it")
+
+// maintenanceMode is the latched flag set while tooling around the arm is being serviced. It's
+// shared by every component on a port, so the gripper and controls can't move the arm either.
+type maintenanceMode struct {
+	mu     sync.Mutex
+	active bool
+	reason string
+	since  time.Time
+}
+
+// check returns ErrMaintenanceMode while the arm is in maintenance mode
+func (m *maintenanceMode) check() error {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.active {
+		return ErrMaintenanceMode
+	}
+	return nil
+}
+
+func (m *maintenanceMode) set(active bool, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active = active
+	m.reason = reason
+	m.since = time.Now()
+}
+
+func (m *maintenanceMode) status() map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{"active": false}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := map[string]interface{}{"active": m.active}
+	if m.active {
+		result["reason"] = m.reason
+		result["since"] = m.since.Format(time.RFC3339)
+	}
+	return result
+}
+
+// checkMotion returns ErrEmergencyStop or ErrMaintenanceMode while either is latched on the port
+func (s *SafeSoArmController) checkMotion() error {
+	if err := s.estop.check(); err != nil {
+		return err
+	}
+	return s.maintenance.check()
+}
+
+// checkMotionAllowed refuses motion during maintenance, while a watchdog fault is latched, or
+// without a required calibration
+func (s *so101) checkMotionAllowed() error {
+	if err := s.maintenance.check(); err != nil {
+		return err
+	}
+	if err := s.watchdog.check(); err != nil {
+		return err
+	}
+	return s.checkCalibrationRequired()
+}
+
+// setMaintenanceMode handles the maintenance_mode DoCommand. Entering stops any motion, parks
+// the arm, and disables torque. Exiting only clears the flag, torque stays off until it's
+// enabled with set_torque.
+func (s *so101) setMaintenanceMode(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	enable, ok := cmd["enable"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("maintenance_mode requires 'enable' boolean parameter")
+	}
+	if !enable {
+		s.maintenance.set(false, "")
+		s.events.add(eventInfo, "maintenance mode exited")
+		result := s.maintenance.status()
+		result["success"] = true
+		return result, nil
+	}
+
+	reason, _ := cmd["reason"].(string)
+	if err := s.Stop(ctx, nil); err != nil {
+		s.logger.Warnf("Failed to stop arm when entering maintenance mode: %v", err)
+	}
+	if err := s.disableCompliance(ctx); err != nil {
+		s.logger.Warnf("Failed to disable compliance mode when entering maintenance mode: %v", err)
+	}
+
+	parked := false
+	if err := s.park(ctx); err != nil {
+		s.logger.Warnf("Failed to park arm for maintenance, disabling torque where it is: %v", err)
+	} else {
+		parked = true
+	}
+
+	// Latch before disabling torque so nothing can re-enable it in between
+	s.maintenance.set(true, reason)
+	s.events.add(eventInfo, "maintenance mode entered: %s", reason)
+
+	var torqueErr error
+	for _, id := range s.servoIDs() {
+		if err := s.controller.WriteServoRegister(ctx, id, "torque_enable", []byte{0}); err != nil && torqueErr == nil {
+			torqueErr = fmt.Errorf("failed to disable torque on servo %d: %w", id, err)
+		}
+	}
+
+	result := s.maintenance.status()
+	result["parked"] = parked
+	result["success"] = torqueErr == nil
+	if torqueErr != nil {
+		result["error"] = torqueErr.Error()
+	}
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceModeSharedAcrossViews(t *testing.T) {
+	ctx := context.Background()
+	controller, _ := newFakeController(t, 1, 2, 3, 4, 5, 6)
+	controller.estop = &emergencyStop{}
+	controller.maintenance = &maintenanceMode{}
+	config := testConfig("/dev/ttyFAKE0")
+	registry := NewControllerRegistry()
+	registry.entries[config.Port] = &ControllerEntry{controller: controller, config: config, refCount: 1}
+
+	arm, err := registry.GetController(config.Port, config, DefaultSO101FullCalibration, false)
+	assert.NoError(t, err)
+	gripper, err := registry.GetController(config.Port, config, DefaultSO101FullCalibration, false)
+	assert.NoError(t, err)
+
+	// The arm entering maintenance stops the gripper on the same port too
+	arm.maintenance.set(true, "replacing the wrist servo")
+	assert.ErrorIs(t, gripper.checkMotion(), ErrMaintenanceMode)
+	assert.ErrorIs(t, gripper.SetTorqueEnable(ctx, true), ErrMaintenanceMode)
+	assert.ErrorIs(t, gripper.WriteServoRegister(ctx, 6, "goal_position", []byte{0, 8}), ErrMaintenanceMode)
+	assert.NoError(t, gripper.SetTorqueEnable(ctx, false))
+
+	arm.maintenance.set(false, "")
+	assert.NoError(t, gripper.checkMotion())
+}
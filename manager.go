@@ -3,13 +3,13 @@ package so_arm
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math"
 	"sync"
 	"sync/atomic"
 
 	"github.com/hipsterbrown/feetech-servo/feetech"
 	"go.viam.com/rdk/logging"
-	"go.viam.com/rdk/utils"
 )
 
 // isGripperServo checks if a servo ID is the gripper (servo 6)
@@ -24,13 +24,29 @@ type SafeSoArmController struct {
 	group            *feetech.ServoGroup
 	calibratedServos map[int]*CalibratedServo
 	logger           logging.Logger
+	logs             *rateLimitedLogger
 	calibration      SO101FullCalibration
-	mu               sync.RWMutex
+	timing           *busTiming
+	estop            *emergencyStop
+	maintenance      *maintenanceMode
+	torque           *torqueState
+	velocityModes    *velocityModes
+	grabFailures     *grabFailures
+	poller           *positionPoller
+	connection       *portMonitor
+	retry            *RetryPolicy
+	busHealth        *busHealth
+	// Servos that speak SCS on an STS bus, from scs_servo_ids
+	scsServos map[int]bool
+	mu        sync.RWMutex
 }
 
 func (s *SafeSoArmController) MoveToJointPositions(ctx context.Context, jointAngles []float64, speed, acc int) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if err := s.checkMotion(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
 	armServoIDs := []int{1, 2, 3, 4, 5}
 	if len(jointAngles) != len(armServoIDs) {
@@ -43,7 +59,7 @@ func (s *SafeSoArmController) MoveToJointPositions(ctx context.Context, jointAng
 		var normalizedValue float64
 
 		// Arm servos: convert radians to degrees
-		normalizedValue = utils.RadToDeg(jointAngles[i])
+		normalizedValue = RadiansToDegrees(jointAngles[i])
 
 		cal := s.calibration.GetMotorCalibrationByID(servoID)
 		raw, err := cal.Denormalize(normalizedValue)
@@ -53,13 +69,20 @@ func (s *SafeSoArmController) MoveToJointPositions(ctx context.Context, jointAng
 		rawPositions[servoID] = raw
 	}
 
-	// Use ServoGroup to write positions
-	return s.group.SetPositions(ctx, rawPositions)
+	return s.syncWritePositions(ctx, rawPositions)
 }
 
+// MoveServosToPositions moves the given servos to joint angles in radians. speed is in degrees
+// per second and acc in degrees per second squared, both apply to every servo and 0 leaves
+// either unlimited.
 func (s *SafeSoArmController) MoveServosToPositions(ctx context.Context, servoIDs []int, jointAngles []float64, speed, acc int) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if err := s.checkMotion(); err != nil {
+		return err
+	}
+	// Only the calibration needs protecting, the bus serializes transactions itself so the
+	// arm and gripper can command their own servos concurrently
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
 	if len(servoIDs) != len(jointAngles) {
 		return fmt.Errorf("servo IDs and joint angles length mismatch")
@@ -72,11 +95,9 @@ func (s *SafeSoArmController) MoveServosToPositions(ctx context.Context, servoID
 
 		if isGripperServo(servoID) {
 			// Gripper: input is in radians representation but encodes percentage
-			// Convert from radians representation back to percentage (0-100)
-			normalizedValue = (jointAngles[i]/math.Pi + 1.0) / 2.0 * 100.0
+			normalizedValue = GripperRadiansToPercent(jointAngles[i])
 		} else {
-			// Arm servos: convert radians to degrees
-			normalizedValue = utils.RadToDeg(jointAngles[i])
+			normalizedValue = RadiansToDegrees(jointAngles[i])
 		}
 
 		cal := s.calibration.GetMotorCalibrationByID(servoID)
@@ -87,8 +108,67 @@ func (s *SafeSoArmController) MoveServosToPositions(ctx context.Context, servoID
 		rawPositions[servoID] = raw
 	}
 
-	// Use appropriate ServoGroup
-	return s.group.SetPositions(ctx, rawPositions)
+	// Always write the goal speed and acceleration so a slow move doesn't leave stale values
+	// behind for the next one
+	stepsPerSec := int(math.Round(DegreesToSteps(float64(speed))))
+	return s.syncWriteGoals(ctx, rawPositions, AccelerationToRegister(float64(acc)), stepsPerSec)
+}
+
+// syncWriteGoals writes acceleration, goal position, goal time and goal speed (registers
+// 41-47) for every servo in one sync write packet, so a coordinated move starts on all
+// joints at once with the same dynamics. SCS servos have no acceleration register, they get
+// a second packet starting at the goal position.
+func (s *SafeSoArmController) syncWriteGoals(ctx context.Context, rawPositions map[int]int, acc, stepsPerSec int) error {
+	stsData := map[int][]byte{}
+	scsData := map[int][]byte{}
+	for id, pos := range rawPositions {
+		if s.isSCSServo(id) {
+			scsData[id] = encodeGoalData(s.protocolFor(id), acc, pos, stepsPerSec)[1:]
+		} else {
+			stsData[id] = encodeGoalData(s.protocolFor(id), acc, pos, stepsPerSec)
+		}
+	}
+	if len(stsData) > 0 {
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			return s.bus.SyncWrite(ctx, feetech.RegAcceleration.Address, goalDataLen, stsData)
+		}); err != nil {
+			return err
+		}
+	}
+	if len(scsData) > 0 {
+		return s.busOp(ctx, func(ctx context.Context) error {
+			return s.bus.SyncWrite(ctx, feetech.RegGoalPosition.Address, goalDataLen-1, scsData)
+		})
+	}
+	return nil
+}
+
+// syncWritePositions writes only the goal position of every servo in one sync write packet,
+// each encoded in its own protocol's byte order
+func (s *SafeSoArmController) syncWritePositions(ctx context.Context, rawPositions feetech.PositionMap) error {
+	if len(rawPositions) == 0 {
+		return nil
+	}
+	data := make(map[int][]byte, len(rawPositions))
+	for id, pos := range rawPositions {
+		data[id] = s.protocolFor(id).EncodeWord(uint16(pos))
+	}
+	return s.busOp(ctx, func(ctx context.Context) error {
+		return s.bus.SyncWrite(ctx, feetech.RegGoalPosition.Address, feetech.RegGoalPosition.Size, data)
+	})
+}
+
+// goalDataLen covers acceleration (1 byte) and goal position, time and speed (2 bytes each)
+const goalDataLen = 7
+
+// encodeGoalData lays out one servo's block starting at the acceleration register
+func encodeGoalData(proto *feetech.Protocol, acc, pos, stepsPerSec int) []byte {
+	data := make([]byte, 0, goalDataLen)
+	data = append(data, byte(acc))
+	data = append(data, proto.EncodeWord(uint16(pos))...)
+	data = append(data, proto.EncodeWord(0)...) // goal time, unused when speed is set
+	data = append(data, proto.EncodeWord(uint16(stepsPerSec))...)
+	return data
 }
 
 func (s *SafeSoArmController) GetJointPositions(ctx context.Context) ([]float64, error) {
@@ -98,8 +178,8 @@ func (s *SafeSoArmController) GetJointPositions(ctx context.Context) ([]float64,
 	servoIDs := []int{1, 2, 3, 4, 5, 6}
 	positions := make([]float64, len(servoIDs))
 
-	// Read arm positions using ServoGroup
-	servoPositions, err := s.group.Positions(ctx)
+	// One sync read for all six servos
+	servoPositions, err := s.readPositionsLocked(ctx, servoIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read servo positions: %w", err)
 	}
@@ -112,8 +192,7 @@ func (s *SafeSoArmController) GetJointPositions(ctx context.Context) ([]float64,
 		if err != nil {
 			return nil, fmt.Errorf("failed to normalize servo %d: %w", servoId, err)
 		}
-		// Convert degrees to radians
-		positions[i] = utils.DegToRad(normalized)
+		positions[i] = DegreesToRadians(normalized)
 	}
 
 	// Normalize gripper position (servo 6)
@@ -123,8 +202,7 @@ func (s *SafeSoArmController) GetJointPositions(ctx context.Context) ([]float64,
 		return nil, fmt.Errorf("failed to normalize gripper: %w", err)
 	}
 	// Gripper uses 0-100 range, convert to radians representation for API consistency
-	// normalized is already 0-100, convert to [-π, +π] range
-	positions[5] = (normalized/100.0*2.0 - 1.0) * math.Pi
+	positions[5] = GripperPercentToRadians(normalized)
 
 	return positions, nil
 }
@@ -135,55 +213,321 @@ func (s *SafeSoArmController) GetJointPositionsForServos(ctx context.Context, se
 
 	positions := make([]float64, len(servoIDs))
 
-	rawPositions, err := s.group.Positions(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get raw positions for servos: %w", err)
+	rawPositions, cached := s.poller.get(servoIDs)
+	if !cached {
+		var err error
+		if rawPositions, err = s.readPositionsLocked(ctx, servoIDs); err != nil {
+			return nil, fmt.Errorf("failed to get raw positions for servos: %w", err)
+		}
 	}
 
 	for i, servoID := range servoIDs {
-		rawPos := rawPositions[servoID]
-		cal := s.calibratedServos[servoID].calibration
-		normalized, err := cal.Normalize(rawPos)
+		radians, err := s.rawToRadians(servoID, rawPositions[servoID])
 		if err != nil {
-			return nil, fmt.Errorf("failed to normalize raw servo value for id %d: %w", servoID, err)
+			return nil, err
 		}
-		if isGripperServo(servoID) {
-			positions[i] = (normalized/100.0*2.0 - 1.0) * math.Pi
-		} else {
-			positions[i] = utils.DegToRad(normalized)
+		positions[i] = radians
+	}
+
+	return positions, nil
+}
+
+// rawToRadians converts a raw servo position to radians using the servo's calibration
+func (s *SafeSoArmController) rawToRadians(servoID, rawPos int) (float64, error) {
+	cal := s.calibratedServos[servoID].calibration
+	normalized, err := cal.Normalize(rawPos)
+	if err != nil {
+		return 0, fmt.Errorf("failed to normalize raw servo value for id %d: %w", servoID, err)
+	}
+	if isGripperServo(servoID) {
+		return GripperPercentToRadians(normalized), nil
+	}
+	return DegreesToRadians(normalized), nil
+}
+
+// readPositionsPartialLocked reads the raw present positions of exactly servoIDs in a single
+// sync read. Servos that don't answer it, and SCS servos which don't support it, are read on
+// their own, and the ones that still fail are reported in the error map. The caller must
+// hold s.mu.
+func (s *SafeSoArmController) readPositionsPartialLocked(ctx context.Context, servoIDs []int) (feetech.PositionMap, map[int]error) {
+	positions := make(feetech.PositionMap, len(servoIDs))
+	syncIDs := make([]int, 0, len(servoIDs))
+	for _, id := range servoIDs {
+		if !s.isSCSServo(id) {
+			syncIDs = append(syncIDs, id)
+		}
+	}
+	if len(syncIDs) > 0 {
+		// A failed sync read still returns the servos that answered, the rest are read below
+		_ = s.busOp(ctx, func(ctx context.Context) error {
+			data, err := s.bus.SyncRead(ctx, feetech.RegPresentPosition.Address, feetech.RegPresentPosition.Size, syncIDs)
+			for id, d := range data {
+				positions[id] = int(s.bus.Protocol().DecodeWord(d))
+			}
+			return err
+		})
+	}
+
+	failed := map[int]error{}
+	for _, id := range servoIDs {
+		if _, ok := positions[id]; ok {
+			continue
 		}
+		var d []byte
+		err := s.busOp(ctx, func(ctx context.Context) error {
+			var err error
+			d, err = s.bus.ReadRegister(ctx, id, feetech.RegPresentPosition.Address, feetech.RegPresentPosition.Size)
+			return err
+		})
+		if err != nil {
+			failed[id] = err
+			continue
+		}
+		positions[id] = int(s.protocolFor(id).DecodeWord(d))
+	}
+	return positions, failed
+}
 
+// readRegisterLocked reads reg from each of servoIDs, in one sync read for the servos that
+// support it and one read each for SCS servos. It fails if any servo doesn't answer. The
+// caller must hold s.mu.
+func (s *SafeSoArmController) readRegisterLocked(ctx context.Context, servoIDs []int, reg feetech.Register) (map[int][]byte, error) {
+	data := make(map[int][]byte, len(servoIDs))
+	syncIDs := make([]int, 0, len(servoIDs))
+	for _, id := range servoIDs {
+		if !s.isSCSServo(id) {
+			syncIDs = append(syncIDs, id)
+		}
+	}
+	if len(syncIDs) > 0 {
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			read, err := s.bus.SyncRead(ctx, reg.Address, reg.Size, syncIDs)
+			if err != nil {
+				return err
+			}
+			maps.Copy(data, read)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
 	}
+	for _, id := range servoIDs {
+		if !s.isSCSServo(id) {
+			continue
+		}
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			d, err := s.bus.ReadRegister(ctx, id, reg.Address, reg.Size)
+			if err != nil {
+				return err
+			}
+			data[id] = d
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("servo %d: %w", id, err)
+		}
+	}
+	return data, nil
+}
 
+// readPositionsLocked is readPositionsPartialLocked for callers that need every servo
+func (s *SafeSoArmController) readPositionsLocked(ctx context.Context, servoIDs []int) (feetech.PositionMap, error) {
+	positions, failed := s.readPositionsPartialLocked(ctx, servoIDs)
+	for _, id := range servoIDs {
+		if err, ok := failed[id]; ok {
+			return nil, fmt.Errorf("servo %d: %w", id, err)
+		}
+	}
 	return positions, nil
 }
 
+// ReadRawPositions reads the uncalibrated present positions of servoIDs
+func (s *SafeSoArmController) ReadRawPositions(ctx context.Context, servoIDs []int) (feetech.PositionMap, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.readPositionsLocked(ctx, servoIDs)
+}
+
+// ReadJointPositionsPartial reads positions in radians like GetJointPositionsForServos, but
+// a servo that fails is retried on its own and reported in the error map instead of failing
+// the whole read
+func (s *SafeSoArmController) ReadJointPositionsPartial(ctx context.Context, servoIDs []int) (map[int]float64, map[int]error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rawPositions, cached := s.poller.get(servoIDs)
+	failed := map[int]error{}
+	if !cached {
+		rawPositions, failed = s.readPositionsPartialLocked(ctx, servoIDs)
+	}
+
+	positions := make(map[int]float64, len(servoIDs))
+	for _, servoID := range servoIDs {
+		rawPos, ok := rawPositions[servoID]
+		if !ok {
+			continue
+		}
+		radians, err := s.rawToRadians(servoID, rawPos)
+		if err != nil {
+			failed[servoID] = err
+			continue
+		}
+		positions[servoID] = radians
+	}
+	return positions, failed
+}
+
+// GetTorqueStates reads each servo's torque_enable register. Servos that can't be read are
+// reported in the error map.
+func (s *SafeSoArmController) GetTorqueStates(ctx context.Context, servoIDs []int) (map[int]bool, map[int]error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make(map[int]bool, len(servoIDs))
+	failed := map[int]error{}
+	for _, id := range servoIDs {
+		servo := s.group.ServoByID(id)
+		if servo == nil {
+			failed[id] = fmt.Errorf("servo %d not available", id)
+			continue
+		}
+		var data []byte
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			var err error
+			data, err = servo.ReadRegister(ctx, "torque_enable")
+			return err
+		}); err != nil {
+			failed[id] = err
+			continue
+		}
+		if len(data) == 0 {
+			failed[id] = fmt.Errorf("empty torque_enable response")
+			continue
+		}
+		states[id] = data[0] != 0
+	}
+	return states, failed
+}
+
+// torqueReport describes torque states for the get_torque DoCommand
+func torqueReport(servoIDs []int, states map[int]bool, failed map[int]error) map[string]interface{} {
+	servos := map[string]interface{}{}
+	errs := map[string]interface{}{}
+	allEnabled, anyEnabled := len(failed) == 0, false
+	for _, id := range servoIDs {
+		name := jointNameForServo(id)
+		if err, ok := failed[id]; ok {
+			errs[name] = err.Error()
+			continue
+		}
+		servos[name] = states[id]
+		allEnabled = allEnabled && states[id]
+		anyEnabled = anyEnabled || states[id]
+	}
+	result := map[string]interface{}{
+		"success":     len(failed) == 0,
+		"torque":      servos,
+		"all_enabled": allEnabled,
+		"any_enabled": anyEnabled,
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result
+}
+
+// ServosMoving reports whether any of the given servos has its Moving flag set
+func (s *SafeSoArmController) ServosMoving(ctx context.Context, servoIDs []int) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	moving, err := s.readRegisterLocked(ctx, servoIDs, feetech.RegMoving)
+	if err != nil {
+		return false, fmt.Errorf("failed to read moving state: %w", err)
+	}
+
+	for _, id := range servoIDs {
+		if data, ok := moving[id]; ok && len(data) > 0 && data[0] != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetServoLoads reads the signed load of each given servo as a percentage of stall torque.
+// The sign follows the joint's calibrated direction.
+func (s *SafeSoArmController) GetServoLoads(ctx context.Context, servoIDs []int) (map[int]float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raw, err := s.readRegisterLocked(ctx, servoIDs, feetech.RegPresentLoad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read servo loads: %w", err)
+	}
+
+	loads := make(map[int]float64, len(servoIDs))
+	for _, id := range servoIDs {
+		data, ok := raw[id]
+		if !ok || len(data) < 2 {
+			return nil, fmt.Errorf("servo %d did not report its load", id)
+		}
+		load := decodeServoLoad(data, s.protocolFor(id))
+		if cal := s.calibration.GetMotorCalibrationByID(id); cal != nil && cal.DriveMode != 0 {
+			load = -load
+		}
+		loads[id] = load
+	}
+	return loads, nil
+}
+
 func (s *SafeSoArmController) SetTorqueEnable(ctx context.Context, enable bool) error {
+	if enable {
+		if err := s.checkMotion(); err != nil {
+			return err
+		}
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if enable {
-		if err := s.group.EnableAll(ctx); err != nil {
+		// A stale goal position would make the servos jump as soon as they're powered
+		s.holdPresentPositionsLocked(ctx)
+		if err := s.busOp(ctx, s.group.EnableAll); err != nil {
 			return fmt.Errorf("failed to set torque enable: %w", err)
 		}
+		s.torque.set(true)
 	} else {
-		if err := s.group.DisableAll(ctx); err != nil {
+		// Cleared first, servos that didn't answer may be off too
+		s.torque.set(false)
+		if err := s.busOp(ctx, s.group.DisableAll); err != nil {
 			return fmt.Errorf("failed to set torque enable: %w", err)
 		}
 	}
 	return nil
 }
 
+// Stop holds every servo at its present position
 func (s *SafeSoArmController) Stop(ctx context.Context) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.StopServos(ctx, []int{1, 2, 3, 4, 5, 6})
+}
 
-	for id, servo := range s.calibratedServos {
-		if err := servo.SetVelocity(ctx, 0); err != nil {
-			s.logger.Warnf("Failed to stop servo %d: %v", id, err)
+// StopServos holds the given servos at their present positions. Other servos on the bus
+// are left alone, so stopping the gripper doesn't interrupt an arm move and vice versa.
+func (s *SafeSoArmController) StopServos(ctx context.Context, servoIDs []int) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	present, err := s.readPositionsLocked(ctx, servoIDs)
+	if err != nil {
+		return fmt.Errorf("failed to read positions to stop servos: %w", err)
+	}
+
+	hold := make(feetech.PositionMap, len(servoIDs))
+	for _, id := range servoIDs {
+		if pos, ok := present[id]; ok {
+			hold[id] = pos
 		}
 	}
-	return nil
+	return s.syncWritePositions(ctx, hold)
 }
 
 func (s *SafeSoArmController) Close() error {
@@ -196,20 +540,113 @@ func (s *SafeSoArmController) Close() error {
 	return nil
 }
 
-func (s *SafeSoArmController) Ping(ctx context.Context) error {
+// Ping checks that each of the given servos answers, so a component only requires the
+// servos it is configured for
+func (s *SafeSoArmController) Ping(ctx context.Context, servoIDs []int) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for id, servo := range s.calibratedServos {
-		if _, err := servo.Ping(ctx); err != nil {
+	for _, id := range servoIDs {
+		servo, ok := s.calibratedServos[id]
+		if !ok {
+			return fmt.Errorf("servo %d not available", id)
+		}
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			_, err := servo.Ping(ctx)
+			return err
+		}); err != nil {
 			return fmt.Errorf("ping failed for servo %d: %w", id, err)
 		}
 	}
 	return nil
 }
 
+// SetServoOperatingMode switches a servo between position and velocity (wheel) mode. Torque
+// is disabled while the mode changes, and re-enabled afterwards only if it was on.
+func (s *SafeSoArmController) SetServoOperatingMode(ctx context.Context, servoID, mode int) error {
+	// Going back to position mode holds the joint still, so it stays allowed while stopped
+	if mode == feetech.ModeVelocity {
+		if err := s.checkMotion(); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	servo := s.group.ServoByID(servoID)
+	if servo == nil {
+		return fmt.Errorf("servo %d not available", servoID)
+	}
+
+	// Torque stays recorded off unless the servo is enabled again at the end
+	wasOn := s.torque.on()
+	s.torque.set(false)
+	if err := s.busOp(ctx, servo.Disable); err != nil {
+		return fmt.Errorf("failed to disable torque on servo %d: %w", servoID, err)
+	}
+	if mode == feetech.ModeVelocity {
+		// Start stopped so the joint doesn't spin off with a stale goal velocity
+		if err := s.busOp(ctx, func(ctx context.Context) error {
+			return servo.SetVelocity(ctx, 0)
+		}); err != nil {
+			return fmt.Errorf("failed to clear velocity on servo %d: %w", servoID, err)
+		}
+	}
+	if err := s.busOp(ctx, func(ctx context.Context) error {
+		return servo.SetOperatingMode(ctx, mode)
+	}); err != nil {
+		return fmt.Errorf("failed to set operating mode on servo %d: %w", servoID, err)
+	}
+	s.velocityModes.set(servoID, mode == feetech.ModeVelocity)
+	if mode == feetech.ModePosition {
+		// Hold where the joint ended up instead of jumping back to an old goal
+		present, err := s.readPositionsLocked(ctx, []int{servoID})
+		if err != nil {
+			return fmt.Errorf("failed to read position of servo %d: %w", servoID, err)
+		}
+		if err := s.syncWritePositions(ctx, present); err != nil {
+			return fmt.Errorf("failed to hold position of servo %d: %w", servoID, err)
+		}
+	}
+	if !wasOn {
+		return nil
+	}
+	if err := s.busOp(ctx, servo.Enable); err != nil {
+		return fmt.Errorf("failed to enable torque on servo %d: %w", servoID, err)
+	}
+	s.torque.set(true)
+	return nil
+}
+
+// SetServoVelocity commands a signed speed in degrees per second to a servo in velocity mode
+func (s *SafeSoArmController) SetServoVelocity(ctx context.Context, servoID int, degsPerSec float64) error {
+	if degsPerSec != 0 {
+		if err := s.checkMotion(); err != nil {
+			return err
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	servo := s.group.ServoByID(servoID)
+	if servo == nil {
+		return fmt.Errorf("servo %d not available", servoID)
+	}
+	return s.busOp(ctx, func(ctx context.Context) error {
+		return servo.SetVelocity(ctx, int(math.Round(DegreesToSteps(degsPerSec))))
+	})
+}
+
 // WriteServoRegister writes to a specific servo register by name
 func (s *SafeSoArmController) WriteServoRegister(ctx context.Context, servoID int, registerName string, data []byte) error {
+	if startsMotion(registerName, data) {
+		if err := s.checkMotion(); err != nil {
+			return err
+		}
+	}
+	if registerName == "torque_enable" && len(data) > 0 && data[0] == 0 {
+		s.torque.set(false)
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -218,7 +655,79 @@ func (s *SafeSoArmController) WriteServoRegister(ctx context.Context, servoID in
 		return fmt.Errorf("servo %d not available", servoID)
 	}
 
-	return servo.WriteRegister(ctx, registerName, data)
+	return s.busOp(ctx, func(ctx context.Context) error {
+		return servo.WriteRegister(ctx, registerName, data)
+	})
+}
+
+// startsMotion reports whether a register write could move a servo or re-enable its torque
+func startsMotion(registerName string, data []byte) bool {
+	switch registerName {
+	case "goal_position":
+		return true
+	case "goal_velocity":
+		for _, b := range data {
+			if b != 0 {
+				return true
+			}
+		}
+	case "torque_enable":
+		// 128 recenters the position reading without enabling torque
+		return len(data) > 0 && data[0] == 1
+	}
+	return false
+}
+
+// ReadServoRegister reads a specific servo register by name
+func (s *SafeSoArmController) ReadServoRegister(ctx context.Context, servoID int, registerName string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	servo := s.group.ServoByID(servoID)
+	if servo == nil {
+		return nil, fmt.Errorf("servo %d not available", servoID)
+	}
+
+	var data []byte
+	err := s.busOp(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = servo.ReadRegister(ctx, registerName)
+		return err
+	})
+	return data, err
+}
+
+// ReadServoRegisterAt reads a register by address, for registers the servo model doesn't name
+func (s *SafeSoArmController) ReadServoRegisterAt(ctx context.Context, servoID int, reg feetech.Register) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var data []byte
+	err := s.busOp(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = s.bus.ReadRegister(ctx, servoID, reg.Address, reg.Size)
+		return err
+	})
+	return data, err
+}
+
+// WriteServoRegisterAt writes a register by address, for registers the servo model doesn't name
+func (s *SafeSoArmController) WriteServoRegisterAt(ctx context.Context, servoID int, reg feetech.Register, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reg.ReadOnly {
+		return fmt.Errorf("register at address %d is read-only", reg.Address)
+	}
+	if len(data) != reg.Size {
+		return fmt.Errorf("data size mismatch: expected %d bytes, got %d", reg.Size, len(data))
+	}
+	if reg.Address == feetech.RegTorqueEnable.Address && data[0] == 0 {
+		s.torque.set(false)
+	}
+	return s.busOp(ctx, func(ctx context.Context) error {
+		return s.bus.WriteRegister(ctx, servoID, reg.Address, data)
+	})
 }
 
 func (s *SafeSoArmController) SetCalibration(calibration SO101FullCalibration) error {
@@ -281,7 +790,8 @@ func configsEqual(a, b *SoArm101Config) bool {
 	}
 	return a.Port == b.Port &&
 		a.Baudrate == b.Baudrate &&
-		a.Timeout == b.Timeout
+		a.Timeout == b.Timeout &&
+		protocolsEqual(a, b)
 }
 
 func fullCalibrationsEqual(a, b SO101FullCalibration) bool {
@@ -296,8 +806,40 @@ func GetSharedControllerWithCalibration(config *SoArm101Config, calibration SO10
 	return globalRegistry.GetController(config.Port, config, calibration, fromFile)
 }
 
-func ReleaseSharedController() {
-	globalRegistry.releaseFromCaller()
+// ReleaseSharedControllerForPort releases one reference to the controller for a port,
+// controller is the one the component was handed
+func ReleaseSharedControllerForPort(portPath string, controller *SafeSoArmController) {
+	globalRegistry.ReleaseController(portPath, controller)
+}
+
+// ApplySharedCalibration applies a calibration to every component on a port
+func ApplySharedCalibration(portPath string, calibration SO101FullCalibration) error {
+	return globalRegistry.ApplyCalibration(portPath, calibration)
+}
+
+// ResetSharedServo factory resets one servo on a port with INST_RESET
+func ResetSharedServo(ctx context.Context, portPath string, servoID int) error {
+	return globalRegistry.ResetServo(ctx, portPath, servoID)
+}
+
+// WatchSharedCalibrationFile hot-reloads the calibration for a port when the file changes
+func WatchSharedCalibrationFile(portPath, filePath string, logger logging.Logger) error {
+	return globalRegistry.WatchCalibrationFile(portPath, filePath, logger)
+}
+
+// RegisterSharedConsumer records how a component uses the controller on its port
+func RegisterSharedConsumer(portPath string, info ConsumerInfo) {
+	globalRegistry.RegisterConsumer(portPath, info)
+}
+
+// UnregisterSharedConsumer forgets a component registered with RegisterSharedConsumer
+func UnregisterSharedConsumer(portPath, name string) {
+	globalRegistry.UnregisterConsumer(portPath, name)
+}
+
+// GetSharedConsumers returns the components sharing the controller on a port
+func GetSharedConsumers(portPath string) []ConsumerInfo {
+	return globalRegistry.GetConsumers(portPath)
 }
 
 func ForceCloseSharedController() error {
@@ -0,0 +1,146 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestEncodeGoalData(t *testing.T) {
+	proto := feetech.NewProtocol(feetech.ProtocolSTS)
+	data := encodeGoalData(proto, 5, 2048, 300)
+	assert.Len(t, data, goalDataLen)
+	assert.Equal(t, byte(5), data[0])
+	assert.Equal(t, uint16(2048), proto.DecodeWord(data[1:3]))
+	assert.Equal(t, uint16(0), proto.DecodeWord(data[3:5]))
+	assert.Equal(t, uint16(300), proto.DecodeWord(data[5:7]))
+}
+
+func TestServosMovingReadsEachServo(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, 1, 2, 3, 4, 5, 6)
+	controller.scsServos = map[int]bool{6: true}
+
+	moving, err := controller.ServosMoving(ctx, []int{1, 2, 3, 4, 5, 6})
+	assert.NoError(t, err)
+	assert.False(t, moving)
+
+	fake.setRegister(6, feetech.RegMoving, []byte{1})
+	moving, err = controller.ServosMoving(ctx, []int{1, 2, 3, 4, 5, 6})
+	assert.NoError(t, err)
+	assert.True(t, moving)
+
+	// Only the requested servos count
+	moving, err = controller.ServosMoving(ctx, []int{1, 2})
+	assert.NoError(t, err)
+	assert.False(t, moving)
+
+	_, err = controller.ServosMoving(ctx, []int{1, 7})
+	assert.Error(t, err)
+}
+
+func TestWaitWithinFinishesWhenServosStopShort(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, _ := newFakeController(t, ids...)
+	s := &so101{
+		cfg:           &SO101ArmConfig{},
+		controller:    controller,
+		armServoIDs:   ids,
+		logger:        logger,
+		events:        &eventLog{},
+		decalibration: newDecalibrationDetector(5, 0),
+	}
+
+	// Every joint rests far from the target with its Moving flag clear
+	target := []float64{1, 1, 1, 1, 1}
+	assert.NoError(t, s.waitWithin(context.Background(), target, DegreesToRadians(1), 0))
+	assert.Equal(t, true, s.decalibration.status()["latched"])
+}
+
+func TestGetServoLoadsReadsEachServo(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, 1, 2, 6)
+	controller.scsServos = map[int]bool{6: true}
+	fake.setRegister(2, feetech.RegPresentLoad, []byte{250, 0})
+	// 50% with the direction bit set, big-endian like every SCS word
+	fake.setRegister(6, feetech.RegPresentLoad, []byte{0x05, 0xF4})
+
+	loads, err := controller.GetServoLoads(ctx, []int{1, 2, 6})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]float64{1: 0, 2: 25, 6: -50}, loads)
+
+	_, err = controller.GetServoLoads(ctx, []int{2, 7})
+	assert.Error(t, err)
+}
+
+func TestHoldPositionsUseEachServosByteOrder(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, 1, 6)
+	controller.scsServos = map[int]bool{6: true}
+	// 2049 in each servo's own byte order
+	fake.setRegister(1, feetech.RegPresentPosition, []byte{0x01, 0x08})
+	fake.setRegister(6, feetech.RegPresentPosition, []byte{0x08, 0x01})
+
+	assert.NoError(t, controller.StopServos(ctx, []int{1, 6}))
+	assert.Equal(t, []byte{0x01, 0x08}, fake.register(1, feetech.RegGoalPosition))
+	assert.Equal(t, []byte{0x08, 0x01}, fake.register(6, feetech.RegGoalPosition))
+
+	fake.setRegister(1, feetech.RegGoalPosition, []byte{0, 0})
+	fake.setRegister(6, feetech.RegGoalPosition, []byte{0, 0})
+	assert.NoError(t, controller.SetTorqueEnable(ctx, true))
+	assert.Equal(t, []byte{0x01, 0x08}, fake.register(1, feetech.RegGoalPosition))
+	assert.Equal(t, []byte{0x08, 0x01}, fake.register(6, feetech.RegGoalPosition))
+}
+
+func TestBusOperationsRetry(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, 1, 2)
+	controller.retry = &RetryPolicy{Attempts: 2}
+	fake.setRegister(2, feetech.RegPresentPosition, []byte{0x00, 0x08})
+
+	fake.drop(1)
+	states, failed := controller.GetTorqueStates(ctx, []int{1})
+	assert.Empty(t, failed)
+	assert.Equal(t, map[int]bool{1: false}, states)
+
+	fake.drop(1)
+	positions, failed := controller.readPositionsPartialLocked(ctx, []int{1, 2})
+	assert.Empty(t, failed)
+	assert.Equal(t, 2048, positions[2])
+
+	fake.drop(1)
+	assert.NoError(t, controller.SetServoVelocity(ctx, 1, 0))
+	assert.NotEmpty(t, fake.writesTo(1, feetech.RegGoalVelocity))
+
+	assert.Equal(t, int64(3), controller.busHealth.status()["retries"])
+}
+
+func TestSetServoOperatingModeKeepsTorqueState(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, 1)
+
+	// Torque that was off stays off on the servo, not just in the recorded state
+	assert.NoError(t, controller.SetServoOperatingMode(ctx, 1, feetech.ModeVelocity))
+	assert.Equal(t, []byte{0}, fake.register(1, feetech.RegTorqueEnable))
+	assert.False(t, controller.TorqueEnabled())
+	assert.NoError(t, controller.SetServoOperatingMode(ctx, 1, feetech.ModePosition))
+	assert.Equal(t, []byte{0}, fake.register(1, feetech.RegTorqueEnable))
+
+	assert.NoError(t, controller.SetTorqueEnable(ctx, true))
+	assert.NoError(t, controller.SetServoOperatingMode(ctx, 1, feetech.ModeVelocity))
+	assert.Equal(t, []byte{1}, fake.register(1, feetech.RegTorqueEnable))
+	assert.True(t, controller.TorqueEnabled())
+}
+
+func TestPingOnlyConfiguredServos(t *testing.T) {
+	ctx := context.Background()
+	// A build without a gripper has no servo 6 on the bus
+	controller, _ := newFakeController(t, 1, 2, 3, 4, 5)
+
+	assert.NoError(t, controller.Ping(ctx, []int{1, 2, 3, 4, 5}))
+	assert.Error(t, controller.Ping(ctx, []int{1, 2, 3, 4, 5, 6}))
+}
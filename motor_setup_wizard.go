@@ -0,0 +1,141 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Motor setup wizard. motor_setup_run_all walks through the whole motor setup, prompting in
+// Readings for each motor to be connected on its own and assigning its ID and baudrate when
+// motor_setup_continue is sent, in the reverse order of SO101MotorConfigs. A last continue
+// verifies the daisy-chained arm.
+const motorSetupTargetBaudrate = 1000000
+
+// motorSetupWizard is the state of a motor_setup_run_all run
+type motorSetupWizard struct {
+	motors  []MotorSetupConfig
+	next    int // index into motors, len(motors) once every motor is assigned
+	results map[string]any
+}
+
+// wizardMotors returns the motors a wizard sets up, the configured ones in setup order
+func wizardMotors(servoIDs []int) []MotorSetupConfig {
+	var motors []MotorSetupConfig
+	for _, motor := range SO101MotorConfigs {
+		if slices.Contains(servoIDs, motor.TargetID) {
+			motors = append(motors, motor)
+		}
+	}
+	return motors
+}
+
+// prompt tells the user what to connect before the next motor_setup_continue
+func (w *motorSetupWizard) prompt() string {
+	if w.next >= len(w.motors) {
+		return "All motors configured. Daisy-chain every motor to the controller board, then send 'motor_setup_continue' to verify."
+	}
+	motor := w.motors[w.next]
+	return fmt.Sprintf("Step %d of %d: connect only the %s motor to the controller board, then send 'motor_setup_continue'. It will become ID %d.",
+		w.next+1, len(w.motors), motor.Name, motor.TargetID)
+}
+
+// motorSetupRunAll handles the motor_setup_run_all DoCommand, starting the wizard
+func (cs *so101CalibrationSensor) motorSetupRunAll() (map[string]any, error) {
+	if cs.calibrationInProgress() {
+		return map[string]any{"success": false},
+			fmt.Errorf("calibration in progress (state: %s), abort it before motor setup", cs.state.String())
+	}
+	if cs.setupInProgress {
+		return map[string]any{"success": false},
+			fmt.Errorf("motor setup already in progress, use 'motor_setup_reset_status' to start over")
+	}
+
+	cs.setupWizard = &motorSetupWizard{motors: wizardMotors(cs.cfg.ServoIDs), results: map[string]any{}}
+	cs.setupInProgress = true
+	cs.currentSetupStep = 1
+	cs.setupStatus = cs.setupWizard.prompt()
+	cs.logger.Infof("Motor setup: %s", cs.setupStatus)
+
+	return map[string]any{
+		"success": true,
+		"step":    cs.currentSetupStep,
+		"status":  cs.setupStatus,
+	}, nil
+}
+
+// motorSetupContinue handles the motor_setup_continue DoCommand. It sets up the motor the
+// wizard asked for, or verifies the arm once every motor is done. A failed step can be retried
+// by sending motor_setup_continue again.
+func (cs *so101CalibrationSensor) motorSetupContinue(ctx context.Context) (map[string]any, error) {
+	w := cs.setupWizard
+	if w == nil {
+		return map[string]any{"success": false}, fmt.Errorf("no motor setup wizard running, use 'motor_setup_run_all' first")
+	}
+
+	if w.next >= len(w.motors) {
+		verify, err := cs.motorSetupVerify(ctx)
+		if err != nil {
+			return verify, err
+		}
+		if verified, _ := verify["success"].(bool); !verified {
+			cs.setupStatus = "Some motors failed verification. Check the daisy chain and send 'motor_setup_continue' to verify again."
+			verify["status"] = cs.setupStatus
+			return verify, nil
+		}
+		cs.setupWizard = nil
+		cs.setupInProgress = false
+		cs.currentSetupStep = 0
+		cs.setupStatus = "All SO-101 motors set up and verified"
+		cs.logger.Infof("Motor setup: %s", cs.setupStatus)
+		verify["status"] = cs.setupStatus
+		verify["configured"] = w.results
+		return verify, nil
+	}
+
+	motor := w.motors[w.next]
+	found, baudrate, err := cs.discoverOneMotor(ctx, motor.Model)
+	if err == nil {
+		err = cs.assignMotorIDAndBaudrate(found.ID, motor.TargetID, baudrate, motorSetupTargetBaudrate)
+	}
+	if err != nil {
+		cs.setupStatus = fmt.Sprintf("Failed to set up the %s motor: %v. Check that only the %s motor is connected, then send 'motor_setup_continue' again.",
+			motor.Name, err, motor.Name)
+		cs.logger.Warnf("Motor setup: %s", cs.setupStatus)
+		return map[string]any{"success": false, "step": cs.currentSetupStep, "status": cs.setupStatus}, nil
+	}
+
+	w.results[motor.Name] = map[string]any{
+		"old_id":       found.ID,
+		"new_id":       motor.TargetID,
+		"new_baudrate": motorSetupTargetBaudrate,
+	}
+	w.next++
+	cs.currentSetupStep = w.next + 1
+	cs.setupStatus = w.prompt()
+	cs.logger.Infof("Motor setup: %s configured as ID %d. %s", motor.Name, motor.TargetID, cs.setupStatus)
+
+	return map[string]any{
+		"success":    true,
+		"motor_name": motor.Name,
+		"old_id":     found.ID,
+		"new_id":     motor.TargetID,
+		"step":       cs.currentSetupStep,
+		"status":     cs.setupStatus,
+	}, nil
+}
+
+// wizardReading describes a running wizard for Readings
+func (w *motorSetupWizard) reading() map[string]any {
+	reading := map[string]any{
+		"total_motors": len(w.motors),
+		"configured":   w.results,
+	}
+	if w.next < len(w.motors) {
+		reading["current_motor"] = w.motors[w.next].Name
+		reading["target_id"] = w.motors[w.next].TargetID
+	} else {
+		reading["current_motor"] = "verify"
+	}
+	return reading
+}
@@ -0,0 +1,40 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestMotorSetupWizard(t *testing.T) {
+	motors := wizardMotors([]int{1, 2, 3, 4, 5})
+	assert.Len(t, motors, 5)
+	assert.Equal(t, "wrist_roll", motors[0].Name)
+	assert.Equal(t, "shoulder_pan", motors[4].Name)
+
+	cs := &so101CalibrationSensor{
+		logger: logging.NewTestLogger(t),
+		cfg:    &SO101CalibrationSensorConfig{ServoIDs: []int{1, 2, 3, 4, 5, 6}},
+	}
+	_, err := cs.motorSetupContinue(context.Background())
+	assert.Error(t, err)
+
+	_, err = cs.motorSetupRunAll()
+	assert.NoError(t, err)
+	assert.True(t, cs.setupInProgress)
+	assert.Contains(t, cs.setupStatus, "gripper")
+	assert.Equal(t, "gripper", cs.setupWizard.reading()["current_motor"])
+
+	_, err = cs.motorSetupRunAll()
+	assert.Error(t, err)
+
+	cs.setupWizard.next = len(cs.setupWizard.motors)
+	assert.Contains(t, cs.setupWizard.prompt(), "verify")
+
+	_, err = cs.motorSetupResetStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, cs.setupWizard)
+	assert.False(t, cs.setupInProgress)
+}
@@ -0,0 +1,62 @@
+package so_arm
+
+import (
+	"fmt"
+
+	"go.viam.com/rdk/components/arm"
+)
+
+// Joint speed and acceleration bounds, matching speed_degs_per_sec and acceleration_degs_per_sec_per_sec
+const (
+	minSpeedDegsPerSec     = 3
+	maxSpeedDegsPerSec     = 180
+	minAccDegsPerSecPerSec = 10
+	maxAccDegsPerSecPerSec = 500
+)
+
+// motionParams is the speed and acceleration used for a single move
+type motionParams struct {
+	SpeedDegsPerSec     float64
+	AccDegsPerSecPerSec float64
+}
+
+// resolveMotionParams maps arm.MoveOptions and the speed_percent extra onto the module's
+// speed model. MoveOptions limits replace the configured defaults, speed_percent then scales
+// the result, and the final values are clamped to what the servos support.
+func resolveMotionParams(defaults motionParams, options *arm.MoveOptions, extra map[string]interface{}) (motionParams, error) {
+	params := defaults
+	if options != nil {
+		if options.MaxVelRads > 0 {
+			params.SpeedDegsPerSec = RadiansToDegrees(options.MaxVelRads)
+		}
+		if options.MaxAccRads > 0 {
+			params.AccDegsPerSecPerSec = RadiansToDegrees(options.MaxAccRads)
+		}
+	}
+
+	if v, ok := extra["speed_percent"]; ok {
+		percent, ok := v.(float64)
+		if !ok {
+			return params, fmt.Errorf("speed_percent must be a number")
+		}
+		if percent <= 0 || percent > 100 {
+			return params, fmt.Errorf("speed_percent must be in (0, 100], got %.1f", percent)
+		}
+		params.SpeedDegsPerSec *= percent / 100
+		params.AccDegsPerSecPerSec *= percent / 100
+	}
+
+	params.SpeedDegsPerSec = clampFloat(params.SpeedDegsPerSec, minSpeedDegsPerSec, maxSpeedDegsPerSec)
+	params.AccDegsPerSecPerSec = clampFloat(params.AccDegsPerSecPerSec, minAccDegsPerSecPerSec, maxAccDegsPerSecPerSec)
+	return params, nil
+}
+
+func clampFloat(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,44 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/components/arm"
+)
+
+func TestResolveMotionParams(t *testing.T) {
+	defaults := motionParams{SpeedDegsPerSec: 50, AccDegsPerSecPerSec: 100}
+
+	params, err := resolveMotionParams(defaults, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaults, params)
+
+	// MoveOptions are in radians and replace the defaults
+	params, err = resolveMotionParams(defaults, &arm.MoveOptions{MaxVelRads: DegreesToRadians(90), MaxAccRads: DegreesToRadians(200)}, nil)
+	assert.NoError(t, err)
+	assert.InDelta(t, 90.0, params.SpeedDegsPerSec, 1e-9)
+	assert.InDelta(t, 200.0, params.AccDegsPerSecPerSec, 1e-9)
+
+	// Unset MoveOptions fields keep the defaults
+	params, err = resolveMotionParams(defaults, &arm.MoveOptions{MaxVelRads: DegreesToRadians(30)}, nil)
+	assert.NoError(t, err)
+	assert.InDelta(t, 30.0, params.SpeedDegsPerSec, 1e-9)
+	assert.InDelta(t, 100.0, params.AccDegsPerSecPerSec, 1e-9)
+
+	params, err = resolveMotionParams(defaults, nil, map[string]interface{}{"speed_percent": 50.0})
+	assert.NoError(t, err)
+	assert.InDelta(t, 25.0, params.SpeedDegsPerSec, 1e-9)
+	assert.InDelta(t, 50.0, params.AccDegsPerSecPerSec, 1e-9)
+
+	// Limits beyond what the servos support are clamped
+	params, err = resolveMotionParams(defaults, &arm.MoveOptions{MaxVelRads: 100, MaxAccRads: 0.01}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(maxSpeedDegsPerSec), params.SpeedDegsPerSec)
+	assert.Equal(t, float64(minAccDegsPerSecPerSec), params.AccDegsPerSecPerSec)
+
+	_, err = resolveMotionParams(defaults, nil, map[string]interface{}{"speed_percent": 150.0})
+	assert.Error(t, err)
+	_, err = resolveMotionParams(defaults, nil, map[string]interface{}{"speed_percent": "fast"})
+	assert.Error(t, err)
+}
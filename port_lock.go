@@ -0,0 +1,46 @@
+//go:build !windows
+
+package so_arm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// portLock is an advisory lock on a serial device node. It's held for as long as the
+// registry has a bus open on the port, so other processes that also lock the port (another
+// module instance, or pyserial opened with exclusive=True) fail cleanly instead of
+// interleaving packets with ours.
+type portLock struct {
+	file *os.File
+}
+
+// acquirePortLock takes an exclusive, non-blocking flock on the device node. A port that
+// can't be opened for locking returns a nil lock so the bus open reports the real error.
+func acquirePortLock(port string) (*portLock, error) {
+	file, err := os.OpenFile(port, os.O_RDONLY|syscall.O_NONBLOCK|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("serial port %s is locked by another process (e.g. LeRobot, a calibration script or another viam-server); stop it before using this component: %w", port, err)
+		}
+		return nil, fmt.Errorf("failed to lock serial port %s: %w", port, err)
+	}
+	return &portLock{file: file}, nil
+}
+
+// Release drops the lock, it's safe to call on a nil lock
+func (l *portLock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortLock(t *testing.T) {
+	port := filepath.Join(t.TempDir(), "ttyUSB0")
+	assert.NoError(t, os.WriteFile(port, nil, 0644))
+
+	lock, err := acquirePortLock(port)
+	assert.NoError(t, err)
+	assert.NotNil(t, lock)
+
+	_, err = acquirePortLock(port)
+	assert.ErrorContains(t, err, "locked by another process")
+
+	assert.NoError(t, lock.Release())
+	lock, err = acquirePortLock(port)
+	assert.NoError(t, err)
+	assert.NoError(t, lock.Release())
+
+	// Missing ports are left for the bus open to report
+	lock, err = acquirePortLock(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+	assert.Nil(t, lock)
+	assert.NoError(t, lock.Release())
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package so_arm
+
+// portLock is a no-op on Windows, where COM ports are already opened exclusively
+type portLock struct{}
+
+func acquirePortLock(port string) (*portLock, error) {
+	return nil, nil
+}
+
+// Release is a no-op on Windows
+func (l *portLock) Release() error {
+	return nil
+}
@@ -0,0 +1,349 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/rdk/spatialmath"
+	"gopkg.in/yaml.v3"
+)
+
+// Pose files hold named poses and programs in YAML so routines can be reviewed in git. Every
+// pose states its frame: "joints" poses are joint angles, "base" poses are end effector poses
+// in the arm's base frame, solved with the local IK solver when they're used.
+const (
+	poseFileVersion = 1
+	poseFrameJoints = "joints"
+	poseFrameBase   = "base"
+)
+
+// PoseFile is the YAML layout of poses_file
+type PoseFile struct {
+	Version int `yaml:"version"`
+	// Units of joint poses, "degrees" (default) or "radians"
+	Units string `yaml:"units,omitempty"`
+	// Joint of each value in joint poses, defaults to the arm's joints in servo order
+	Joints   []string                 `yaml:"joints,omitempty"`
+	Poses    map[string]PoseEntry     `yaml:"poses,omitempty"`
+	Programs map[string][]ProgramStep `yaml:"programs,omitempty"`
+}
+
+// PoseEntry is one named pose. In YAML a plain list is shorthand for a joint pose.
+type PoseEntry struct {
+	Frame  string    `yaml:"frame"`
+	Joints []float64 `yaml:"joints,omitempty"`
+	// Position in millimeters and orientation vector in degrees, for base frame poses
+	PositionMM  []float64        `yaml:"position_mm,omitempty,flow"`
+	Orientation *PoseOrientation `yaml:"orientation_degs,omitempty"`
+}
+
+// PoseOrientation is an orientation vector with theta in degrees
+type PoseOrientation struct {
+	OX    float64 `yaml:"ox"`
+	OY    float64 `yaml:"oy"`
+	OZ    float64 `yaml:"oz"`
+	Theta float64 `yaml:"theta"`
+}
+
+// ProgramStep moves to a named pose, optionally at its own speed
+type ProgramStep struct {
+	Pose            string  `yaml:"pose"`
+	SpeedDegsPerSec float64 `yaml:"speed_degs_per_sec,omitempty"`
+}
+
+func (e *PoseEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		e.Frame = poseFrameJoints
+		return node.Decode(&e.Joints)
+	}
+	type plain PoseEntry
+	return node.Decode((*plain)(e))
+}
+
+func (e PoseEntry) MarshalYAML() (interface{}, error) {
+	if e.Frame == poseFrameJoints {
+		node := &yaml.Node{}
+		if err := node.Encode(e.Joints); err != nil {
+			return nil, err
+		}
+		node.Style = yaml.FlowStyle
+		return node, nil
+	}
+	type plain PoseEntry
+	return plain(e), nil
+}
+
+// namedPose is a pose ready to use: joint angles in degrees in the arm's joint order, or an
+// end effector pose to solve for
+type namedPose struct {
+	jointsDegs []float64
+	pose       spatialmath.Pose
+	source     string
+}
+
+func (p namedPose) toMap() map[string]interface{} {
+	result := map[string]interface{}{"source": p.source}
+	if p.pose != nil {
+		point := p.pose.Point()
+		ov := p.pose.Orientation().OrientationVectorDegrees()
+		result["frame"] = poseFrameBase
+		result["position_mm"] = []float64{point.X, point.Y, point.Z}
+		result["orientation_degs"] = map[string]interface{}{"ox": ov.OX, "oy": ov.OY, "oz": ov.OZ, "theta": ov.Theta}
+		return result
+	}
+	result["frame"] = poseFrameJoints
+	result["positions_degs"] = p.jointsDegs
+	return result
+}
+
+// loadPoseFile reads a pose file and converts its poses for an arm with jointNames
+func loadPoseFile(path string, jointNames []string) (map[string]namedPose, map[string][]ProgramStep, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read poses file: %w", err)
+	}
+	var file PoseFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse poses file %s: %w", path, err)
+	}
+	return file.resolve(jointNames)
+}
+
+// resolve validates the file and converts its poses, programs must only use poses from the file
+func (f *PoseFile) resolve(jointNames []string) (map[string]namedPose, map[string][]ProgramStep, error) {
+	if f.Version < 1 || f.Version > poseFileVersion {
+		return nil, nil, fmt.Errorf("poses file version %d is not supported, this module reads up to version %d", f.Version, poseFileVersion)
+	}
+	if f.Units != "" && f.Units != trajectoryUnitsDegs && f.Units != trajectoryUnitsRads {
+		return nil, nil, fmt.Errorf("units must be %q or %q, got %q", trajectoryUnitsDegs, trajectoryUnitsRads, f.Units)
+	}
+	fileJoints := f.Joints
+	if len(fileJoints) == 0 {
+		fileJoints = jointNames
+	}
+	columns := make([]int, len(jointNames))
+	for i, name := range jointNames {
+		columns[i] = -1
+		for j, fileName := range fileJoints {
+			if fileName == name {
+				columns[i] = j
+			}
+		}
+		if columns[i] < 0 {
+			return nil, nil, fmt.Errorf("poses file joints has no %s", name)
+		}
+	}
+
+	poses := make(map[string]namedPose, len(f.Poses))
+	for name, entry := range f.Poses {
+		switch entry.Frame {
+		case poseFrameJoints:
+			if len(entry.Joints) != len(fileJoints) {
+				return nil, nil, fmt.Errorf("pose %q has %d joint values, expected %d", name, len(entry.Joints), len(fileJoints))
+			}
+			degs := make([]float64, len(columns))
+			for i, col := range columns {
+				degs[i] = entry.Joints[col]
+				if f.Units == trajectoryUnitsRads {
+					degs[i] = RadiansToDegrees(degs[i])
+				}
+			}
+			poses[name] = namedPose{jointsDegs: degs, source: "poses_file"}
+		case poseFrameBase:
+			if len(entry.PositionMM) != 3 || entry.Orientation == nil {
+				return nil, nil, fmt.Errorf("pose %q in the base frame needs position_mm [x, y, z] and orientation_degs", name)
+			}
+			o := entry.Orientation
+			pose := spatialmath.NewPose(
+				r3.Vector{X: entry.PositionMM[0], Y: entry.PositionMM[1], Z: entry.PositionMM[2]},
+				&spatialmath.OrientationVectorDegrees{OX: o.OX, OY: o.OY, OZ: o.OZ, Theta: o.Theta},
+			)
+			poses[name] = namedPose{pose: pose, source: "poses_file"}
+		default:
+			return nil, nil, fmt.Errorf("pose %q frame must be %q or %q, got %q", name, poseFrameJoints, poseFrameBase, entry.Frame)
+		}
+	}
+
+	for name, steps := range f.Programs {
+		if len(steps) == 0 {
+			return nil, nil, fmt.Errorf("program %q has no steps", name)
+		}
+		for i, step := range steps {
+			if _, ok := poses[step.Pose]; !ok {
+				return nil, nil, fmt.Errorf("program %q step %d uses unknown pose %q", name, i+1, step.Pose)
+			}
+			if step.SpeedDegsPerSec < 0 {
+				return nil, nil, fmt.Errorf("program %q step %d speed_degs_per_sec must not be negative", name, i+1)
+			}
+		}
+	}
+	return poses, f.Programs, nil
+}
+
+// exportPoseFile writes poses and programs as a pose file, joint poses in degrees
+func exportPoseFile(path string, jointNames []string, poses map[string]namedPose, programs map[string][]ProgramStep) error {
+	file := PoseFile{
+		Version:  poseFileVersion,
+		Units:    trajectoryUnitsDegs,
+		Joints:   jointNames,
+		Poses:    map[string]PoseEntry{},
+		Programs: programs,
+	}
+	for name, p := range poses {
+		if p.pose == nil {
+			file.Poses[name] = PoseEntry{Frame: poseFrameJoints, Joints: p.jointsDegs}
+			continue
+		}
+		point := p.pose.Point()
+		ov := p.pose.Orientation().OrientationVectorDegrees()
+		file.Poses[name] = PoseEntry{
+			Frame:       poseFrameBase,
+			PositionMM:  []float64{point.X, point.Y, point.Z},
+			Orientation: &PoseOrientation{OX: ov.OX, OY: ov.OY, OZ: ov.OZ, Theta: ov.Theta},
+		}
+	}
+	data, err := yaml.Marshal(&file)
+	if err != nil {
+		return fmt.Errorf("failed to encode poses: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write poses file: %w", err)
+	}
+	return nil
+}
+
+// loadPoses combines the poses attribute with poses_file, a pose in both is an error so it's
+// never unclear which one goto_pose uses
+func (s *so101) loadPoses() error {
+	poses, programs, err := resolvePoses(s.config())
+	if err != nil {
+		return err
+	}
+	s.setPoses(poses, programs)
+	return nil
+}
+
+func resolvePoses(conf *SO101ArmConfig) (map[string]namedPose, map[string][]ProgramStep, error) {
+	poses := map[string]namedPose{}
+	for name, degs := range conf.Poses {
+		poses[name] = namedPose{jointsDegs: degs, source: "poses"}
+	}
+	var programs map[string][]ProgramStep
+	if conf.PosesFile != "" {
+		jointNames := make([]string, len(conf.ServoIDs))
+		for i, id := range conf.ServoIDs {
+			jointNames[i] = jointNameForServo(id)
+		}
+		filePoses, filePrograms, err := loadPoseFile(conf.PosesFile, jointNames)
+		if err != nil {
+			return nil, nil, err
+		}
+		for name, pose := range filePoses {
+			if _, ok := poses[name]; ok {
+				return nil, nil, fmt.Errorf("pose %q is in both poses and %s", name, conf.PosesFile)
+			}
+			poses[name] = pose
+		}
+		programs = filePrograms
+	}
+	return poses, programs, nil
+}
+
+func (s *so101) setPoses(poses map[string]namedPose, programs map[string][]ProgramStep) {
+	s.posesMu.Lock()
+	defer s.posesMu.Unlock()
+	s.poses = poses
+	s.programs = programs
+}
+
+// lookupPose returns a named pose
+func (s *so101) lookupPose(name string) (namedPose, bool) {
+	s.posesMu.RLock()
+	defer s.posesMu.RUnlock()
+	pose, ok := s.namedPoses()[name]
+	return pose, ok
+}
+
+// poseJointPositions returns the joint positions in radians to move to for a pose
+func (s *so101) poseJointPositions(ctx context.Context, pose namedPose, extra map[string]interface{}) ([]float64, error) {
+	if pose.pose == nil {
+		positions := make([]float64, len(pose.jointsDegs))
+		for i, deg := range pose.jointsDegs {
+			positions[i] = DegreesToRadians(deg)
+		}
+		return positions, nil
+	}
+	return s.solveLocalIK(ctx, pose.pose, extra)
+}
+
+// importPoses handles the import_poses DoCommand, reloading poses_file
+func (s *so101) importPoses() (map[string]interface{}, error) {
+	if s.config().PosesFile == "" {
+		return map[string]interface{}{"success": false, "error": "no poses_file configured"}, nil
+	}
+	if err := s.loadPoses(); err != nil {
+		return map[string]interface{}{"success": false, "error": err.Error()}, nil
+	}
+	result := s.listPoses()
+	result["success"] = true
+	return result, nil
+}
+
+// exportPoses handles the export_poses DoCommand
+func (s *so101) exportPoses(cmd map[string]interface{}) (map[string]interface{}, error) {
+	path, ok := cmd["path"].(string)
+	if !ok || path == "" {
+		return nil, errors.New("export_poses requires 'path' string parameter")
+	}
+	s.posesMu.RLock()
+	poses := s.namedPoses()
+	err := exportPoseFile(path, s.armJointNames(), poses, s.programs)
+	count := len(poses)
+	s.posesMu.RUnlock()
+	if err != nil {
+		return map[string]interface{}{"success": false, "error": err.Error()}, nil
+	}
+	return map[string]interface{}{"success": true, "path": path, "poses": count}, nil
+}
+
+// runProgram handles the run_program DoCommand, moving through a program's poses in order
+func (s *so101) runProgram(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["name"].(string)
+	if !ok {
+		return nil, errors.New("run_program requires 'name' string parameter")
+	}
+	s.posesMu.RLock()
+	steps, ok := s.programs[name]
+	names := make([]string, 0, len(s.programs))
+	for program := range s.programs {
+		names = append(names, program)
+	}
+	s.posesMu.RUnlock()
+	if !ok {
+		sort.Strings(names)
+		return map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("unknown program %q, programs are %v", name, names),
+		}, nil
+	}
+
+	for i, step := range steps {
+		stepCmd := map[string]interface{}{"name": step.Pose}
+		if step.SpeedDegsPerSec > 0 {
+			stepCmd["speed_degs_per_sec"] = step.SpeedDegsPerSec
+		}
+		if _, err := s.gotoPose(ctx, stepCmd); err != nil {
+			return map[string]interface{}{
+				"success":         false,
+				"program":         name,
+				"steps_completed": i,
+				"error":           fmt.Sprintf("step %d (%s): %v", i+1, step.Pose, err),
+			}, nil
+		}
+	}
+	return map[string]interface{}{"success": true, "program": name, "steps_completed": len(steps)}, nil
+}
@@ -0,0 +1,75 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testArmJoints = []string{"shoulder_pan", "shoulder_lift", "elbow_flex", "wrist_flex", "wrist_roll"}
+
+func TestPoseFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "poses.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+version: 1
+joints: [wrist_roll, wrist_flex, elbow_flex, shoulder_lift, shoulder_pan]
+poses:
+  home: [5, 60, 90, -90, 0]
+  above_bin:
+    frame: base
+    position_mm: [250, 0, 120]
+    orientation_degs: {ox: 0, oy: 0, oz: -1, theta: 0}
+programs:
+  pick:
+    - pose: above_bin
+    - pose: home
+      speed_degs_per_sec: 30
+`), 0o644))
+
+	poses, programs, err := loadPoseFile(path, testArmJoints)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0, -90, 90, 60, 5}, poses["home"].jointsDegs)
+	assert.Equal(t, 250.0, poses["above_bin"].pose.Point().X)
+	assert.Equal(t, []ProgramStep{{Pose: "above_bin"}, {Pose: "home", SpeedDegsPerSec: 30}}, programs["pick"])
+
+	exported := filepath.Join(dir, "exported.yaml")
+	assert.NoError(t, exportPoseFile(exported, testArmJoints, poses, programs))
+	again, againPrograms, err := loadPoseFile(exported, testArmJoints)
+	assert.NoError(t, err)
+	assert.Equal(t, poses["home"].jointsDegs, again["home"].jointsDegs)
+	assert.InDelta(t, 120.0, again["above_bin"].pose.Point().Z, 1e-9)
+	assert.Equal(t, programs, againPrograms)
+
+	for name, content := range map[string]string{
+		"version":      "version: 2\n",
+		"units":        "version: 1\nunits: turns\n",
+		"frame":        "version: 1\nposes:\n  a: {frame: tool, joints: [0, 0, 0, 0, 0]}\n",
+		"length":       "version: 1\nposes:\n  a: [0, 0]\n",
+		"base":         "version: 1\nposes:\n  a: {frame: base, position_mm: [1, 2]}\n",
+		"missing":      "version: 1\njoints: [shoulder_pan]\nposes:\n  a: [0]\n",
+		"program pose": "version: 1\nposes:\n  a: [0, 0, 0, 0, 0]\nprograms:\n  p: [{pose: b}]\n",
+	} {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		_, _, err := loadPoseFile(path, testArmJoints)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadPoses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "poses.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("version: 1\nunits: radians\nposes:\n  ready: [0, 0, 0, 0, 3.141592653589793]\n"), 0o644))
+
+	cfg := &SO101ArmConfig{ServoIDs: []int{1, 2, 3, 4, 5}, PosesFile: path, Poses: map[string][]float64{"home": {0, -90, 90, 60, 0}}}
+	arm := &so101{cfg: cfg, armServoIDs: []int{1, 2, 3, 4, 5}}
+	assert.NoError(t, arm.loadPoses())
+	assert.Equal(t, []string{"home", "ready"}, arm.poseNames())
+	ready, ok := arm.lookupPose("ready")
+	assert.True(t, ok)
+	assert.InDelta(t, 180.0, ready.jointsDegs[4], 1e-9)
+
+	cfg.Poses["ready"] = []float64{0, 0, 0, 0, 0}
+	assert.Error(t, arm.loadPoses())
+}
@@ -0,0 +1,102 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// gotoPose handles the goto_pose DoCommand, moving to a pose from poses or poses_file by name.
+// speed_degs_per_sec replaces the configured speed and speed_percent scales it, as for moves.
+func (s *so101) gotoPose(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("goto_pose requires 'name' string parameter")
+	}
+	pose, ok := s.lookupPose(name)
+	if !ok {
+		return map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("unknown pose %q, configured poses are %v", name, s.poseNames()),
+		}, nil
+	}
+
+	defaults := s.defaultMotionParams()
+	if v, ok := cmd["speed_degs_per_sec"].(float64); ok {
+		if v <= 0 {
+			return nil, fmt.Errorf("speed_degs_per_sec must be positive, got %.1f", v)
+		}
+		defaults.SpeedDegsPerSec = v
+	}
+	params, err := resolveMotionParams(defaults, nil, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.poseJointPositions(ctx, pose, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve pose %q: %w", name, err)
+	}
+	degs := make([]float64, len(positions))
+	for i, rad := range positions {
+		degs[i] = RadiansToDegrees(rad)
+	}
+	if err := s.moveWithParams(ctx, positions, params, "goto_pose"); err != nil {
+		return nil, fmt.Errorf("failed to move to pose %q: %w", name, err)
+	}
+	return map[string]interface{}{
+		"success":            true,
+		"pose":               name,
+		"positions_degs":     degs,
+		"speed_degs_per_sec": params.SpeedDegsPerSec,
+	}, nil
+}
+
+// listPoses handles the list_poses DoCommand
+func (s *so101) listPoses() map[string]interface{} {
+	s.posesMu.RLock()
+	defer s.posesMu.RUnlock()
+	poses := map[string]interface{}{}
+	for name, pose := range s.namedPoses() {
+		if pose.pose == nil {
+			poses[name] = pose.jointsDegs
+		} else {
+			poses[name] = pose.toMap()
+		}
+	}
+	programs := map[string]interface{}{}
+	for name, steps := range s.programs {
+		stepNames := make([]interface{}, len(steps))
+		for i, step := range steps {
+			stepNames[i] = step.Pose
+		}
+		programs[name] = stepNames
+	}
+	return map[string]interface{}{"poses": poses, "programs": programs}
+}
+
+func (s *so101) poseNames() []string {
+	s.posesMu.RLock()
+	defer s.posesMu.RUnlock()
+	poses := s.namedPoses()
+	names := make([]string, 0, len(poses))
+	for name := range poses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namedPoses returns the loaded poses, or the poses attribute alone before loadPoses has run.
+// Callers hold posesMu.
+func (s *so101) namedPoses() map[string]namedPose {
+	if s.poses != nil {
+		return s.poses
+	}
+	configured := s.config().Poses
+	poses := make(map[string]namedPose, len(configured))
+	for name, degs := range configured {
+		poses[name] = namedPose{jointsDegs: degs, source: "poses"}
+	}
+	return poses
+}
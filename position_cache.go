@@ -0,0 +1,174 @@
+package so_arm
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// maxPositionPollHz bounds position_poll_hz, faster than this the poller would crowd moves
+// and other reads off the bus
+const maxPositionPollHz = 200
+
+// A cached position older than this many poll periods is ignored and the servos are read
+// directly, so a stalled poller never serves stale joints
+const positionCacheMaxAgePeriods = 3
+
+// positionPoller keeps the latest raw position of each polled servo, shared by every
+// component on a port
+type positionPoller struct {
+	mu        sync.Mutex
+	interval  time.Duration
+	ids       map[int]bool
+	positions feetech.PositionMap
+	readAt    map[int]time.Time
+	failures  int64
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// start begins polling ids every interval with read, or adds ids to a running poller and
+// speeds it up if interval is shorter
+func (p *positionPoller) start(interval time.Duration, ids []int, read func(context.Context, []int) (feetech.PositionMap, map[int]error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ids == nil {
+		p.ids = map[int]bool{}
+		p.positions = feetech.PositionMap{}
+		p.readAt = map[int]time.Time{}
+	}
+	for _, id := range ids {
+		p.ids[id] = true
+	}
+	if p.cancel != nil {
+		p.interval = min(p.interval, interval)
+		return
+	}
+	p.interval = interval
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx, read, p.done)
+}
+
+func (p *positionPoller) run(ctx context.Context, read func(context.Context, []int) (feetech.PositionMap, map[int]error), done chan struct{}) {
+	defer close(done)
+	for {
+		p.mu.Lock()
+		interval := p.interval
+		ids := make([]int, 0, len(p.ids))
+		for id := range p.ids {
+			ids = append(ids, id)
+		}
+		p.mu.Unlock()
+		sort.Ints(ids)
+
+		positions, failed := read(ctx, ids)
+		now := time.Now()
+		p.mu.Lock()
+		if ctx.Err() != nil {
+			p.mu.Unlock()
+			return
+		}
+		for id, pos := range positions {
+			p.positions[id] = pos
+			p.readAt[id] = now
+		}
+		p.failures += int64(len(failed))
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// stop ends polling and drops the cache
+func (p *positionPoller) stop() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	done := p.done
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.cancel, p.done = nil, nil
+	p.ids, p.positions, p.readAt = nil, nil, nil
+	p.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// get returns cached positions for ids if every one of them is fresh
+func (p *positionPoller) get(ids []int) (feetech.PositionMap, bool) {
+	if p == nil {
+		return nil, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel == nil {
+		return nil, false
+	}
+	maxAge := positionCacheMaxAgePeriods * p.interval
+	positions := make(feetech.PositionMap, len(ids))
+	for _, id := range ids {
+		pos, ok := p.positions[id]
+		if !ok || time.Since(p.readAt[id]) > maxAge {
+			return nil, false
+		}
+		positions[id] = pos
+	}
+	return positions, true
+}
+
+func (p *positionPoller) status() map[string]interface{} {
+	if p == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	ages := map[string]interface{}{}
+	for id, at := range p.readAt {
+		ages[jointNameForServo(id)] = time.Since(at).Milliseconds()
+	}
+	return map[string]interface{}{
+		"enabled":       true,
+		"rate_hz":       float64(time.Second) / float64(p.interval),
+		"age_ms":        ages,
+		"read_failures": p.failures,
+	}
+}
+
+// StartPositionPolling reads servoIDs in the background at rateHz, so position reads are
+// served from the cache instead of a bus round trip
+func (s *SafeSoArmController) StartPositionPolling(rateHz float64, servoIDs []int) {
+	if s.poller == nil || rateHz <= 0 {
+		return
+	}
+	s.poller.start(time.Duration(float64(time.Second)/rateHz), servoIDs, func(ctx context.Context, ids []int) (feetech.PositionMap, map[int]error) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.readPositionsPartialLocked(ctx, ids)
+	})
+}
+
+// StopPositionPolling stops the background reads, later reads go to the bus again
+func (s *SafeSoArmController) StopPositionPolling() {
+	s.poller.stop()
+}
+
+// PositionCacheStatus reports whether the position cache is running and how fresh it is
+func (s *SafeSoArmController) PositionCacheStatus() map[string]interface{} {
+	return s.poller.status()
+}
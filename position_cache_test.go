@@ -0,0 +1,50 @@
+package so_arm
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionPoller(t *testing.T) {
+	var reads atomic.Int64
+	read := func(ctx context.Context, ids []int) (feetech.PositionMap, map[int]error) {
+		reads.Add(1)
+		positions := feetech.PositionMap{}
+		for _, id := range ids {
+			positions[id] = 2000 + id
+		}
+		return positions, map[int]error{}
+	}
+
+	var nilPoller *positionPoller
+	_, ok := nilPoller.get([]int{1})
+	assert.False(t, ok)
+	assert.Equal(t, false, nilPoller.status()["enabled"])
+
+	p := &positionPoller{}
+	p.start(5*time.Millisecond, []int{1, 2}, read)
+	assert.Eventually(t, func() bool {
+		_, ok := p.get([]int{1, 2})
+		return ok
+	}, time.Second, time.Millisecond)
+
+	positions, ok := p.get([]int{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, 2001, positions[1])
+	// Servos that aren't polled miss the cache
+	_, ok = p.get([]int{1, 3})
+	assert.False(t, ok)
+	assert.Equal(t, true, p.status()["enabled"])
+
+	p.stop()
+	_, ok = p.get([]int{1, 2})
+	assert.False(t, ok)
+	stopped := reads.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, reads.Load())
+}
@@ -0,0 +1,62 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// present_voltage is in 0.1 V and present_current in steps of 6.5 mA, with bit 15 as the
+// direction flag
+const (
+	voltageUnitVolts     = 0.1
+	currentUnitMilliamps = 6.5
+)
+
+// powerStatus reads the supply voltage and current draw of each arm servo. Voltage sagging
+// under load is the first sign of a weak battery or supply, well before servos start resetting.
+func (s *so101) powerStatus(ctx context.Context) map[string]interface{} {
+	joints := map[string]interface{}{}
+	errs := []string{}
+	minVolts, maxVolts := math.Inf(1), math.Inf(-1)
+	totalMilliamps := 0.0
+
+	for _, id := range s.servoIDs() {
+		entry := map[string]interface{}{}
+		if data, err := s.controller.ReadServoRegister(ctx, id, "present_voltage"); err != nil {
+			errs = append(errs, fmt.Sprintf("servo %d voltage: %v", id, err))
+		} else if len(data) > 0 {
+			volts := float64(data[0]) * voltageUnitVolts
+			entry["voltage_v"] = volts
+			minVolts = math.Min(minVolts, volts)
+			maxVolts = math.Max(maxVolts, volts)
+		}
+
+		if data, err := s.controller.ReadServoRegister(ctx, id, "present_current"); err != nil {
+			errs = append(errs, fmt.Sprintf("servo %d current: %v", id, err))
+		} else if len(data) >= 2 {
+			milliamps := float64(int(s.controller.protocolFor(id).DecodeWord(data))&0x7FFF) * currentUnitMilliamps
+			entry["current_ma"] = milliamps
+			totalMilliamps += milliamps
+		}
+		joints[jointNameForServo(id)] = entry
+	}
+
+	result := map[string]interface{}{
+		"joints":           joints,
+		"total_current_ma": totalMilliamps,
+		"errors":           errs,
+	}
+	if !math.IsInf(minVolts, 0) {
+		result["min_voltage_v"] = minVolts
+		result["max_voltage_v"] = maxVolts
+		if threshold := s.config().LowVoltageWarningV; threshold > 0 {
+			low := minVolts < threshold
+			result["low_voltage"] = low
+			if low {
+				s.logs.Warnf("low-voltage", "Servo supply is at %.1fV, below low_voltage_warning_v %.1fV", minVolts, threshold)
+			}
+		}
+	}
+	return result
+}
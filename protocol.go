@@ -0,0 +1,69 @@
+package so_arm
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Bus protocols for the protocol attribute. STS (SO-101's STS3215) is little-endian and
+// supports sync read, SCS is big-endian and doesn't.
+const (
+	protocolSTS = "sts"
+	protocolSCS = "scs"
+)
+
+// scsProtocol encodes words for SCS servos on an STS bus
+var scsProtocol = feetech.NewProtocol(feetech.ProtocolSCS)
+
+// busProtocol maps the protocol attribute to the feetech protocol version
+func busProtocol(name string) (int, error) {
+	switch name {
+	case "", protocolSTS:
+		return feetech.ProtocolSTS, nil
+	case protocolSCS:
+		return feetech.ProtocolSCS, nil
+	default:
+		return 0, fmt.Errorf("protocol must be \"sts\" or \"scs\", got %q", name)
+	}
+}
+
+// validateBusProtocol checks the protocol and scs_servo_ids attributes together
+func validateBusProtocol(protocol string, scsServoIDs []int) error {
+	if _, err := busProtocol(protocol); err != nil {
+		return err
+	}
+	if len(scsServoIDs) > 0 && protocol == protocolSCS {
+		return fmt.Errorf("scs_servo_ids only applies when protocol is \"sts\", every servo already uses SCS")
+	}
+	for _, id := range scsServoIDs {
+		if id < 1 || id > 253 {
+			return fmt.Errorf("scs_servo_ids must be between 1 and 253, got %d", id)
+		}
+	}
+	return nil
+}
+
+// protocolsEqual compares the protocol settings of two controller configs
+func protocolsEqual(a, b *SoArm101Config) bool {
+	pa, _ := busProtocol(a.Protocol)
+	pb, _ := busProtocol(b.Protocol)
+	idsA := slices.Sorted(slices.Values(a.SCSServoIDs))
+	idsB := slices.Sorted(slices.Values(b.SCSServoIDs))
+	return pa == pb && slices.Equal(idsA, idsB)
+}
+
+// isSCSServo reports whether a servo speaks SCS, either on an SCS bus or listed in
+// scs_servo_ids on an STS bus
+func (s *SafeSoArmController) isSCSServo(servoID int) bool {
+	return s.bus.Protocol().Version() == feetech.ProtocolSCS || s.scsServos[servoID]
+}
+
+// protocolFor returns the word encoding a servo uses
+func (s *SafeSoArmController) protocolFor(servoID int) *feetech.Protocol {
+	if s.scsServos[servoID] {
+		return scsProtocol
+	}
+	return s.bus.Protocol()
+}
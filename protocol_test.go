@@ -0,0 +1,35 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusProtocolConfig(t *testing.T) {
+	version, err := busProtocol("")
+	assert.NoError(t, err)
+	assert.Equal(t, feetech.ProtocolSTS, version)
+	version, err = busProtocol(protocolSCS)
+	assert.NoError(t, err)
+	assert.Equal(t, feetech.ProtocolSCS, version)
+	_, err = busProtocol("dynamixel")
+	assert.Error(t, err)
+
+	assert.NoError(t, validateBusProtocol(protocolSTS, []int{6}))
+	assert.Error(t, validateBusProtocol(protocolSCS, []int{6}))
+	assert.Error(t, validateBusProtocol("", []int{254}))
+
+	// Components sharing a port must agree, "" and "sts" are the same
+	a := &SoArm101Config{Port: "/dev/ttyUSB0", SCSServoIDs: []int{6, 5}}
+	b := &SoArm101Config{Port: "/dev/ttyUSB0", Protocol: protocolSTS, SCSServoIDs: []int{5, 6}}
+	assert.True(t, configsEqual(a, b))
+	b.SCSServoIDs = []int{6}
+	assert.False(t, configsEqual(a, b))
+	assert.Contains(t, compareConfigs(a, b), "protocol")
+
+	cfg := &SO101ArmConfig{Port: "/dev/ttyUSB0", Protocol: "scs", SCSServoIDs: []int{1}}
+	_, _, err = cfg.Validate("")
+	assert.Error(t, err)
+}
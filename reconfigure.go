@@ -0,0 +1,330 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"go.viam.com/rdk/resource"
+)
+
+// liveArmConfig returns conf with the attributes Reconfigure applies in place copied from
+// old. If the result differs from old, something changed that needs the arm rebuilt: the
+// port settings, dependencies, or state kept by a monitor such as collision detection.
+func liveArmConfig(old, conf *SO101ArmConfig) SO101ArmConfig {
+	live := *conf
+	live.SpeedDegsPerSec = old.SpeedDegsPerSec
+	live.AccelerationDegsPerSec = old.AccelerationDegsPerSec
+	live.ServoIDs = old.ServoIDs
+	live.CalibrationFile = old.CalibrationFile
+	live.CalibrationProfilesDir = old.CalibrationProfilesDir
+	live.CalibrationProfile = old.CalibrationProfile
+	live.RequireCalibrationFile = old.RequireCalibrationFile
+	live.CalibrationMismatch = old.CalibrationMismatch
+	live.OnCancel = old.OnCancel
+	live.ParkPose = old.ParkPose
+	live.Poses = old.Poses
+	live.PosesFile = old.PosesFile
+	live.ShutdownBehavior = old.ShutdownBehavior
+	live.IKSolver = old.IKSolver
+	live.IKSeedDegs = old.IKSeedDegs
+	live.IKOrientationToleranceDegs = old.IKOrientationToleranceDegs
+	live.IKElbow = old.IKElbow
+	live.IsMovingSource = old.IsMovingSource
+	live.BlendRadiusDegs = old.BlendRadiusDegs
+	live.WaypointEpsilonDegs = old.WaypointEpsilonDegs
+	live.VerboseLogging = old.VerboseLogging
+	live.JointLimits = old.JointLimits
+	live.MaxTorquePercent = old.MaxTorquePercent
+	live.LowVoltageWarningV = old.LowVoltageWarningV
+	live.TorqueRampMs = old.TorqueRampMs
+	return live
+}
+
+// armNeedsRebuild reports whether changing from old to conf must reopen the arm
+func armNeedsRebuild(old, conf *SO101ArmConfig) bool {
+	if !reflect.DeepEqual(*old, liveArmConfig(old, conf)) {
+		return true
+	}
+	// The file watcher follows the file it was started with
+	return conf.WatchCalibrationFile && conf.CalibrationFile != old.CalibrationFile
+}
+
+// Reconfigure applies motion, calibration, servo and pose changes without releasing the
+// shared controller. Changes to the port settings return a must-rebuild error, so the arm is
+// closed and created again.
+func (s *so101) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	conf, err := resource.NativeConfig[*SO101ArmConfig](rawConf)
+	if err != nil {
+		return err
+	}
+	speed, acc, err := applyArmDefaults(conf)
+	if err != nil {
+		return err
+	}
+	if armNeedsRebuild(s.config(), conf) {
+		return resource.NewMustRebuildError(rawConf.ResourceName())
+	}
+
+	// Everything that can fail is checked before anything changes
+	calibrationChanged := conf.CalibrationFile != s.config().CalibrationFile
+	calibration, fromFile := s.controller.GetCalibration(), s.calibrationLoaded.Load()
+	calibrationFile := conf.CalibrationFile
+	if calibrationChanged {
+		controllerConfig := armControllerConfig(conf, s.logger)
+		calibration, fromFile = controllerConfig.LoadCalibration(s.logger)
+		calibrationFile = controllerConfig.CalibrationFile
+	}
+	if err := checkJointLimits(conf.JointLimits, calibration, conf.ServoIDs); err != nil {
+		return err
+	}
+	poses, programs, err := resolvePoses(conf)
+	if err != nil {
+		return err
+	}
+
+	// Wait for a move in progress to finish, following is stopped since it never does
+	s.follow.end()
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	old := s.config()
+	servosChanged := !slices.Equal(conf.ServoIDs, old.ServoIDs)
+	if servosChanged {
+		// State kept for the old servo IDs would otherwise apply to the wrong joints
+		s.resetServoState(ctx)
+	}
+
+	s.mu.Lock()
+	if conf.SpeedDegsPerSec != old.SpeedDegsPerSec {
+		s.defaultSpeed = speed
+	}
+	if conf.AccelerationDegsPerSec != old.AccelerationDegsPerSec {
+		s.defaultAcc = acc
+	}
+	s.cfg = conf
+	s.armServoIDs = conf.ServoIDs
+	speed, acc = s.defaultSpeed, s.defaultAcc
+	s.mu.Unlock()
+
+	s.setPoses(poses, programs)
+	if conf.VerboseLogging != old.VerboseLogging {
+		s.logs.setInterval(logRateLimitInterval(conf.VerboseLogging))
+	}
+
+	if calibrationChanged {
+		if err := ApplySharedCalibration(conf.Port, calibration); err != nil {
+			return err
+		}
+		s.calibrationLoaded.Store(fromFile)
+		if conf.RequireCalibrationFile && !fromFile {
+			s.logger.Errorf("require_calibration_file is set but %s could not be loaded, motion commands will be refused", calibrationFile)
+		}
+	}
+	if calibrationChanged || servosChanged {
+		RegisterSharedConsumer(conf.Port, ConsumerInfo{
+			Name:            s.name.ShortName(),
+			CalibrationFile: calibrationFile,
+			ServoIDs:        conf.ServoIDs,
+		})
+	}
+
+	if servosChanged {
+		if conf.PositionPollHz > 0 {
+			s.controller.StartPositionPolling(conf.PositionPollHz, conf.ServoIDs)
+		}
+		if err := s.initializeServos(); err != nil {
+			return err
+		}
+	} else if !reflect.DeepEqual(conf.MaxTorquePercent, old.MaxTorquePercent) {
+		if err := s.applyMaxTorque(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Debugf("SO-101 reconfigured in place, speed: %.1f deg/s, acceleration: %.1f deg/s², servo IDs: %v",
+		speed, acc, conf.ServoIDs)
+	return nil
+}
+
+// resetServoState undoes and forgets what the arm keeps per servo before its servos change:
+// velocity mode, compliance mode, torque limits reduced by thermal protection or a collision,
+// and cached joint reads. The caller must hold moveLock.
+func (s *so101) resetServoState(ctx context.Context) {
+	if err := s.disableCompliance(ctx); err != nil {
+		s.logger.Warnf("Failed to disable compliance mode before changing servos: %v", err)
+	}
+	if err := s.stopVelocityServos(ctx); err != nil {
+		s.logger.Warnf("Failed to stop velocity mode joints before changing servos: %v", err)
+	}
+	// Joints that failed to switch back stay tracked so a later restore retries them
+	s.restorePositionMode(ctx)
+
+	for servoID, limit := range s.thermal.reset() {
+		if err := s.controller.WriteServoRegister(ctx, servoID, "torque_limit", limit); err != nil {
+			s.logger.Warnf("Failed to restore torque limit of servo %d after thermal protection: %v", servoID, err)
+		}
+	}
+	if s.collision != nil {
+		if err := s.clearCollision(ctx); err != nil {
+			s.logger.Warnf("Failed to restore torque limits reduced by a collision: %v", err)
+		}
+		s.collision.reset()
+	}
+	if s.jointCache != nil {
+		s.jointCache.reset()
+	}
+}
+
+// servoIDs returns the arm's servo IDs. Reconfigure swaps in a new slice under mu and never
+// changes one in place, so callers can keep what they got for the rest of an operation.
+func (s *so101) servoIDs() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.armServoIDs
+}
+
+// config returns the arm's configuration. Reconfigure and set_calibration_profile replace it
+// under mu while the arm runs, so it's read through here rather than from cfg directly.
+func (s *so101) config() *SO101ArmConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+// liveGripperConfig returns conf with the attributes the gripper's Reconfigure applies in
+// place copied from old
+func liveGripperConfig(old, conf *SO101GripperConfig) SO101GripperConfig {
+	live := *conf
+	live.ServoID = old.ServoID
+	live.CalibrationFile = old.CalibrationFile
+	live.OverloadLoadPercent = old.OverloadLoadPercent
+	live.OverloadDurationSec = old.OverloadDurationSec
+	live.OverloadBackoffPercent = old.OverloadBackoffPercent
+	live.DisableOverloadBackoff = old.DisableOverloadBackoff
+	live.GrabPositionThresholdPercent = old.GrabPositionThresholdPercent
+	live.GripLoadThresholdPercent = old.GripLoadThresholdPercent
+	live.HoldTorquePercent = old.HoldTorquePercent
+	live.GrabBackoffPercent = old.GrabBackoffPercent
+	live.MaxOpeningMM = old.MaxOpeningMM
+	live.JawSwingDegs = old.JawSwingDegs
+	return live
+}
+
+// gripperNeedsRebuild reports whether changing from old to conf must reopen the gripper
+func gripperNeedsRebuild(old, conf *SO101GripperConfig) bool {
+	if !reflect.DeepEqual(*old, liveGripperConfig(old, conf)) {
+		return true
+	}
+	return conf.WatchCalibrationFile && conf.CalibrationFile != old.CalibrationFile
+}
+
+// Reconfigure applies servo ID, calibration, grab, overload and geometry changes without
+// releasing the shared controller, so the arm on the same port keeps running
+func (g *so101Gripper) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	conf, err := resource.NativeConfig[*SO101GripperConfig](rawConf)
+	if err != nil {
+		return err
+	}
+	applyGripperDefaults(conf)
+	if gripperNeedsRebuild(g.cfg, conf) {
+		return resource.NewMustRebuildError(rawConf.ResourceName())
+	}
+
+	old := g.cfg
+	calibrationChanged := conf.CalibrationFile != old.CalibrationFile
+	servoChanged := conf.ServoID != old.ServoID
+	calibration := g.controller.GetCalibration()
+	calibrationFile := g.calibrationFile
+	if calibrationChanged {
+		controllerConfig := gripperControllerConfig(conf, g.logger)
+		calibration, _ = controllerConfig.LoadCalibration(g.logger)
+		calibrationFile = controllerConfig.CalibrationFile
+	}
+	if calibrationChanged || servoChanged {
+		if calibration.Gripper == nil {
+			return fmt.Errorf("calibration has no gripper servo")
+		}
+		gripperCal := *calibration.Gripper
+		gripperCal.ID = conf.ServoID
+		calibration.Gripper = &gripperCal
+	}
+	model := g.model
+	if conf.JawSwingDegs != old.JawSwingDegs {
+		if model, err = makeGripperModelFrame("so101_gripper", gripperJawSwingDegs(conf)); err != nil {
+			return err
+		}
+	}
+
+	// A hold keeps the servo squeezing with the old settings, and a move must finish first
+	g.releaseHold(ctx)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if calibrationChanged || servoChanged {
+		if err := ApplySharedCalibration(conf.Port, calibration); err != nil {
+			return err
+		}
+		RegisterSharedConsumer(conf.Port, ConsumerInfo{
+			Name:            g.name.ShortName(),
+			CalibrationFile: calibrationFile,
+			ServoIDs:        []int{conf.ServoID},
+			Gripper:         true,
+		})
+	}
+
+	g.cfg = conf
+	g.servoID = conf.ServoID
+	g.calibrationFile = calibrationFile
+	g.model = model
+	g.applySettings(conf)
+
+	g.logger.Debugf("SO-101 gripper reconfigured in place with servo ID %d", conf.ServoID)
+	return nil
+}
+
+// calibrationSensorNeedsRebuild reports whether changing from old to conf must reopen the
+// calibration sensor. Only the servo IDs and the calibration file apply in place.
+func calibrationSensorNeedsRebuild(old, conf *SO101CalibrationSensorConfig) bool {
+	live := *conf
+	live.ServoIDs = old.ServoIDs
+	live.CalibrationFile = old.CalibrationFile
+	return !reflect.DeepEqual(*old, live)
+}
+
+// Reconfigure changes the calibrated servos and where calibration is saved without releasing
+// the shared controller. Changing the servos resets a calibration in progress.
+func (cs *so101CalibrationSensor) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	conf, err := resource.NativeConfig[*SO101CalibrationSensorConfig](rawConf)
+	if err != nil {
+		return err
+	}
+	applyCalibrationSensorDefaults(conf)
+	if calibrationSensorNeedsRebuild(cs.cfg, conf) {
+		return resource.NewMustRebuildError(rawConf.ResourceName())
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.setupInProgress && !slices.Equal(conf.ServoIDs, cs.cfg.ServoIDs) {
+		return fmt.Errorf("cannot change servo_ids while motor setup is in progress")
+	}
+	if !slices.Equal(conf.ServoIDs, cs.cfg.ServoIDs) {
+		if cs.recordingCancel != nil {
+			cs.recordingCancel()
+			cs.recordingCancel = nil
+		}
+		cs.recordingActive = false
+		cs.joints = newJointCalibrationData(conf.ServoIDs, cs.servoNames)
+		cs.positionHistory = nil
+		cs.failedWrites = nil
+		cs.activeJoint = 0
+		cs.setState(StateIdle, "Servo configuration changed, calibration was reset. Use DoCommand with 'start' to begin.")
+	}
+	cs.cfg = conf
+
+	cs.logger.Infof("SO-101 calibration sensor reconfigured for servos: %v, saving to %s", conf.ServoIDs, conf.CalibrationFile)
+	return nil
+}
@@ -0,0 +1,107 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+func TestArmNeedsRebuild(t *testing.T) {
+	old := &SO101ArmConfig{Port: "/dev/ttyUSB0", Baudrate: 1000000, ServoIDs: []int{1, 2, 3, 4, 5}, SpeedDegsPerSec: 50}
+
+	conf := *old
+	conf.SpeedDegsPerSec = 90
+	conf.ServoIDs = []int{1, 2, 3}
+	conf.CalibrationFile = "other.json"
+	conf.Poses = map[string][]float64{"home": {0, 0, 0}}
+	assert.False(t, armNeedsRebuild(old, &conf))
+
+	conf.WatchCalibrationFile = true
+	old.WatchCalibrationFile = true
+	assert.True(t, armNeedsRebuild(old, &conf))
+
+	for name, change := range map[string]func(*SO101ArmConfig){
+		"port":      func(c *SO101ArmConfig) { c.Port = "/dev/ttyUSB1" },
+		"baudrate":  func(c *SO101ArmConfig) { c.Baudrate = 500000 },
+		"motion":    func(c *SO101ArmConfig) { c.Motion = "planner" },
+		"collision": func(c *SO101ArmConfig) { c.CollisionLoadPercent = 80 },
+	} {
+		conf := *old
+		change(&conf)
+		assert.True(t, armNeedsRebuild(old, &conf), name)
+	}
+}
+
+func TestReconfigureInPlace(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	name := arm.Named("arm")
+	old := &SO101ArmConfig{Port: "/dev/ttyUSB0"}
+	speed, acc, err := applyArmDefaults(old)
+	assert.NoError(t, err)
+	s := &so101{
+		name:         name,
+		cfg:          old,
+		logger:       logger,
+		logs:         newRateLimitedLogger(logger, logRateLimitInterval(false)),
+		controller:   &SafeSoArmController{calibration: DefaultSO101FullCalibration},
+		armServoIDs:  old.ServoIDs,
+		defaultSpeed: speed,
+		defaultAcc:   acc,
+	}
+
+	conf := &SO101ArmConfig{Port: "/dev/ttyUSB0", SpeedDegsPerSec: 90, VerboseLogging: true, Poses: map[string][]float64{"home": {0, -90, 90, 60, 0}}}
+	rawConf := resource.Config{Name: name.Name, API: arm.API, ConvertedAttributes: conf}
+	assert.NoError(t, s.Reconfigure(context.Background(), nil, rawConf))
+	assert.Equal(t, float32(90), s.defaultSpeed)
+	assert.Equal(t, acc, s.defaultAcc)
+	assert.Equal(t, []string{"home"}, s.poseNames())
+	assert.Equal(t, conf, s.cfg)
+
+	conf = &SO101ArmConfig{Port: "/dev/ttyUSB0", JointLimits: map[string]JointLimit{"elbow_flex": {MinDegs: -500, MaxDegs: 0}}}
+	rawConf.ConvertedAttributes = conf
+	assert.Error(t, s.Reconfigure(context.Background(), nil, rawConf))
+	assert.Equal(t, float32(90), s.defaultSpeed)
+
+	rawConf.ConvertedAttributes = &SO101ArmConfig{Port: "/dev/ttyUSB1"}
+	assert.True(t, resource.IsMustRebuildError(s.Reconfigure(context.Background(), nil, rawConf)))
+}
+
+func TestGripperNeedsRebuild(t *testing.T) {
+	old := &SO101GripperConfig{Port: "/dev/ttyUSB0", Baudrate: 1000000, ServoID: 6}
+
+	conf := *old
+	conf.ServoID = 5
+	conf.CalibrationFile = "other.json"
+	conf.GripLoadThresholdPercent = 40
+	conf.JawSwingDegs = 80
+	assert.False(t, gripperNeedsRebuild(old, &conf))
+
+	conf.Port = "/dev/ttyUSB1"
+	assert.True(t, gripperNeedsRebuild(old, &conf))
+}
+
+func TestCalibrationSensorReconfigure(t *testing.T) {
+	old := &SO101CalibrationSensorConfig{Port: "/dev/ttyUSB0"}
+	applyCalibrationSensorDefaults(old)
+	cs := &so101CalibrationSensor{
+		logger:     logging.NewTestLogger(t),
+		cfg:        old,
+		state:      StateHomingPosition,
+		servoNames: map[int]string{1: "shoulder_pan", 2: "shoulder_lift"},
+	}
+	cs.joints = newJointCalibrationData(old.ServoIDs, cs.servoNames)
+
+	conf := &SO101CalibrationSensorConfig{Port: "/dev/ttyUSB0", ServoIDs: []int{1, 2}, CalibrationFile: "arm.json"}
+	rawConf := resource.Config{Name: "calibration", ConvertedAttributes: conf}
+	assert.NoError(t, cs.Reconfigure(context.Background(), nil, rawConf))
+	assert.Equal(t, StateIdle, cs.state)
+	assert.Len(t, cs.joints, 2)
+	assert.Equal(t, "arm.json", cs.cfg.CalibrationFile)
+
+	rawConf.ConvertedAttributes = &SO101CalibrationSensorConfig{Port: "/dev/ttyUSB0", Baudrate: 500000}
+	assert.True(t, resource.IsMustRebuildError(cs.Reconfigure(context.Background(), nil, rawConf)))
+}
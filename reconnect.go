@@ -0,0 +1,389 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"go.bug.st/serial/enumerator"
+)
+
+// USB reconnection. The device node of a shared port is watched, and when the adapter is
+// unplugged the bus is closed. Once it's back, at the same path or at whichever path now has
+// the same USB serial number, the bus is reopened with the same settings and every component
+// on the port gets to restore its servo configuration.
+const (
+	reconnectPollInterval   = time.Second
+	reconnectRestoreTimeout = 10 * time.Second
+)
+
+// portMonitor tracks the connection of one shared port
+type portMonitor struct {
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu             sync.Mutex
+	watched        bool // a watchConnection goroutine polls the port
+	connected      bool
+	devicePath     string
+	usbSerial      string
+	device         os.FileInfo // device node the bus was opened on, to spot a quick replug
+	reconnects     int
+	lastDisconnect time.Time
+	lastReconnect  time.Time
+	lastErr        error
+	handlers       map[string]func(context.Context)
+
+	// Secondary port held in standby, see failover.go
+	standbyPath  string
+	standbyErr   error
+	failovers    int
+	lastFailover time.Time
+}
+
+func newPortMonitor(devicePath string) *portMonitor {
+	m := &portMonitor{
+		done:       make(chan struct{}),
+		connected:  true,
+		devicePath: devicePath,
+		usbSerial:  usbSerialForPort(devicePath),
+		handlers:   map[string]func(context.Context){},
+	}
+	m.device, _ = os.Stat(devicePath)
+	return m
+}
+
+// monitoredPort reports whether a port is a device node that can disappear and come back
+func monitoredPort(port string) bool {
+	return strings.HasPrefix(port, "/dev/")
+}
+
+// stop ends monitoring, it doesn't wait for a reconnect in progress
+func (m *portMonitor) stop() {
+	if m == nil {
+		return
+	}
+	m.closeOnce.Do(func() { close(m.done) })
+}
+
+// startWatching marks the port as watched, it returns false if a watcher already runs
+func (m *portMonitor) startWatching() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.watched {
+		return false
+	}
+	m.watched = true
+	return true
+}
+
+func (m *portMonitor) stopped() bool {
+	select {
+	case <-m.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// deviceGone reports whether the device node the bus was opened on has been removed or
+// replaced by a new one
+func (m *portMonitor) deviceGone() bool {
+	m.mu.Lock()
+	path, opened := m.devicePath, m.device
+	m.mu.Unlock()
+	// A port without a device node, such as COM3, can only fail over
+	if opened == nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+	return !os.SameFile(opened, info)
+}
+
+// resolveDevice returns the path to reopen: the original one if it exists, otherwise the
+// port whose adapter has the original USB serial number
+func (m *portMonitor) resolveDevice() (string, bool) {
+	m.mu.Lock()
+	path, serial := m.devicePath, m.usbSerial
+	m.mu.Unlock()
+	if _, err := os.Stat(path); err == nil {
+		return path, true
+	}
+	if serial == "" {
+		return "", false
+	}
+	ports, err := enumerator.GetDetailedPortsList()
+	if err != nil {
+		return "", false
+	}
+	moved := portWithUSBSerial(ports, serial)
+	return moved, moved != ""
+}
+
+// portWithUSBSerial finds the port of the USB adapter with the given serial number
+func portWithUSBSerial(ports []*enumerator.PortDetails, serial string) string {
+	for _, p := range ports {
+		if p.IsUSB && p.SerialNumber == serial {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+func (m *portMonitor) markDisconnected() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connected = false
+	m.lastDisconnect = time.Now()
+}
+
+func (m *portMonitor) markReconnected(devicePath string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connected = true
+	m.devicePath = devicePath
+	m.device, _ = os.Stat(devicePath)
+	m.reconnects++
+	m.lastReconnect = time.Now()
+	m.lastErr = nil
+}
+
+func (m *portMonitor) setError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastErr = err
+}
+
+func (m *portMonitor) isConnected() bool {
+	if m == nil {
+		return true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.connected
+}
+
+func (m *portMonitor) status() map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{"monitored": false, "connected": true}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.watched {
+		return map[string]interface{}{"monitored": false, "connected": true}
+	}
+	result := map[string]interface{}{
+		"monitored":  true,
+		"connected":  m.connected,
+		"device":     m.devicePath,
+		"usb_serial": m.usbSerial,
+		"reconnects": m.reconnects,
+	}
+	if !m.lastDisconnect.IsZero() {
+		result["last_disconnect"] = m.lastDisconnect.Format(time.RFC3339)
+	}
+	if !m.lastReconnect.IsZero() {
+		result["last_reconnect"] = m.lastReconnect.Format(time.RFC3339)
+	}
+	if m.lastErr != nil {
+		result["last_error"] = m.lastErr.Error()
+	}
+	if m.standbyPath != "" || m.failovers > 0 {
+		result["standby_port"] = m.standbyPath
+		result["failovers"] = m.failovers
+	}
+	if m.standbyErr != nil {
+		result["standby_error"] = m.standbyErr.Error()
+	}
+	if !m.lastFailover.IsZero() {
+		result["last_failover"] = m.lastFailover.Format(time.RFC3339)
+	}
+	return result
+}
+
+// runHandlers calls every reconnect handler, the entry lock must not be held
+func (m *portMonitor) runHandlers() {
+	m.mu.Lock()
+	handlers := make([]func(context.Context), 0, len(m.handlers))
+	for _, fn := range m.handlers {
+		handlers = append(handlers, fn)
+	}
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconnectRestoreTimeout)
+	defer cancel()
+	for _, fn := range handlers {
+		fn(ctx)
+	}
+}
+
+// watchConnection polls the device node of a port until the monitor is stopped
+func (r *ControllerRegistry) watchConnection(entry *ControllerEntry, m *portMonitor) {
+	ticker := time.NewTicker(reconnectPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+		if entry.failoverDue(m) {
+			if err := entry.failover(m); err != nil {
+				m.setError(err)
+				continue
+			}
+			m.runHandlers()
+			continue
+		}
+		if m.isConnected() {
+			if m.deviceGone() {
+				entry.disconnect(m)
+			}
+			continue
+		}
+		path, ok := m.resolveDevice()
+		if !ok {
+			continue
+		}
+		if err := entry.reconnect(m, path); err != nil {
+			m.setError(err)
+			continue
+		}
+		m.runHandlers()
+	}
+}
+
+// disconnect closes the bus of an unplugged port so a later reopen doesn't race the old
+// file descriptor
+func (e *ControllerEntry) disconnect(m *portMonitor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if m.stopped() || e.controller == nil {
+		return
+	}
+	m.markDisconnected()
+	if err := e.controller.bus.Close(); err != nil {
+		m.setError(err)
+	}
+	e.releaseLock()
+	if e.config != nil && e.config.Logger != nil {
+		e.config.Logger.Warnf("Serial port %s disconnected, waiting for it to come back", e.config.Port)
+	}
+}
+
+// reconnect reopens the bus on devicePath and hands it to the controller and every view
+func (e *ControllerEntry) reconnect(m *portMonitor, devicePath string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if m.stopped() || e.controller == nil {
+		return nil
+	}
+
+	lock, err := acquirePortLock(devicePath)
+	if err != nil {
+		return err
+	}
+	busConfig := e.busConfig
+	busConfig.Port = devicePath
+	bus, err := feetech.NewBus(busConfig)
+	if err != nil {
+		lock.Release()
+		return fmt.Errorf("failed to reopen serial port %s: %w", devicePath, err)
+	}
+	e.lock = lock
+	e.useBus(bus)
+	m.markReconnected(devicePath)
+	if e.config != nil && e.config.Logger != nil {
+		e.config.Logger.Infof("Serial port %s reconnected on %s", e.config.Port, devicePath)
+	}
+	return nil
+}
+
+// busServoModels returns the servos to build on a reopened bus, by ID: the servos in the old
+// group with the models they had, the calibrated arm servos, and every servo a registered
+// consumer uses, such as an aux servo or an arm with non-default servo_ids
+func busServoModels(old *feetech.ServoGroup, calibrated map[int]*CalibratedServo, consumers map[string]ConsumerInfo) map[int]*feetech.Model {
+	models := map[int]*feetech.Model{}
+	if old != nil {
+		for _, servo := range old.Servos() {
+			models[servo.ID()] = servo.Model()
+		}
+	}
+	for id := range calibrated {
+		if _, ok := models[id]; !ok {
+			models[id] = &feetech.ModelSTS3215
+		}
+	}
+	for _, consumer := range consumers {
+		for _, id := range consumer.ServoIDs {
+			if _, ok := models[id]; !ok {
+				models[id] = &feetech.ModelSTS3215
+			}
+		}
+	}
+	return models
+}
+
+// useBus rebuilds the servos on bus and hands it to the controller and every view, the entry
+// lock must be held
+func (e *ControllerEntry) useBus(bus *feetech.Bus) {
+	// ApplyCalibration can replace calibratedServos, so rebind the servos it held under the lock
+	e.controller.mu.RLock()
+	models := busServoModels(e.controller.group, e.controller.calibratedServos, e.consumers)
+	calibratedServos := maps.Clone(e.controller.calibratedServos)
+	e.controller.mu.RUnlock()
+
+	ids := slices.Sorted(maps.Keys(models))
+	rawServos := make([]*feetech.Servo, 0, len(ids))
+	for _, id := range ids {
+		servo := feetech.NewServo(bus, id, models[id])
+		rawServos = append(rawServos, servo)
+		if calibrated, ok := calibratedServos[id]; ok {
+			calibrated.setServo(servo)
+		}
+	}
+	group := feetech.NewServoGroup(bus, rawServos...)
+
+	for _, c := range append([]*SafeSoArmController{e.controller}, e.views...) {
+		c.mu.Lock()
+		c.bus = bus
+		c.group = group
+		c.mu.Unlock()
+	}
+}
+
+// OnReconnect registers fn to restore servo configuration after the port comes back, name
+// replaces an earlier handler with the same name
+func (s *SafeSoArmController) OnReconnect(name string, fn func(context.Context)) {
+	if s.connection == nil {
+		return
+	}
+	s.connection.mu.Lock()
+	defer s.connection.mu.Unlock()
+	s.connection.handlers[name] = fn
+}
+
+// RemoveReconnectHandler drops a handler registered with OnReconnect
+func (s *SafeSoArmController) RemoveReconnectHandler(name string) {
+	if s.connection == nil {
+		return
+	}
+	s.connection.mu.Lock()
+	defer s.connection.mu.Unlock()
+	delete(s.connection.handlers, name)
+}
+
+// ConnectionStatus reports whether the port is connected, how often it has reconnected, and
+// the state of the secondary port
+func (s *SafeSoArmController) ConnectionStatus() map[string]interface{} {
+	return s.connection.status()
+}
@@ -0,0 +1,97 @@
+package so_arm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.bug.st/serial/enumerator"
+)
+
+func TestPortWithUSBSerial(t *testing.T) {
+	ports := []*enumerator.PortDetails{
+		{Name: "/dev/ttyS0"},
+		{Name: "/dev/ttyACM0", IsUSB: true, SerialNumber: "5A46083062"},
+		{Name: "/dev/ttyACM1", IsUSB: true, SerialNumber: "58CD176705"},
+	}
+	assert.Equal(t, "/dev/ttyACM1", portWithUSBSerial(ports, "58CD176705"))
+	assert.Equal(t, "", portWithUSBSerial(ports, "missing"))
+}
+
+func TestPortMonitorDeviceGone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ttyACM0")
+	assert.NoError(t, os.WriteFile(path, nil, 0o600))
+	m := newPortMonitor(path)
+	assert.True(t, m.startWatching())
+	assert.False(t, m.deviceGone())
+
+	// A replug between polls leaves a new device node at the same path
+	replugged := filepath.Join(t.TempDir(), "new")
+	assert.NoError(t, os.WriteFile(replugged, nil, 0o600))
+	assert.NoError(t, os.Rename(replugged, path))
+	assert.True(t, m.deviceGone())
+
+	assert.NoError(t, os.Remove(path))
+	assert.True(t, m.deviceGone())
+	_, ok := m.resolveDevice()
+	assert.False(t, ok)
+	assert.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	resolved, ok := m.resolveDevice()
+	assert.True(t, ok)
+	assert.Equal(t, path, resolved)
+
+	m.markDisconnected()
+	assert.False(t, m.status()["connected"].(bool))
+	m.markReconnected(path)
+	assert.False(t, m.deviceGone())
+	status := m.status()
+	assert.True(t, status["connected"].(bool))
+	assert.Equal(t, 1, status["reconnects"])
+
+	m.stop()
+	m.stop()
+	assert.True(t, m.stopped())
+}
+
+func TestConnectionStatusUnmonitored(t *testing.T) {
+	controller := &SafeSoArmController{}
+	controller.OnReconnect("arm", nil)
+	assert.Equal(t, false, controller.ConnectionStatus()["monitored"])
+	assert.Equal(t, true, controller.ConnectionStatus()["connected"])
+}
+
+func TestReconnectHandlersBeforeWatching(t *testing.T) {
+	// Handlers registered before a secondary port starts the watcher are kept
+	m := newPortMonitor("COM3")
+	controller := &SafeSoArmController{connection: m}
+	restored := false
+	controller.OnReconnect("arm", func(context.Context) { restored = true })
+	assert.Equal(t, false, controller.ConnectionStatus()["monitored"])
+
+	assert.True(t, m.startWatching())
+	assert.False(t, m.startWatching())
+	assert.Equal(t, true, controller.ConnectionStatus()["monitored"])
+	m.runHandlers()
+	assert.True(t, restored)
+}
+
+func TestBusServoModels(t *testing.T) {
+	old := feetech.NewServoGroup(nil, feetech.NewServo(nil, 1, &feetech.ModelSCS15), feetech.NewServo(nil, 2, &feetech.ModelSTS3215))
+	calibrated := map[int]*CalibratedServo{1: nil, 2: nil, 3: nil}
+	consumers := map[string]ConsumerInfo{
+		"arm":     {Name: "arm", ServoIDs: []int{1, 2, 3}},
+		"aux":     {Name: "aux", ServoIDs: []int{9}},
+		"gripper": {Name: "gripper", ServoIDs: []int{16}},
+	}
+
+	models := busServoModels(old, calibrated, consumers)
+	assert.Len(t, models, 5)
+	assert.Equal(t, &feetech.ModelSCS15, models[1])
+	assert.Equal(t, &feetech.ModelSTS3215, models[3])
+	assert.Equal(t, &feetech.ModelSTS3215, models[9])
+	assert.Equal(t, &feetech.ModelSTS3215, models[16])
+}
@@ -0,0 +1,175 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Register access. read_register and write_register give support workflows one named register
+// of one servo at a time. Writes are guarded: read-only registers, and the ID and baud rate that
+// motor setup owns, are refused, values must fit the register, EEPROM registers need the
+// servo's torque off, and a write that starts motion is refused while emergency stopped.
+
+// addressingRegisters change how a servo is found on the bus, so they're left to motor setup
+var addressingRegisters = []string{"id", "baud_rate"}
+
+// lookupRegister finds a named register of the control table
+func lookupRegister(name string) (servoSetting, error) {
+	i := slices.IndexFunc(controlTableRegisters, func(s servoSetting) bool { return s.Name == name })
+	if i < 0 {
+		return servoSetting{}, fmt.Errorf("unknown register %q, see dump_registers for the register names", name)
+	}
+	return controlTableRegisters[i], nil
+}
+
+// encodeRegisterWrite checks a value for a register write and encodes it. Registers with a sign
+// bit take negative values, encoded sign-magnitude.
+func encodeRegisterWrite(setting servoSetting, value int, proto *feetech.Protocol) ([]byte, error) {
+	reg := setting.Register
+	if reg.ReadOnly {
+		return nil, fmt.Errorf("register %s is read-only", setting.Name)
+	}
+	if slices.Contains(addressingRegisters, setting.Name) {
+		return nil, fmt.Errorf("register %s changes how the servo is addressed, use the calibration sensor's motor setup commands", setting.Name)
+	}
+
+	raw := value
+	maxValue := 1<<(8*reg.Size) - 1
+	if reg.SignBit > 0 {
+		maxValue = 1<<reg.SignBit - 1
+		if value < 0 {
+			raw = -value | 1<<reg.SignBit
+		}
+	}
+	if value > maxValue || (value < 0 && (reg.SignBit == 0 || -value > maxValue)) {
+		minValue := 0
+		if reg.SignBit > 0 {
+			minValue = -maxValue
+		}
+		return nil, fmt.Errorf("value %d out of range for register %s, must be between %d and %d", value, setting.Name, minValue, maxValue)
+	}
+
+	return encodeRegisterValue(raw, reg.Size, proto), nil
+}
+
+// decodeRegisterRead decodes a register read, as a signed value for registers with a sign bit
+func decodeRegisterRead(setting servoSetting, data []byte, proto *feetech.Protocol) (raw, value int) {
+	raw = decodeRegisterValue(data, proto)
+	value = raw
+	if bit := setting.Register.SignBit; bit > 0 {
+		value = raw & (1<<bit - 1)
+		if raw&(1<<bit) != 0 {
+			value = -value
+		}
+	}
+	return raw, value
+}
+
+// registerCommandTarget reads the servo_id and register parameters of read_register and
+// write_register, allowing the arm servos and the gripper configured on the same port
+func (s *so101) registerCommandTarget(command string, cmd map[string]interface{}) (int, servoSetting, error) {
+	servoIDs := s.servoIDs()
+	id, ok := cmd["servo_id"].(float64)
+	if !ok {
+		return 0, servoSetting{}, fmt.Errorf("%s requires 'servo_id' number parameter", command)
+	}
+	servoID := int(id)
+	if gripperID, ok := s.gripperServoID(); ok {
+		servoIDs = append(slices.Clone(servoIDs), gripperID)
+	}
+	if !slices.Contains(servoIDs, servoID) {
+		return 0, servoSetting{}, fmt.Errorf("servo %d is not one of the arm or gripper servos %v", servoID, servoIDs)
+	}
+	name, ok := cmd["register"].(string)
+	if !ok {
+		return 0, servoSetting{}, fmt.Errorf("%s requires 'register' string parameter", command)
+	}
+	setting, err := lookupRegister(name)
+	return servoID, setting, err
+}
+
+// readRegister handles the read_register DoCommand
+func (s *so101) readRegister(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	servoID, setting, err := s.registerCommandTarget("read_register", cmd)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.controller.ReadServoRegisterAt(ctx, servoID, setting.Register)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s of servo %d: %w", setting.Name, servoID, err)
+	}
+	raw, value := decodeRegisterRead(setting, data, s.controller.protocolFor(servoID))
+	return map[string]interface{}{
+		"servo_id": servoID,
+		"register": setting.Name,
+		"address":  int(setting.Register.Address),
+		"size":     setting.Register.Size,
+		"raw":      raw,
+		"value":    value,
+	}, nil
+}
+
+// writeRegister handles the write_register DoCommand. EEPROM registers are written with the
+// EEPROM unlocked and locked again after. The register is read back so the response shows what
+// the servo kept.
+func (s *so101) writeRegister(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	servoID, setting, err := s.registerCommandTarget("write_register", cmd)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := cmd["value"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("write_register requires 'value' number parameter")
+	}
+	proto := s.controller.protocolFor(servoID)
+	data, err := encodeRegisterWrite(setting, int(v), proto)
+	if err != nil {
+		return nil, err
+	}
+	if startsMotion(setting.Name, data) {
+		if err := s.controller.checkMotion(); err != nil {
+			return nil, err
+		}
+	}
+
+	if setting.EEPROM {
+		torque, err := s.controller.ReadServoRegisterAt(ctx, servoID, feetech.RegTorqueEnable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read torque state of servo %d: %w", servoID, err)
+		}
+		if len(torque) > 0 && torque[0] != 0 {
+			return map[string]interface{}{
+				"success": false,
+				"error":   fmt.Sprintf("servo %d has torque enabled, disable torque before writing EEPROM register %s", servoID, setting.Name),
+			}, nil
+		}
+		if err := s.controller.WriteServoRegisterAt(ctx, servoID, feetech.RegLock, []byte{0}); err != nil {
+			return nil, fmt.Errorf("failed to unlock EEPROM: %w", err)
+		}
+		defer func() {
+			if err := s.controller.WriteServoRegisterAt(ctx, servoID, feetech.RegLock, []byte{1}); err != nil {
+				s.logger.Warnf("Failed to lock EEPROM on servo %d after writing %s: %v", servoID, setting.Name, err)
+			}
+		}()
+	}
+
+	if err := s.controller.WriteServoRegisterAt(ctx, servoID, setting.Register, data); err != nil {
+		return nil, fmt.Errorf("failed to write %s of servo %d: %w", setting.Name, servoID, err)
+	}
+	s.logger.Infof("Wrote register %s of servo %d: %d", setting.Name, servoID, int(v))
+
+	response := map[string]interface{}{
+		"success":  true,
+		"servo_id": servoID,
+		"register": setting.Name,
+		"written":  int(v),
+	}
+	if readBack, err := s.controller.ReadServoRegisterAt(ctx, servoID, setting.Register); err == nil {
+		_, value := decodeRegisterRead(setting, readBack, proto)
+		response["value"] = value
+	}
+	return response, nil
+}
@@ -0,0 +1,44 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRegisterWrite(t *testing.T) {
+	proto := feetech.NewProtocol(feetech.ProtocolSTS)
+
+	pGain, err := lookupRegister("p_gain")
+	assert.NoError(t, err)
+	data, err := encodeRegisterWrite(pGain, 16, proto)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{16}, data)
+	_, err = encodeRegisterWrite(pGain, 256, proto)
+	assert.ErrorContains(t, err, "out of range")
+	_, err = encodeRegisterWrite(pGain, -1, proto)
+	assert.ErrorContains(t, err, "out of range")
+
+	// Sign-magnitude registers take negative values
+	offset, err := lookupRegister("position_offset")
+	assert.NoError(t, err)
+	data, err = encodeRegisterWrite(offset, -100, proto)
+	assert.NoError(t, err)
+	assert.Equal(t, encodeHomingOffset(-100, proto), data)
+	raw, value := decodeRegisterRead(offset, data, proto)
+	assert.Equal(t, 100|1<<11, raw)
+	assert.Equal(t, -100, value)
+	_, err = encodeRegisterWrite(offset, 2048, proto)
+	assert.ErrorContains(t, err, "out of range")
+
+	for _, name := range []string{"id", "baud_rate", "present_position"} {
+		setting, err := lookupRegister(name)
+		assert.NoError(t, err)
+		_, err = encodeRegisterWrite(setting, 1, proto)
+		assert.Error(t, err, name)
+	}
+
+	_, err = lookupRegister("no_such_register")
+	assert.ErrorContains(t, err, "unknown register")
+}
@@ -0,0 +1,93 @@
+package so_arm
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// Register dump. dump_registers reads one servo's whole control table, EEPROM and RAM, in two
+// block reads and names every byte of it, for debugging without Feetech's own tools. Unlike
+// dump_servo_settings it also returns the addresses the register map doesn't name, and decodes
+// the sign-magnitude registers.
+
+// controlTableSize is the length of the STS control table, up to the end of present_current
+var controlTableSize = int(feetech.RegPresentCurrent.Address) + feetech.RegPresentCurrent.Size
+
+// controlTableBlocks splits the control table where RAM starts
+var controlTableBlocks = []feetech.Register{
+	{Address: 0, Size: int(feetech.RegTorqueEnable.Address)},
+	{Address: feetech.RegTorqueEnable.Address, Size: controlTableSize - int(feetech.RegTorqueEnable.Address)},
+}
+
+// controlTableRegisters are the named registers of a dump, the settings plus the firmware
+// minor version
+var controlTableRegisters = append([]servoSetting{
+	{Name: "firmware_minor_version", Register: regFirmwareMinorVersion, EEPROM: true},
+}, servoSettings...)
+
+// ReadControlTable reads a servo's control table from address 0
+func (s *SafeSoArmController) ReadControlTable(ctx context.Context, servoID int) ([]byte, error) {
+	table := make([]byte, 0, controlTableSize)
+	for _, block := range controlTableBlocks {
+		data, err := s.ReadServoRegisterAt(ctx, servoID, block)
+		if err != nil {
+			return nil, err
+		}
+		table = append(table, data...)
+	}
+	return table, nil
+}
+
+// decodeControlTable names the bytes of a control table read from address 0. Bytes outside
+// every named register are returned as address_<n>, and registers with a sign bit are decoded
+// into signed as well.
+func decodeControlTable(table []byte, proto *feetech.Protocol) (registers, signed map[string]interface{}) {
+	registers = make(map[string]interface{})
+	signed = make(map[string]interface{})
+	named := make([]bool, len(table))
+	for _, setting := range controlTableRegisters {
+		start, end := int(setting.Register.Address), int(setting.Register.Address)+setting.Register.Size
+		if end > len(table) {
+			continue
+		}
+		raw, value := decodeRegisterRead(setting, table[start:end], proto)
+		registers[setting.Name] = raw
+		if setting.Register.SignBit > 0 {
+			signed[setting.Name] = value
+		}
+		for i := start; i < end; i++ {
+			named[i] = true
+		}
+	}
+	for i, b := range table {
+		if !named[i] {
+			registers[fmt.Sprintf("address_%d", i)] = int(b)
+		}
+	}
+	return registers, signed
+}
+
+// dumpRegisters handles the dump_registers DoCommand for the arm and the calibration sensor
+func dumpRegisters(ctx context.Context, controller *SafeSoArmController, cmd map[string]interface{}) (map[string]interface{}, error) {
+	id, ok := cmd["servo_id"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("dump_registers requires 'servo_id' number parameter")
+	}
+	servoID := int(id)
+	table, err := controller.ReadControlTable(ctx, servoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read control table of servo %d: %w", servoID, err)
+	}
+	registers, signed := decodeControlTable(table, controller.protocolFor(servoID))
+	return map[string]interface{}{
+		"servo_id":  servoID,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"registers": registers,
+		"signed":    signed,
+		"raw":       hex.EncodeToString(table),
+	}, nil
+}
@@ -0,0 +1,30 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeControlTable(t *testing.T) {
+	proto := feetech.NewProtocol(feetech.ProtocolSTS)
+	table := make([]byte, controlTableSize)
+	table[0], table[1], table[2] = 3, 10, 0x5A
+	copy(table[3:], proto.EncodeWord(777))
+	copy(table[31:], encodeHomingOffset(-100, proto))
+	copy(table[56:], proto.EncodeWord(2047))
+
+	registers, signed := decodeControlTable(table, proto)
+	assert.Equal(t, 3, registers["firmware_version"])
+	assert.Equal(t, 10, registers["firmware_minor_version"])
+	assert.Equal(t, 777, registers["model_number"])
+	assert.Equal(t, 2047, registers["present_position"])
+	assert.Equal(t, 100|1<<11, registers["position_offset"])
+	assert.Equal(t, -100, signed["position_offset"])
+	assert.NotContains(t, signed, "present_position")
+
+	// Bytes the register map doesn't name are still dumped
+	assert.Equal(t, 0x5A, registers["address_2"])
+	assert.NotContains(t, registers, "address_3")
+}
@@ -3,13 +3,14 @@ package so_arm
 import (
 	"context"
 	"fmt"
-	"runtime"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/hipsterbrown/feetech-servo/feetech"
+	"go.viam.com/rdk/logging"
 )
 
 type ControllerEntry struct {
@@ -18,22 +19,35 @@ type ControllerEntry struct {
 	calibration SO101FullCalibration
 	refCount    int64 // Atomic reference counter
 	lastError   error
-	mu          sync.RWMutex
+	consumers   map[string]ConsumerInfo // resource name -> how it uses the controller
+	views       []*SafeSoArmController  // every controller handed out for this port
+	watcher     *calibrationWatcher
+	lock        *portLock // advisory lock held while the bus is open
+	busConfig   feetech.BusConfig
+	monitor     *portMonitor
+	// Open bus on secondary_port, nil without one or after failing over to it
+	standby       *standbyPort
+	failoverAfter int
+	mu            sync.RWMutex
+}
+
+// ConsumerInfo describes how a component is using a shared controller
+type ConsumerInfo struct {
+	Name            string
+	CalibrationFile string
+	ServoIDs        []int
+	// Gripper is set for a gripper component, whose ServoIDs is its one servo
+	Gripper bool
 }
 
 type ControllerRegistry struct {
 	entries map[string]*ControllerEntry // port path -> entry
 	mu      sync.RWMutex
-
-	// For backward API compatibility - track which caller uses which port
-	callerPorts map[uintptr]string // caller pointer -> port path
-	callerMu    sync.RWMutex
 }
 
 func NewControllerRegistry() *ControllerRegistry {
 	return &ControllerRegistry{
-		entries:     make(map[string]*ControllerEntry),
-		callerPorts: make(map[uintptr]string),
+		entries: make(map[string]*ControllerEntry),
 	}
 }
 
@@ -93,15 +107,29 @@ func (r *ControllerRegistry) getExistingController(entry *ControllerEntry, confi
 	}
 
 	atomic.AddInt64(&entry.refCount, 1)
-	r.trackCaller(entry.config.Port)
+	r.addStandby(entry, config)
 
-	return &SafeSoArmController{
+	view := &SafeSoArmController{
 		bus:              entry.controller.bus,
 		group:            entry.controller.group,
 		calibratedServos: entry.controller.calibratedServos,
 		logger:           config.Logger,
+		logs:             newRateLimitedLogger(config.Logger, defaultLogRateLimitInterval),
+		retry:            config.Retry,
 		calibration:      entry.calibration,
-	}, nil
+		timing:           entry.controller.timing,
+		estop:            entry.controller.estop,
+		maintenance:      entry.controller.maintenance,
+		torque:           entry.controller.torque,
+		velocityModes:    entry.controller.velocityModes,
+		grabFailures:     entry.controller.grabFailures,
+		poller:           entry.controller.poller,
+		busHealth:        entry.controller.busHealth,
+		connection:       entry.controller.connection,
+		scsServos:        entry.controller.scsServos,
+	}
+	entry.views = append(entry.views, view)
+	return view, nil
 }
 
 func (r *ControllerRegistry) createNewController(portPath string, config *SoArm101Config, calibration SO101FullCalibration, fromFile bool) (*SafeSoArmController, error) {
@@ -123,26 +151,53 @@ func (r *ControllerRegistry) createNewController(portPath string, config *SoArm1
 		config.Logger.Info("Calibration map: ", feetechCalibrations)
 	}
 
+	protocol, err := busProtocol(config.Protocol)
+	if err != nil {
+		return nil, err
+	}
 	busConfig := feetech.BusConfig{
 		Port:     config.Port,
 		BaudRate: config.Baudrate,
-		Protocol: feetech.ProtocolSTS,
+		Protocol: protocol,
 		Timeout:  config.Timeout,
 	}
 
 	if busConfig.Timeout == 0 {
-		busConfig.Timeout = time.Second
+		busConfig.Timeout = defaultBusTimeout
 	}
 	if busConfig.BaudRate == 0 {
 		busConfig.BaudRate = 1000000
 	}
 
+	lock, err := acquirePortLock(config.Port)
+	if err != nil {
+		return nil, err
+	}
+
 	bus, err := feetech.NewBus(busConfig)
 	if err != nil {
+		lock.Release()
 		entry.lastError = err
 		r.entries[portPath] = entry
 		return nil, fmt.Errorf("failed to create feetech servo bus: %w", err)
 	}
+	entry.lock = lock
+
+	// An explicit timeout wins, otherwise the timing is learned from the servos
+	timing := &busTiming{Timeout: busConfig.Timeout, CommandGap: defaultBusCommandGap}
+	if config.Timeout == 0 {
+		probeIDs := config.ServoIDs
+		if len(probeIDs) == 0 {
+			probeIDs = []int{1, 2, 3, 4, 5, 6}
+		}
+		bus, timing, err = adaptBusTiming(bus, busConfig, probeIDs, config.Logger)
+		if err != nil {
+			entry.releaseLock()
+			entry.lastError = err
+			r.entries[portPath] = entry
+			return nil, fmt.Errorf("failed to reopen feetech servo bus: %w", err)
+		}
+	}
 
 	// Create raw servo instances
 	rawServos := make(map[int]*feetech.Servo)
@@ -197,12 +252,44 @@ func (r *ControllerRegistry) createNewController(portPath string, config *SoArm1
 		}
 	}
 
+	// Reopen with the settings in use, so a reconnect keeps any adaptive timing
+	entry.busConfig = busConfig
+	entry.busConfig.Timeout = timing.Timeout
+	entry.busConfig.MinCommandGap = timing.CommandGap
+	// Every view shares the monitor from the start so reconnect handlers registered before
+	// a secondary port starts the watcher aren't lost
+	entry.monitor = newPortMonitor(config.Port)
+	entry.openStandby(config)
+
+	estop := &emergencyStop{}
+	maintenance := &maintenanceMode{}
+	torque := &torqueState{}
+	velocityModes := &velocityModes{}
+	grabFailures := &grabFailures{}
+	poller := &positionPoller{}
+	busHealth := newBusHealth()
+	scsServos := map[int]bool{}
+	for _, id := range config.SCSServoIDs {
+		scsServos[id] = true
+	}
 	entry.controller = &SafeSoArmController{
 		bus:              bus,
 		group:            group,
 		calibratedServos: calibratedServos,
 		logger:           config.Logger,
+		logs:             newRateLimitedLogger(config.Logger, defaultLogRateLimitInterval),
+		retry:            config.Retry,
 		calibration:      finalCalibration,
+		timing:           timing,
+		estop:            estop,
+		maintenance:      maintenance,
+		torque:           torque,
+		velocityModes:    velocityModes,
+		grabFailures:     grabFailures,
+		poller:           poller,
+		busHealth:        busHealth,
+		connection:       entry.monitor,
+		scsServos:        scsServos,
 	}
 	// Update entry calibration after controller creation for consistency
 	entry.calibration = finalCalibration
@@ -211,22 +298,39 @@ func (r *ControllerRegistry) createNewController(portPath string, config *SoArm1
 
 	r.entries[portPath] = entry
 
-	r.trackCaller(portPath)
-
 	if config.Logger != nil {
 		config.Logger.Debugf("Created new feetech servo bus with %d servos for port %s", len(calibratedServos), portPath)
 	}
 
-	return &SafeSoArmController{
+	view := &SafeSoArmController{
 		bus:              bus,
 		group:            group,
 		calibratedServos: calibratedServos,
 		logger:           config.Logger,
+		logs:             newRateLimitedLogger(config.Logger, defaultLogRateLimitInterval),
+		retry:            config.Retry,
 		calibration:      finalCalibration,
-	}, nil
+		timing:           timing,
+		estop:            estop,
+		maintenance:      maintenance,
+		torque:           torque,
+		velocityModes:    velocityModes,
+		grabFailures:     grabFailures,
+		poller:           poller,
+		busHealth:        busHealth,
+		connection:       entry.monitor,
+		scsServos:        scsServos,
+	}
+	entry.views = append(entry.views, view)
+	if (monitoredPort(config.Port) || config.SecondaryPort != "") && entry.monitor.startWatching() {
+		go r.watchConnection(entry, entry.monitor)
+	}
+	return view, nil
 }
 
-func (r *ControllerRegistry) ReleaseController(portPath string) {
+// ReleaseController releases one reference to the controller for a port. view is the
+// controller GetController handed out, it stops getting calibration and bus updates.
+func (r *ControllerRegistry) ReleaseController(portPath string, view *SafeSoArmController) {
 	r.mu.RLock()
 	entry, exists := r.entries[portPath]
 	r.mu.RUnlock()
@@ -238,13 +342,29 @@ func (r *ControllerRegistry) ReleaseController(portPath string) {
 	entry.mu.Lock()
 	defer entry.mu.Unlock()
 
+	if i := slices.Index(entry.views, view); i >= 0 {
+		entry.views = slices.Delete(entry.views, i, i+1)
+	}
+	if view != nil {
+		view.logs.Flush()
+	}
+
 	currentRefCount := atomic.AddInt64(&entry.refCount, -1)
 	if currentRefCount <= 0 {
-		if entry.controller != nil && entry.controller.bus != nil {
+		entry.stopWatcher()
+		entry.monitor.stop()
+		entry.closeStandby()
+		if entry.controller != nil {
+			entry.controller.poller.stop()
+			entry.controller.logs.Flush()
+		}
+		// A disconnected port's bus is already closed
+		if entry.controller != nil && entry.controller.bus != nil && entry.monitor.isConnected() {
 			if err := entry.controller.bus.Close(); err != nil && entry.config != nil && entry.config.Logger != nil {
 				entry.config.Logger.Warnf("error closing shared controller for port %s: %v", portPath, err)
 			}
 		}
+		entry.releaseLock()
 
 		r.mu.Lock()
 		delete(r.entries, portPath)
@@ -253,6 +373,7 @@ func (r *ControllerRegistry) ReleaseController(portPath string) {
 		entry.controller = nil
 		entry.config = nil
 		entry.calibration = SO101FullCalibration{}
+		entry.views = nil
 		atomic.StoreInt64(&entry.refCount, 0)
 		entry.lastError = nil
 	}
@@ -273,12 +394,22 @@ func (r *ControllerRegistry) ForceCloseController(portPath string) error {
 	entry.mu.Lock()
 	defer entry.mu.Unlock()
 
+	entry.stopWatcher()
+	entry.monitor.stop()
+	entry.closeStandby()
+
 	var err error
 	if entry.controller != nil {
-		err = entry.controller.bus.Close()
+		entry.controller.poller.stop()
+		entry.controller.logs.Flush()
+		if entry.monitor.isConnected() {
+			err = entry.controller.bus.Close()
+		}
+		entry.releaseLock()
 		entry.controller = nil
 		entry.config = nil
 		entry.calibration = SO101FullCalibration{}
+		entry.views = nil
 		atomic.StoreInt64(&entry.refCount, 0)
 		entry.lastError = nil
 	}
@@ -329,34 +460,154 @@ func (r *ControllerRegistry) GetCurrentCalibration(portPath string) SO101FullCal
 	return entry.calibration
 }
 
-func (r *ControllerRegistry) trackCaller(portPath string) {
-	pc, _, _, ok := runtime.Caller(3) // 3 levels up to get the actual caller
-	if !ok {
+// ApplyCalibration validates and applies a calibration to the controller on a port
+// and to every component sharing it
+func (r *ControllerRegistry) ApplyCalibration(portPath string, calibration SO101FullCalibration) error {
+	r.mu.RLock()
+	entry, exists := r.entries[portPath]
+	r.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("no controller for port %s", portPath)
+	}
+
+	if err := ValidateFullCalibration(calibration, nil); err != nil {
+		return fmt.Errorf("invalid calibration: %w", err)
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.controller == nil {
+		return fmt.Errorf("controller not available for port %s", portPath)
+	}
+
+	if err := entry.controller.SetCalibration(calibration); err != nil {
+		return err
+	}
+	for _, view := range entry.views {
+		if err := view.SetCalibration(calibration); err != nil {
+			return err
+		}
+		if view.logger != nil {
+			view.logger.Infof("Applied updated calibration for port %s", portPath)
+		}
+	}
+	entry.calibration = calibration
+	return nil
+}
+
+// WatchCalibrationFile starts watching a calibration file for the controller on a port.
+// Only one watcher runs per port, later calls for the same file are no-ops.
+func (r *ControllerRegistry) WatchCalibrationFile(portPath, filePath string, logger logging.Logger) error {
+	r.mu.RLock()
+	entry, exists := r.entries[portPath]
+	r.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("no controller for port %s", portPath)
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.watcher != nil {
+		if entry.watcher.path != filePath {
+			return fmt.Errorf("port %s is already watching calibration file %s", portPath, entry.watcher.path)
+		}
+		return nil
+	}
+
+	watcher, err := newCalibrationWatcher(filePath, logger, func(calibration SO101FullCalibration) {
+		if err := r.ApplyCalibration(portPath, calibration); err != nil {
+			logger.Warnf("Failed to apply calibration from %s: %v", filePath, err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	entry.watcher = watcher
+	return nil
+}
+
+// stopWatcher stops the calibration file watcher, the entry lock must be held
+func (e *ControllerEntry) stopWatcher() {
+	if e.watcher != nil {
+		e.watcher.Close()
+		e.watcher = nil
+	}
+}
+
+// closeStandby closes the secondary port, the entry lock must be held
+func (e *ControllerEntry) closeStandby() {
+	if err := e.standby.close(); err != nil && e.config != nil && e.config.Logger != nil {
+		e.config.Logger.Warnf("error closing secondary port %s: %v", e.standby.path, err)
+	}
+	e.standby = nil
+}
+
+// releaseLock lets other processes open the port once our bus is closed
+func (e *ControllerEntry) releaseLock() {
+	if err := e.lock.Release(); err != nil && e.config != nil && e.config.Logger != nil {
+		e.config.Logger.Warnf("error releasing lock on port %s: %v", e.config.Port, err)
+	}
+	e.lock = nil
+}
+
+// RegisterConsumer records which calibration file and servos a component uses on a port
+func (r *ControllerRegistry) RegisterConsumer(portPath string, info ConsumerInfo) {
+	r.mu.RLock()
+	entry, exists := r.entries[portPath]
+	r.mu.RUnlock()
+
+	if !exists {
 		return
 	}
 
-	r.callerMu.Lock()
-	r.callerPorts[pc] = portPath
-	r.callerMu.Unlock()
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.consumers == nil {
+		entry.consumers = make(map[string]ConsumerInfo)
+	}
+	entry.consumers[info.Name] = info
 }
 
-func (r *ControllerRegistry) releaseFromCaller() {
-	pc, _, _, ok := runtime.Caller(2) // 2 levels up to get the actual caller
-	if !ok {
+// UnregisterConsumer removes a component from the consumers of a port
+func (r *ControllerRegistry) UnregisterConsumer(portPath, name string) {
+	r.mu.RLock()
+	entry, exists := r.entries[portPath]
+	r.mu.RUnlock()
+
+	if !exists {
 		return
 	}
 
-	r.callerMu.RLock()
-	portPath, exists := r.callerPorts[pc]
-	r.callerMu.RUnlock()
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	delete(entry.consumers, name)
+}
+
+// GetConsumers returns the registered consumers of a port
+func (r *ControllerRegistry) GetConsumers(portPath string) []ConsumerInfo {
+	r.mu.RLock()
+	entry, exists := r.entries[portPath]
+	r.mu.RUnlock()
 
-	if exists {
-		r.ReleaseController(portPath)
+	if !exists {
+		return nil
+	}
+
+	entry.mu.RLock()
+	defer entry.mu.RUnlock()
 
-		r.callerMu.Lock()
-		delete(r.callerPorts, pc)
-		r.callerMu.Unlock()
+	consumers := make([]ConsumerInfo, 0, len(entry.consumers))
+	for _, info := range entry.consumers {
+		consumers = append(consumers, info)
 	}
+	sort.Slice(consumers, func(i, j int) bool {
+		return consumers[i].Name < consumers[j].Name
+	})
+	return consumers
 }
 
 // compareConfigs returns a string describing the differences between two configs
@@ -371,6 +622,9 @@ func compareConfigs(a, b *SoArm101Config) string {
 	if a.Timeout != b.Timeout {
 		diffs = append(diffs, fmt.Sprintf("timeout: %v vs %v", a.Timeout, b.Timeout))
 	}
+	if !protocolsEqual(a, b) {
+		diffs = append(diffs, fmt.Sprintf("protocol: %s %v vs %s %v", a.Protocol, a.SCSServoIDs, b.Protocol, b.SCSServoIDs))
+	}
 	if len(diffs) == 0 {
 		return "unknown differences"
 	}
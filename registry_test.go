@@ -38,10 +38,6 @@ func TestRegistryCreation(t *testing.T) {
 		t.Fatal("Registry entries map not initialized")
 	}
 
-	if registry.callerPorts == nil {
-		t.Fatal("Registry callerPorts map not initialized")
-	}
-
 	if len(registry.entries) != 0 {
 		t.Fatal("Registry should start empty")
 	}
@@ -84,7 +80,7 @@ func TestSingleControllerAccess(t *testing.T) {
 	registry.mu.RUnlock()
 
 	// Release controller
-	registry.ReleaseController(config.Port)
+	registry.ReleaseController(config.Port, controller)
 
 	// Verify cleanup
 	registry.mu.RLock()
@@ -233,7 +229,7 @@ func TestCleanupOnZeroRefs(t *testing.T) {
 	registry.mu.RUnlock()
 
 	// Release the controller
-	registry.ReleaseController(port)
+	registry.ReleaseController(port, nil)
 
 	// Verify cleanup occurred
 	registry.mu.RLock()
@@ -417,3 +413,108 @@ func TestControllerUsesServoCalibrationWhenNoFile(t *testing.T) {
 
 	t.Skip("Integration test - requires hardware or mock bus setup")
 }
+
+// TestConsumerTracking tests recording which components share a port
+func TestConsumerTracking(t *testing.T) {
+	registry := NewControllerRegistry()
+	port := "/dev/ttyUSB0"
+
+	// Registering against an unknown port is a no-op
+	registry.RegisterConsumer(port, ConsumerInfo{Name: "arm", ServoIDs: []int{1, 2, 3, 4, 5}})
+	if consumers := registry.GetConsumers(port); len(consumers) != 0 {
+		t.Fatalf("Expected no consumers for unknown port, got %d", len(consumers))
+	}
+
+	registry.entries[port] = &ControllerEntry{
+		config:      testConfig(port),
+		calibration: DefaultSO101FullCalibration,
+		refCount:    2,
+	}
+
+	registry.RegisterConsumer(port, ConsumerInfo{Name: "gripper", CalibrationFile: "a.json", ServoIDs: []int{6}})
+	registry.RegisterConsumer(port, ConsumerInfo{Name: "arm", CalibrationFile: "b.json", ServoIDs: []int{1, 2, 3, 4, 5}})
+
+	consumers := registry.GetConsumers(port)
+	if len(consumers) != 2 {
+		t.Fatalf("Expected 2 consumers, got %d", len(consumers))
+	}
+	if consumers[0].Name != "arm" || consumers[1].Name != "gripper" {
+		t.Errorf("Expected consumers sorted by name, got %s, %s", consumers[0].Name, consumers[1].Name)
+	}
+
+	registry.UnregisterConsumer(port, "arm")
+	consumers = registry.GetConsumers(port)
+	if len(consumers) != 1 || consumers[0].Name != "gripper" {
+		t.Errorf("Expected only gripper after unregister, got %v", consumers)
+	}
+}
+
+// TestApplyCalibrationUpdatesAllConsumers tests that a calibration change reaches every controller on a port
+func TestApplyCalibrationUpdatesAllConsumers(t *testing.T) {
+	registry := NewControllerRegistry()
+	port := "/dev/ttyUSB0"
+
+	calibratedServos := make(map[int]*CalibratedServo)
+	for id := 1; id <= 6; id++ {
+		calibratedServos[id] = NewCalibratedServo(nil, DefaultSO101FullCalibration.GetMotorCalibrationByID(id))
+	}
+	newView := func() *SafeSoArmController {
+		return &SafeSoArmController{
+			calibratedServos: calibratedServos,
+			calibration:      DefaultSO101FullCalibration,
+		}
+	}
+
+	armView, gripperView := newView(), newView()
+	registry.entries[port] = &ControllerEntry{
+		controller:  newView(),
+		config:      testConfig(port),
+		calibration: DefaultSO101FullCalibration,
+		refCount:    2,
+		views:       []*SafeSoArmController{armView, gripperView},
+	}
+
+	updated := DefaultSO101FullCalibration
+	shoulderPan := *updated.ShoulderPan
+	shoulderPan.HomingOffset = 42
+	updated.ShoulderPan = &shoulderPan
+
+	if err := registry.ApplyCalibration(port, updated); err != nil {
+		t.Fatalf("ApplyCalibration failed: %v", err)
+	}
+
+	for _, view := range []*SafeSoArmController{armView, gripperView} {
+		if got := view.GetCalibration().ShoulderPan.HomingOffset; got != 42 {
+			t.Errorf("Expected homing offset 42, got %d", got)
+		}
+	}
+	if got := registry.GetCurrentCalibration(port).ShoulderPan.HomingOffset; got != 42 {
+		t.Errorf("Expected registry calibration homing offset 42, got %d", got)
+	}
+
+	if err := registry.ApplyCalibration("/dev/ttyUSB9", updated); err == nil {
+		t.Error("Expected error applying calibration to unknown port")
+	}
+}
+
+// TestReleaseRemovesView tests that a released controller stops getting updates
+func TestReleaseRemovesView(t *testing.T) {
+	registry := NewControllerRegistry()
+	port := "/dev/ttyUSB0"
+	first, second := &SafeSoArmController{}, &SafeSoArmController{}
+	registry.entries[port] = &ControllerEntry{
+		config:   testConfig(port),
+		refCount: 2,
+		views:    []*SafeSoArmController{first, second},
+	}
+
+	registry.ReleaseController(port, first)
+
+	entry, exists := registry.entries[port]
+	if !exists {
+		t.Fatal("Registry entry should remain while referenced")
+	}
+	if len(entry.views) != 1 || entry.views[0] != second {
+		t.Fatalf("Expected only the unreleased view to remain, got %d views", len(entry.views))
+	}
+}
@@ -0,0 +1,36 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrRequestTimeout is returned when a call runs past the timeout_ms passed in its extra or
+// command map
+var ErrRequestTimeout = errors.New("TIMEOUT: request exceeded timeout_ms")
+
+// withRequestTimeout bounds ctx by the optional timeout_ms in a call's extra or command map,
+// so a UI call fails fast instead of waiting on the bus timeout and retries
+func withRequestTimeout(ctx context.Context, extra map[string]interface{}) (context.Context, context.CancelFunc, error) {
+	raw, ok := extra["timeout_ms"]
+	if !ok {
+		return ctx, func() {}, nil
+	}
+	ms, ok := raw.(float64)
+	if !ok || ms <= 0 {
+		return nil, nil, fmt.Errorf("timeout_ms must be a positive number, got %v", raw)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(ms*float64(time.Millisecond)))
+	return ctx, cancel, nil
+}
+
+// requestTimeoutError reports err as ErrRequestTimeout when the request's deadline passed
+func requestTimeoutError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", ErrRequestTimeout, err)
+	}
+	return err
+}
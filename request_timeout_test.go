@@ -0,0 +1,36 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRequestTimeout(t *testing.T) {
+	ctx, cancel, err := withRequestTimeout(context.Background(), map[string]interface{}{})
+	assert.NoError(t, err)
+	defer cancel()
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+
+	_, _, err = withRequestTimeout(context.Background(), map[string]interface{}{"timeout_ms": -5.0})
+	assert.Error(t, err)
+	_, _, err = withRequestTimeout(context.Background(), map[string]interface{}{"timeout_ms": "fast"})
+	assert.Error(t, err)
+
+	ctx, cancel, err = withRequestTimeout(context.Background(), map[string]interface{}{"timeout_ms": 1.0})
+	assert.NoError(t, err)
+	defer cancel()
+	<-ctx.Done()
+
+	busErr := errors.New("read failed")
+	assert.ErrorIs(t, requestTimeoutError(ctx, busErr), ErrRequestTimeout)
+	assert.NoError(t, requestTimeoutError(ctx, nil))
+
+	live, liveCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer liveCancel()
+	assert.Equal(t, busErr, requestTimeoutError(live, busErr))
+}
@@ -0,0 +1,131 @@
+package so_arm
+
+import (
+	"fmt"
+	"math"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+// Rest pose search. Candidate poses fold the shoulder, elbow and wrist within the joint
+// limits, and the one that keeps the links' centers of mass closest to over the base (and
+// low) wins. Links are weighted equally, the model has no mass data.
+const (
+	restPoseStepDegs        = 10.0
+	restPoseHeightWeight    = 0.5
+	restPoseMinClearanceMM  = 20.0
+	restPoseShoulderLiftIdx = 1
+	restPoseElbowIdx        = 2
+	restPoseWristFlexIdx    = 3
+)
+
+// computeRestPose finds a stable folded pose, in radians, with the base pan and wrist roll at
+// 0 (or their nearest limits)
+func computeRestPose(model referenceframe.Model, limits [][2]float64) ([]float64, error) {
+	if len(limits) != len(model.DoF()) {
+		return nil, fmt.Errorf("expected %d joint limits, got %d", len(model.DoF()), len(limits))
+	}
+	step := DegreesToRadians(restPoseStepDegs)
+	sweep := func(idx int) []float64 {
+		var values []float64
+		for v := limits[idx][0]; v <= limits[idx][1]; v += step {
+			values = append(values, v)
+		}
+		return values
+	}
+
+	candidate := make([]float64, len(limits))
+	for i := range candidate {
+		candidate[i] = math.Max(limits[i][0], math.Min(limits[i][1], 0))
+	}
+
+	var best []float64
+	bestScore := math.Inf(1)
+	for _, shoulder := range sweep(restPoseShoulderLiftIdx) {
+		for _, elbow := range sweep(restPoseElbowIdx) {
+			for _, wrist := range sweep(restPoseWristFlexIdx) {
+				candidate[restPoseShoulderLiftIdx] = shoulder
+				candidate[restPoseElbowIdx] = elbow
+				candidate[restPoseWristFlexIdx] = wrist
+				score, ok := restPoseScore(model, candidate)
+				if ok && score < bestScore {
+					bestScore = score
+					best = append([]float64(nil), candidate...)
+				}
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no pose within the joint limits keeps the arm clear of the table")
+	}
+	return best, nil
+}
+
+// restPoseScore rates how stable a pose is, lower is better. Poses that put the end effector
+// or any link near or below the base plane are rejected.
+func restPoseScore(model referenceframe.Model, inputs []float64) (float64, bool) {
+	end, err := referenceframe.ComputeOOBPosition(model, inputs)
+	if err != nil || end.Point().Z < restPoseMinClearanceMM {
+		return 0, false
+	}
+	gif, err := model.Geometries(inputs)
+	if err != nil {
+		return 0, false
+	}
+	geometries := gif.Geometries()
+	if len(geometries) == 0 {
+		return 0, false
+	}
+
+	var x, y, z float64
+	for _, geometry := range geometries {
+		point := geometry.Pose().Point()
+		if point.Z < 0 {
+			return 0, false
+		}
+		x += point.X
+		y += point.Y
+		z += point.Z
+	}
+	n := float64(len(geometries))
+	x, y, z = x/n, y/n, z/n
+	return math.Hypot(x, y) + restPoseHeightWeight*z, true
+}
+
+// parkPoseRadians returns park_pose clamped to the joint limits, or a computed rest pose
+// when park_pose isn't configured
+func (s *so101) parkPoseRadians() ([]float64, error) {
+	jointLimits := s.calculateJointLimits()
+	parkPose := s.config().ParkPose
+	if len(parkPose) == 0 {
+		if len(s.servoIDs()) != len(s.model.DoF()) {
+			return nil, fmt.Errorf("a rest pose can only be computed for all %d arm joints, configure park_pose instead", len(s.model.DoF()))
+		}
+		s.restPoseOnce.Do(func() {
+			s.restPose, s.restPoseErr = computeRestPose(s.model, jointLimits)
+		})
+		return s.restPose, s.restPoseErr
+	}
+	target := make([]float64, len(parkPose))
+	for i, deg := range parkPose {
+		target[i] = math.Max(jointLimits[i][0], math.Min(jointLimits[i][1], DegreesToRadians(deg)))
+	}
+	return target, nil
+}
+
+// getParkPose handles the get_park_pose DoCommand
+func (s *so101) getParkPose() map[string]interface{} {
+	source := "park_pose"
+	if len(s.config().ParkPose) == 0 {
+		source = "computed"
+	}
+	target, err := s.parkPoseRadians()
+	if err != nil {
+		return map[string]interface{}{"success": false, "source": source, "error": err.Error()}
+	}
+	degs := make([]float64, len(target))
+	for i, rad := range target {
+		degs[i] = RadiansToDegrees(rad)
+	}
+	return map[string]interface{}{"success": true, "source": source, "positions_degs": degs}
+}
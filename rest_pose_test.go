@@ -0,0 +1,43 @@
+package so_arm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/referenceframe"
+)
+
+func TestComputeRestPose(t *testing.T) {
+	model, err := makeSO101ModelFrame()
+	assert.NoError(t, err)
+
+	limits := make([][2]float64, len(model.DoF()))
+	for i := range limits {
+		limits[i] = [2]float64{-math.Pi / 2, math.Pi / 2}
+	}
+
+	pose, err := computeRestPose(model, limits)
+	assert.NoError(t, err)
+	assert.Len(t, pose, 5)
+	for i, v := range pose {
+		assert.GreaterOrEqual(t, v, limits[i][0])
+		assert.LessOrEqual(t, v, limits[i][1])
+	}
+	assert.InDelta(t, 0, pose[0], 1e-9)
+	assert.InDelta(t, 0, pose[4], 1e-9)
+
+	// Folded and clear of the table, and more stable than the straight-up zero pose
+	end, err := referenceframe.ComputeOOBPosition(model, pose)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, end.Point().Z, restPoseMinClearanceMM)
+	restScore, ok := restPoseScore(model, pose)
+	assert.True(t, ok)
+	zeroScore, ok := restPoseScore(model, make([]float64, 5))
+	if ok {
+		assert.Less(t, restScore, zeroScore)
+	}
+
+	_, err = computeRestPose(model, limits[:3])
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// maxRetryAttempts bounds retry.attempts, a dead servo shouldn't stall every read for long
+const maxRetryAttempts = 10
+
+// Servo initialization is retried when no retry policy is configured
+const (
+	defaultInitAttempts = 3
+	defaultInitBackoff  = 500 * time.Millisecond
+)
+
+// RetryPolicy is how serial reads, writes and pings are retried. Each component keeps its
+// own, so a UI-facing sensor can fail fast while the arm retries on the same port.
+type RetryPolicy struct {
+	// Tries per operation including the first, default 1
+	Attempts int `json:"attempts,omitempty"`
+	// Wait before the first retry, doubled for each later one up to max_backoff_ms
+	BackoffMs    float64 `json:"backoff_ms,omitempty"`
+	MaxBackoffMs float64 `json:"max_backoff_ms,omitempty"`
+	// Time limit for each try, 0 leaves it to the bus timeout
+	OpTimeoutMs float64 `json:"op_timeout_ms,omitempty"`
+}
+
+// Validate checks the policy's ranges
+func (p *RetryPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Attempts < 0 || p.Attempts > maxRetryAttempts {
+		return fmt.Errorf("retry.attempts must be between 0 and %d, got %d", maxRetryAttempts, p.Attempts)
+	}
+	if p.BackoffMs < 0 || p.MaxBackoffMs < 0 || p.OpTimeoutMs < 0 {
+		return fmt.Errorf("retry.backoff_ms, retry.max_backoff_ms and retry.op_timeout_ms can't be negative")
+	}
+	if p.MaxBackoffMs != 0 && p.MaxBackoffMs < p.BackoffMs {
+		return fmt.Errorf("retry.max_backoff_ms (%.0f) must be at least retry.backoff_ms (%.0f)", p.MaxBackoffMs, p.BackoffMs)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.Attempts == 0 {
+		return 1
+	}
+	return p.Attempts
+}
+
+// backoff returns the wait after the given failed attempt, counting from 1
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	if p == nil {
+		return 0
+	}
+	ms := p.BackoffMs * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoffMs != 0 {
+		ms = math.Min(ms, p.MaxBackoffMs)
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// do runs op until it succeeds, the attempts run out or ctx is done
+func (p *RetryPolicy) do(ctx context.Context, op func(context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		opCtx, cancel := ctx, context.CancelFunc(func() {})
+		if p != nil && p.OpTimeoutMs > 0 {
+			opCtx, cancel = context.WithTimeout(ctx, time.Duration(p.OpTimeoutMs*float64(time.Millisecond)))
+		}
+		err = op(opCtx)
+		cancel()
+		if err == nil || attempt >= p.attempts() || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(p.backoff(attempt)):
+		}
+	}
+}
+
+// initRetry returns the attempts and wait between them for servo initialization
+func (p *RetryPolicy) initRetry() (int, func(attempt int) time.Duration) {
+	if p == nil {
+		return defaultInitAttempts, func(attempt int) time.Duration {
+			return time.Duration(attempt) * defaultInitBackoff
+		}
+	}
+	return p.attempts(), p.backoff
+}
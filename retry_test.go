@@ -0,0 +1,61 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	var unset *RetryPolicy
+	assert.NoError(t, unset.Validate())
+	assert.NoError(t, (&RetryPolicy{Attempts: 3, BackoffMs: 10, MaxBackoffMs: 100}).Validate())
+	assert.Error(t, (&RetryPolicy{Attempts: 11}).Validate())
+	assert.Error(t, (&RetryPolicy{BackoffMs: -1}).Validate())
+	assert.Error(t, (&RetryPolicy{BackoffMs: 50, MaxBackoffMs: 10}).Validate())
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{Attempts: 5, BackoffMs: 10, MaxBackoffMs: 30}
+	assert.Equal(t, 10*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 20*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 30*time.Millisecond, p.backoff(3))
+
+	attempts, backoff := (*RetryPolicy)(nil).initRetry()
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, time.Second, backoff(2))
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	failing := errors.New("no response")
+
+	calls := 0
+	err := (*RetryPolicy)(nil).do(context.Background(), func(context.Context) error {
+		calls++
+		return failing
+	})
+	assert.ErrorIs(t, err, failing)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	p := &RetryPolicy{Attempts: 3}
+	err = p.do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return failing
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	p = &RetryPolicy{Attempts: 2, OpTimeoutMs: 5}
+	err = p.do(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,252 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// servoSetting is one register in a settings dump. Restore skips registers that would change
+// how the servo is addressed, that command motion, or that only report state.
+type servoSetting struct {
+	Name        string
+	Register    feetech.Register
+	EEPROM      bool
+	Restore     bool
+	Calibration bool
+}
+
+// servoSettings lists every documented STS3215 register in address order
+var servoSettings = []servoSetting{
+	{Name: "firmware_version", Register: feetech.RegFirmwareVersion, EEPROM: true},
+	{Name: "model_number", Register: feetech.RegModelNumber, EEPROM: true},
+	{Name: "id", Register: feetech.RegID, EEPROM: true},
+	{Name: "baud_rate", Register: feetech.RegBaudRate, EEPROM: true},
+	{Name: "response_delay", Register: feetech.RegResponseDelay, EEPROM: true, Restore: true},
+	{Name: "min_angle_limit", Register: feetech.RegMinAngleLimit, EEPROM: true, Restore: true, Calibration: true},
+	{Name: "max_angle_limit", Register: feetech.RegMaxAngleLimit, EEPROM: true, Restore: true, Calibration: true},
+	{Name: "max_temp", Register: feetech.RegMaxTemp, EEPROM: true, Restore: true},
+	{Name: "max_voltage", Register: feetech.RegMaxVoltage, EEPROM: true, Restore: true},
+	{Name: "min_voltage", Register: feetech.RegMinVoltage, EEPROM: true, Restore: true},
+	{Name: "max_torque", Register: feetech.RegMaxTorque, EEPROM: true, Restore: true},
+	{Name: "phase", Register: feetech.RegPhase, EEPROM: true, Restore: true},
+	{Name: "unload_condition", Register: feetech.RegUnloadCondition, EEPROM: true, Restore: true},
+	{Name: "led_alarm", Register: feetech.RegLEDAlarm, EEPROM: true, Restore: true},
+	{Name: "p_gain", Register: feetech.RegPGain, EEPROM: true, Restore: true},
+	{Name: "d_gain", Register: feetech.RegDGain, EEPROM: true, Restore: true},
+	{Name: "i_gain", Register: feetech.RegIGain, EEPROM: true, Restore: true},
+	{Name: "min_startup_force", Register: feetech.RegMinStartupForce, EEPROM: true, Restore: true},
+	{Name: "cw_deadband", Register: feetech.RegClockwiseDeadband, EEPROM: true, Restore: true},
+	{Name: "ccw_deadband", Register: feetech.RegCounterClockwiseDeadband, EEPROM: true, Restore: true},
+	{Name: "protection_current", Register: feetech.RegProtectionCurrent, EEPROM: true, Restore: true},
+	{Name: "angular_resolution", Register: feetech.RegAngularResolution, EEPROM: true, Restore: true},
+	{Name: "position_offset", Register: feetech.RegPositionOffset, EEPROM: true, Restore: true, Calibration: true},
+	{Name: "operating_mode", Register: feetech.RegOperatingMode, EEPROM: true, Restore: true},
+	{Name: "protection_torque", Register: feetech.RegProtectionTorque, EEPROM: true, Restore: true},
+	{Name: "protection_time", Register: feetech.RegProtectionTime, EEPROM: true, Restore: true},
+	{Name: "overload_torque", Register: feetech.RegOverloadTorque, EEPROM: true, Restore: true},
+	{Name: "speed_closed_loop", Register: feetech.RegSpeedClosedLoop, EEPROM: true, Restore: true},
+	{Name: "current_closed_loop", Register: feetech.RegCurrentClosedLoop, EEPROM: true, Restore: true},
+	{Name: "torque_enable", Register: feetech.RegTorqueEnable},
+	{Name: "acceleration", Register: feetech.RegAcceleration, Restore: true},
+	{Name: "goal_position", Register: feetech.RegGoalPosition},
+	{Name: "goal_time", Register: feetech.RegGoalTime},
+	{Name: "goal_velocity", Register: feetech.RegGoalVelocity},
+	{Name: "torque_limit", Register: feetech.RegTorqueLimit, Restore: true},
+	{Name: "lock", Register: feetech.RegLock},
+	{Name: "present_position", Register: feetech.RegPresentPosition},
+	{Name: "present_velocity", Register: feetech.RegPresentVelocity},
+	{Name: "present_load", Register: feetech.RegPresentLoad},
+	{Name: "present_voltage", Register: feetech.RegPresentVoltage},
+	{Name: "present_temp", Register: feetech.RegPresentTemp},
+	{Name: "servo_status", Register: feetech.RegServoStatus},
+	{Name: "moving", Register: feetech.RegMoving},
+	{Name: "present_current", Register: feetech.RegPresentCurrent},
+}
+
+// decodeRegisterValue turns register bytes into the raw unsigned value, reading words in the
+// servo protocol's byte order
+func decodeRegisterValue(data []byte, proto *feetech.Protocol) int {
+	if len(data) == 2 {
+		return int(proto.DecodeWord(data))
+	}
+	return int(data[0])
+}
+
+// encodeRegisterValue turns a raw value back into bytes of the register's size, writing words
+// in the servo protocol's byte order
+func encodeRegisterValue(value, size int, proto *feetech.Protocol) []byte {
+	if size == 2 {
+		return proto.EncodeWord(uint16(value))
+	}
+	return []byte{byte(value)}
+}
+
+// settingsServoIDs returns the servos named by a servo_ids parameter, defaulting to the arm
+// servos and the gripper configured on the same port
+func (s *so101) settingsServoIDs(cmd map[string]interface{}) ([]int, error) {
+	raw, ok := cmd["servo_ids"]
+	if !ok {
+		ids := append([]int(nil), s.servoIDs()...)
+		if gripperID, ok := s.gripperServoID(); ok {
+			ids = append(ids, gripperID)
+		}
+		return ids, nil
+	}
+	values, err := floatList(raw, "servo_ids")
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(values))
+	for i, v := range values {
+		ids[i] = int(v)
+	}
+	return ids, nil
+}
+
+// dumpServoSettings handles the dump_servo_settings DoCommand. Values are raw register
+// contents so a restore writes back exactly what was read.
+func (s *so101) dumpServoSettings(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	ids, err := s.settingsServoIDs(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	servos := map[string]interface{}{}
+	errs := []string{}
+	for _, id := range ids {
+		registers := map[string]interface{}{}
+		for _, setting := range servoSettings {
+			data, err := s.controller.ReadServoRegisterAt(ctx, id, setting.Register)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("servo %d %s: %v", id, setting.Name, err))
+				continue
+			}
+			registers[setting.Name] = decodeRegisterValue(data, s.controller.protocolFor(id))
+		}
+		servos[strconv.Itoa(id)] = registers
+	}
+
+	return map[string]interface{}{
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"servos":    servos,
+		"errors":    errs,
+	}, nil
+}
+
+// restoreServoSettings handles the restore_servo_settings DoCommand. Torque must be off, since
+// operating_mode and the limits can make a powered servo jump. Calibration registers are only
+// written with include_calibration, as they depend on how the new servo's horn is mounted.
+func (s *so101) restoreServoSettings(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	snapshot, ok := cmd["snapshot"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("restore_servo_settings requires 'snapshot' object from dump_servo_settings")
+	}
+	servos, ok := snapshot["servos"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("snapshot has no 'servos' object")
+	}
+	includeCalibration, _ := cmd["include_calibration"].(bool)
+
+	ids := make([]int, 0, len(servos))
+	for key := range servos {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid servo id %q in snapshot", key)
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	if _, ok := cmd["servo_ids"]; ok {
+		requested, err := s.settingsServoIDs(cmd)
+		if err != nil {
+			return nil, err
+		}
+		ids = filterServoIDs(requested, servos)
+	}
+
+	for _, id := range ids {
+		data, err := s.controller.ReadServoRegisterAt(ctx, id, feetech.RegTorqueEnable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read torque state of servo %d: %w", id, err)
+		}
+		if len(data) > 0 && data[0] != 0 {
+			return map[string]interface{}{
+				"success": false,
+				"error":   fmt.Sprintf("servo %d has torque enabled, disable torque before restoring settings", id),
+			}, nil
+		}
+	}
+
+	restored := map[string]interface{}{}
+	errs := []string{}
+	for _, id := range ids {
+		registers, _ := servos[strconv.Itoa(id)].(map[string]interface{})
+		written, err := s.restoreServo(ctx, id, registers, includeCalibration)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("servo %d: %v", id, err))
+		}
+		restored[strconv.Itoa(id)] = written
+	}
+
+	return map[string]interface{}{
+		"success":  len(errs) == 0,
+		"restored": restored,
+		"errors":   errs,
+	}, nil
+}
+
+// filterServoIDs keeps the requested IDs that are present in the snapshot
+func filterServoIDs(ids []int, servos map[string]interface{}) []int {
+	kept := []int{}
+	for _, id := range ids {
+		if _, ok := servos[strconv.Itoa(id)]; ok {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// restoreServo writes one servo's registers with the EEPROM unlocked, refusing to restore
+// onto a different servo model. It returns the names of the registers written.
+func (s *so101) restoreServo(ctx context.Context, id int, registers map[string]interface{}, includeCalibration bool) ([]string, error) {
+	written := []string{}
+	if want, ok := registers["model_number"].(float64); ok {
+		data, err := s.controller.ReadServoRegisterAt(ctx, id, feetech.RegModelNumber)
+		if err != nil {
+			return written, fmt.Errorf("failed to read model number: %w", err)
+		}
+		if got := decodeRegisterValue(data, s.controller.protocolFor(id)); got != int(want) {
+			return written, fmt.Errorf("snapshot is for model %d but servo is model %d", int(want), got)
+		}
+	}
+
+	if err := s.controller.WriteServoRegisterAt(ctx, id, feetech.RegLock, []byte{0}); err != nil {
+		return written, fmt.Errorf("failed to unlock EEPROM: %w", err)
+	}
+	defer func() {
+		if err := s.controller.WriteServoRegisterAt(ctx, id, feetech.RegLock, []byte{1}); err != nil {
+			s.logger.Warnf("Failed to lock EEPROM on servo %d after restore: %v", id, err)
+		}
+	}()
+
+	for _, setting := range servoSettings {
+		if !setting.Restore || (setting.Calibration && !includeCalibration) {
+			continue
+		}
+		value, ok := registers[setting.Name].(float64)
+		if !ok {
+			continue
+		}
+		data := encodeRegisterValue(int(value), setting.Register.Size, s.controller.protocolFor(id))
+		if err := s.controller.WriteServoRegisterAt(ctx, id, setting.Register, data); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", setting.Name, err)
+		}
+		written = append(written, setting.Name)
+	}
+	return written, nil
+}
@@ -0,0 +1,39 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterValueRoundTrip(t *testing.T) {
+	sts := feetech.NewProtocol(feetech.ProtocolSTS)
+	assert.Equal(t, 0x0FFF, decodeRegisterValue([]byte{0xFF, 0x0F}, sts))
+	assert.Equal(t, 32, decodeRegisterValue([]byte{32}, sts))
+	assert.Equal(t, []byte{0xFF, 0x0F}, encodeRegisterValue(0x0FFF, 2, sts))
+	assert.Equal(t, []byte{32}, encodeRegisterValue(32, 1, sts))
+
+	// SCS words are big-endian
+	assert.Equal(t, 0x0FFF, decodeRegisterValue([]byte{0x0F, 0xFF}, scsProtocol))
+	assert.Equal(t, []byte{0x0F, 0xFF}, encodeRegisterValue(0x0FFF, 2, scsProtocol))
+
+	// Sign-magnitude registers come back bit for bit
+	raw := []byte{0x10, 0x08}
+	assert.Equal(t, raw, encodeRegisterValue(decodeRegisterValue(raw, sts), 2, sts))
+}
+
+func TestServoSettingsRestoreSafety(t *testing.T) {
+	names := map[string]servoSetting{}
+	for _, setting := range servoSettings {
+		names[setting.Name] = setting
+		if setting.Register.ReadOnly {
+			assert.False(t, setting.Restore, setting.Name)
+		}
+	}
+	for _, name := range []string{"id", "baud_rate", "torque_enable", "goal_position", "lock"} {
+		assert.False(t, names[name].Restore, name)
+	}
+	assert.True(t, names["position_offset"].Calibration)
+	assert.True(t, names["p_gain"].Restore)
+}
@@ -0,0 +1,95 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// regFirmwareMinorVersion follows the firmware major version register, which is all the
+// feetech register map names
+var regFirmwareMinorVersion = feetech.Register{Address: 1, Size: 1, ReadOnly: true}
+
+// servoVersion is a servo's firmware version and model number, read from its EEPROM
+type servoVersion struct {
+	FirmwareMajor int
+	FirmwareMinor int
+	ModelNumber   int
+}
+
+func (v servoVersion) firmware() string {
+	return fmt.Sprintf("%d.%d", v.FirmwareMajor, v.FirmwareMinor)
+}
+
+// modelName returns the name of the servo's model, or "unknown" for a model number the
+// feetech library doesn't know
+func (v servoVersion) modelName() string {
+	if model, ok := feetech.GetModelByNumber(v.ModelNumber); ok {
+		return model.Name
+	}
+	return "unknown"
+}
+
+func (v servoVersion) reading() map[string]interface{} {
+	return map[string]interface{}{
+		"firmware":       v.firmware(),
+		"firmware_major": v.FirmwareMajor,
+		"firmware_minor": v.FirmwareMinor,
+		"model_number":   v.ModelNumber,
+		"model":          v.modelName(),
+	}
+}
+
+// ReadServoVersion reads a servo's firmware major/minor version and model number
+func (s *SafeSoArmController) ReadServoVersion(ctx context.Context, servoID int) (servoVersion, error) {
+	var v servoVersion
+	data, err := s.ReadServoRegisterAt(ctx, servoID, feetech.RegFirmwareVersion)
+	if err != nil {
+		return v, fmt.Errorf("failed to read firmware major version: %w", err)
+	}
+	v.FirmwareMajor = decodeRegisterValue(data, s.protocolFor(servoID))
+	if data, err = s.ReadServoRegisterAt(ctx, servoID, regFirmwareMinorVersion); err != nil {
+		return v, fmt.Errorf("failed to read firmware minor version: %w", err)
+	}
+	v.FirmwareMinor = decodeRegisterValue(data, s.protocolFor(servoID))
+	if data, err = s.ReadServoRegisterAt(ctx, servoID, feetech.RegModelNumber); err != nil {
+		return v, fmt.Errorf("failed to read model number: %w", err)
+	}
+	v.ModelNumber = decodeRegisterValue(data, s.protocolFor(servoID))
+	return v, nil
+}
+
+// mixedFirmware reports whether the servos are on different firmware versions. Servos on one
+// arm flashed at different times can disagree on register semantics, so this is worth a flash.
+func mixedFirmware(versions map[int]servoVersion) bool {
+	firmware := ""
+	for _, v := range versions {
+		if firmware != "" && v.firmware() != firmware {
+			return true
+		}
+		firmware = v.firmware()
+	}
+	return false
+}
+
+// servoVersionsStatus reads every servo's version for controller_status, keyed by servo ID.
+// Servos that can't be read report their error instead.
+func servoVersionsStatus(ctx context.Context, controller *SafeSoArmController, servoIDs []int) map[string]interface{} {
+	servos := make(map[string]interface{}, len(servoIDs))
+	versions := make(map[int]servoVersion, len(servoIDs))
+	for _, id := range servoIDs {
+		v, err := controller.ReadServoVersion(ctx, id)
+		if err != nil {
+			servos[strconv.Itoa(id)] = map[string]interface{}{"error": err.Error()}
+			continue
+		}
+		versions[id] = v
+		servos[strconv.Itoa(id)] = v.reading()
+	}
+	return map[string]interface{}{
+		"servos":         servos,
+		"mixed_firmware": mixedFirmware(versions),
+	}
+}
@@ -0,0 +1,29 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServoVersionReading(t *testing.T) {
+	v := servoVersion{FirmwareMajor: 3, FirmwareMinor: 10, ModelNumber: 777}
+	reading := v.reading()
+	assert.Equal(t, "3.10", reading["firmware"])
+	assert.Equal(t, "sts3215", reading["model"])
+	assert.Equal(t, 777, reading["model_number"])
+
+	assert.Equal(t, "unknown", servoVersion{ModelNumber: 1234}.modelName())
+}
+
+func TestMixedFirmware(t *testing.T) {
+	assert.False(t, mixedFirmware(nil))
+	assert.False(t, mixedFirmware(map[int]servoVersion{
+		1: {FirmwareMajor: 3, FirmwareMinor: 10},
+		2: {FirmwareMajor: 3, FirmwareMinor: 10},
+	}))
+	assert.True(t, mixedFirmware(map[int]servoVersion{
+		1: {FirmwareMajor: 3, FirmwareMinor: 10},
+		2: {FirmwareMajor: 3, FirmwareMinor: 9},
+	}))
+}
@@ -0,0 +1,69 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// What the arm does when it's closed
+const (
+	shutdownHold = "hold"
+	shutdownLimp = "limp"
+	shutdownPark = "park"
+)
+
+// shutdownTimeout bounds the park move so a stuck joint can't hang Close
+const shutdownTimeout = 15 * time.Second
+
+// shutdown applies shutdown_behavior while the arm is closing. Close's context is often
+// already short on time during a reconfigure, so the park move gets its own.
+func (s *so101) shutdown() {
+	behavior := s.config().ShutdownBehavior
+	if behavior == "" || behavior == shutdownHold {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if behavior == shutdownPark {
+		if err := s.park(ctx); err != nil {
+			s.logger.Warnf("Failed to park arm on close, disabling torque where it is: %v", err)
+		}
+	}
+
+	for _, id := range s.servoIDs() {
+		if err := s.controller.WriteServoRegister(ctx, id, "torque_enable", []byte{0}); err != nil {
+			s.logger.Warnf("Failed to disable torque on servo %d during shutdown: %v", id, err)
+		}
+	}
+	s.logger.Infof("Arm shut down with %q behavior", behavior)
+}
+
+// park moves the arm to its park pose slowly and waits for it to arrive
+func (s *so101) park(ctx context.Context) error {
+	servoIDs := s.servoIDs()
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	current, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+	if err != nil {
+		return fmt.Errorf("failed to read joint positions: %w", err)
+	}
+	target, err := s.parkPoseRadians()
+	if err != nil {
+		return fmt.Errorf("failed to compute park pose: %w", err)
+	}
+	if err := s.controller.MoveServosToPositions(ctx, servoIDs, target, cancelRecoverySpeedDegsPerSec, 0); err != nil {
+		return err
+	}
+
+	maxMovement := 0.0
+	for i := range target {
+		maxMovement = math.Max(maxMovement, math.Abs(target[i]-current[i]))
+	}
+	expected := time.Duration(RadiansToDegrees(maxMovement) / cancelRecoverySpeedDegsPerSec * float64(time.Second))
+	return s.waitForMove(ctx, target, expected)
+}
@@ -0,0 +1,85 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// snapshot collects the arm's full state in one structure for issue reports and dataset
+// labeling. Read failures are recorded under "errors" instead of failing the whole snapshot.
+func (s *so101) snapshot(ctx context.Context) map[string]interface{} {
+	servoIDs := s.servoIDs()
+	errs := []string{}
+	result := map[string]interface{}{
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"arm":       s.name.ShortName(),
+		"port":      s.config().Port,
+		"is_moving": s.isMoving.Load(),
+	}
+
+	joints := map[string]interface{}{}
+	positions, err := s.controller.GetJointPositionsForServos(ctx, servoIDs)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("joint positions: %v", err))
+	}
+	for i, servoID := range servoIDs {
+		joint := map[string]interface{}{"servo_id": servoID}
+		if positions != nil {
+			joint["position_degrees"] = RadiansToDegrees(positions[i])
+		}
+		s.addServoState(ctx, joint, servoID, &errs)
+		joints[jointNameForServo(servoID)] = joint
+	}
+	result["joints"] = joints
+
+	if positions != nil {
+		pose, err := s.EndPosition(ctx, nil)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("pose: %v", err))
+		} else {
+			result["pose"] = poseToMap(pose)
+		}
+	}
+
+	if gripperID, ok := s.gripperServoID(); ok {
+		gripper := map[string]interface{}{"servo_id": gripperID}
+		if gripperPositions, err := s.controller.GetJointPositionsForServos(ctx, []int{gripperID}); err != nil {
+			errs = append(errs, fmt.Sprintf("gripper position: %v", err))
+		} else {
+			gripper["aperture_percent"] = GripperRadiansToPercent(gripperPositions[0])
+		}
+		s.addServoState(ctx, gripper, gripperID, &errs)
+		result["gripper"] = gripper
+	}
+
+	result["health"] = s.health()
+	result["errors"] = errs
+	return result
+}
+
+// gripperServoID returns the servo_id of the gripper component on the arm's port, false when
+// none is configured
+func (s *so101) gripperServoID() (int, bool) {
+	for _, consumer := range GetSharedConsumers(s.config().Port) {
+		if consumer.Gripper && len(consumer.ServoIDs) == 1 {
+			return consumer.ServoIDs[0], true
+		}
+	}
+	return 0, false
+}
+
+// addServoState adds the torque state and temperature of a servo to a snapshot entry
+func (s *so101) addServoState(ctx context.Context, entry map[string]interface{}, servoID int, errs *[]string) {
+	if data, err := s.controller.ReadServoRegister(ctx, servoID, "torque_enable"); err != nil {
+		*errs = append(*errs, fmt.Sprintf("servo %d torque: %v", servoID, err))
+	} else if len(data) > 0 {
+		entry["torque_enabled"] = data[0] != 0
+	}
+
+	if data, err := s.controller.ReadServoRegister(ctx, servoID, "present_temp"); err != nil {
+		*errs = append(*errs, fmt.Sprintf("servo %d temperature: %v", servoID, err))
+	} else if len(data) > 0 {
+		entry["temperature_c"] = int(data[0])
+	}
+}
@@ -0,0 +1,79 @@
+package so_arm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/logging"
+)
+
+func TestSnapshotUsesConfiguredGripper(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, fake := newFakeController(t, 1, 2, 3, 4, 5, 7)
+	controller.estop = &emergencyStop{}
+	controller.maintenance = &maintenanceMode{}
+	model, err := makeSO101ModelFrame()
+	require.NoError(t, err)
+
+	port := "/dev/ttySNAPSHOT0"
+	globalRegistry.mu.Lock()
+	globalRegistry.entries[port] = &ControllerEntry{controller: controller, refCount: 1}
+	globalRegistry.mu.Unlock()
+	t.Cleanup(func() {
+		globalRegistry.mu.Lock()
+		delete(globalRegistry.entries, port)
+		globalRegistry.mu.Unlock()
+	})
+
+	s := &so101{
+		name:        arm.Named("arm"),
+		cfg:         &SO101ArmConfig{Port: port},
+		controller:  controller,
+		maintenance: controller.maintenance,
+		armServoIDs: ids,
+		model:       model,
+		logger:      logger,
+		logs:        newRateLimitedLogger(logger, 0),
+		events:      &eventLog{},
+		usage:       newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger),
+		thermal:     newThermalMonitor(0, ""),
+		watchdog:    newCommWatchdog(0),
+	}
+
+	// Without a gripper component there is no gripper to report or address
+	snapshot := s.snapshot(ctx)
+	assert.NotContains(t, snapshot, "gripper")
+	_, err = s.readRegister(ctx, map[string]interface{}{"servo_id": 7.0, "register": "present_temp"})
+	assert.Error(t, err)
+	settingsIDs, err := s.settingsServoIDs(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, ids, settingsIDs)
+
+	// The gripper's servo_id is used, not a fixed servo 6
+	RegisterSharedConsumer(port, ConsumerInfo{Name: "gripper", ServoIDs: []int{7}, Gripper: true})
+	fake.setRegister(7, feetech.RegTorqueEnable, []byte{1})
+	fake.setRegister(7, feetech.RegPresentTemp, []byte{41})
+
+	snapshot = s.snapshot(ctx)
+	gripper, ok := snapshot["gripper"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, 7, gripper["servo_id"])
+	assert.Contains(t, gripper, "aperture_percent")
+	assert.Equal(t, true, gripper["torque_enabled"])
+	assert.Equal(t, 41, gripper["temperature_c"])
+	assert.Empty(t, snapshot["errors"])
+
+	result, err := s.readRegister(ctx, map[string]interface{}{"servo_id": 7.0, "register": "present_temp"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 41, result["value"])
+	settingsIDs, err = s.settingsServoIDs(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 7}, settingsIDs)
+}
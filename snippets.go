@@ -0,0 +1,613 @@
+package so_arm
+
+// clientSnippet is an example DoCommand payload with the units of each parameter
+type clientSnippet struct {
+	Command     string
+	Description string
+	Payload     map[string]interface{}
+	Units       map[string]string
+}
+
+func (c clientSnippet) toMap() map[string]interface{} {
+	units := map[string]interface{}{}
+	for param, unit := range c.Units {
+		units[param] = unit
+	}
+	return map[string]interface{}{
+		"command":     c.Command,
+		"description": c.Description,
+		"payload":     c.Payload,
+		"units":       units,
+	}
+}
+
+// clientSnippetsResponse builds the client_snippets DoCommand response
+func clientSnippetsResponse(model string, snippets []clientSnippet) map[string]interface{} {
+	results := make([]interface{}, 0, len(snippets))
+	for _, snippet := range snippets {
+		results = append(results, snippet.toMap())
+	}
+	return map[string]interface{}{
+		"model":    model,
+		"snippets": results,
+	}
+}
+
+var armClientSnippets = []clientSnippet{
+	{
+		Command:     "set_torque",
+		Description: "Enable or disable torque on all arm joints",
+		Payload:     map[string]interface{}{"command": "set_torque", "enable": true},
+	},
+	{
+		Command:     "emergency_stop",
+		Description: "Disable torque on every servo and block motion until cleared",
+		Payload:     map[string]interface{}{"command": "emergency_stop", "reason": "operator stop"},
+	},
+	{
+		Command:     "clear_emergency_stop",
+		Description: "Release the emergency stop latch (torque stays off)",
+		Payload:     map[string]interface{}{"command": "clear_emergency_stop"},
+	},
+	{
+		Command:     "emergency_stop_status",
+		Description: "Show whether the emergency stop is latched, and why",
+		Payload:     map[string]interface{}{"command": "emergency_stop_status"},
+	},
+	{
+		Command:     "ping",
+		Description: "Test communication with the arm's servos",
+		Payload:     map[string]interface{}{"command": "ping"},
+	},
+	{
+		Command:     "controller_status",
+		Description: "Show the shared controller status and servo firmware versions",
+		Payload:     map[string]interface{}{"command": "controller_status"},
+	},
+	{
+		Command:     "diagnose",
+		Description: "Run connection diagnostics",
+		Payload:     map[string]interface{}{"command": "diagnose"},
+	},
+	{
+		Command:     "verify_config",
+		Description: "Verify servo configuration and communication",
+		Payload:     map[string]interface{}{"command": "verify_config"},
+	},
+	{
+		Command:     "reinitialize",
+		Description: "Reinitialize servo communication",
+		Payload:     map[string]interface{}{"command": "reinitialize", "retries": 3},
+		Units:       map[string]string{"retries": "count"},
+	},
+	{
+		Command:     "test_servo_communication",
+		Description: "Read positions from the arm servos",
+		Payload:     map[string]interface{}{"command": "test_servo_communication"},
+	},
+	{
+		Command:     "reload_calibration",
+		Description: "Reload calibration from the configured file",
+		Payload:     map[string]interface{}{"command": "reload_calibration"},
+	},
+	{
+		Command:     "get_calibration",
+		Description: "Return the calibration currently in use",
+		Payload:     map[string]interface{}{"command": "get_calibration"},
+	},
+	{
+		Command:     "set_calibration_profile",
+		Description: "Switch to another calibration profile in calibration_profiles_dir",
+		Payload:     map[string]interface{}{"command": "set_calibration_profile", "profile": "leader"},
+	},
+	{
+		Command:     "list_calibration_profiles",
+		Description: "List the calibration profiles and the active one",
+		Payload:     map[string]interface{}{"command": "list_calibration_profiles"},
+	},
+	{
+		Command:     "health",
+		Description: "Report usage, thermal state and whether maintenance is due",
+		Payload:     map[string]interface{}{"command": "health"},
+	},
+	{
+		Command:     "thermal_status",
+		Description: "Report servo temperatures and which joints are thermally protected",
+		Payload:     map[string]interface{}{"command": "thermal_status"},
+	},
+	{
+		Command:     "get_joint_loads",
+		Description: "Read the signed load on each joint as a percentage of stall torque",
+		Payload:     map[string]interface{}{"command": "get_joint_loads"},
+	},
+	{
+		Command:     "get_power_status",
+		Description: "Read supply voltage and current draw of each joint",
+		Payload:     map[string]interface{}{"command": "get_power_status"},
+	},
+	{
+		Command:     "get_usage_summary",
+		Description: "Report moves, torque-on hours and errors by type since the last reset_usage",
+		Payload:     map[string]interface{}{"command": "get_usage_summary"},
+	},
+	{
+		Command:     "reset_usage",
+		Description: "Clear accumulated usage after servicing the arm",
+		Payload:     map[string]interface{}{"command": "reset_usage"},
+	},
+	{
+		Command:     "lint_config",
+		Description: "Check for common misconfigurations",
+		Payload:     map[string]interface{}{"command": "lint_config"},
+	},
+	{
+		Command:     "dump_servo_settings",
+		Description: "Back up every servo register to JSON before swapping a servo",
+		Payload:     map[string]interface{}{"command": "dump_servo_settings"},
+	},
+	{
+		Command:     "dump_registers",
+		Description: "Read one servo's whole EEPROM and RAM control table by name",
+		Payload:     map[string]interface{}{"command": "dump_registers", "servo_id": 3},
+	},
+	{
+		Command:     "read_register",
+		Description: "Read one named register of a servo",
+		Payload:     map[string]interface{}{"command": "read_register", "servo_id": 3, "register": "p_gain"},
+	},
+	{
+		Command:     "write_register",
+		Description: "Write one named register of a servo, EEPROM registers need torque off",
+		Payload:     map[string]interface{}{"command": "write_register", "servo_id": 3, "register": "p_gain", "value": 16},
+	},
+	{
+		Command:     "restore_servo_settings",
+		Description: "Configure a replacement servo from a dump_servo_settings snapshot (torque off)",
+		Payload: map[string]interface{}{
+			"command":   "restore_servo_settings",
+			"snapshot":  map[string]interface{}{"servos": map[string]interface{}{"3": map[string]interface{}{"p_gain": 16}}},
+			"servo_ids": []interface{}{3},
+		},
+	},
+	{
+		Command:     "get_torque",
+		Description: "Read whether torque is enabled on each servo",
+		Payload:     map[string]interface{}{"command": "get_torque"},
+	},
+	{
+		Command:     "home",
+		Description: "Move to the calibrated centers and report each joint's settling error",
+		Payload:     map[string]interface{}{"command": "home", "tolerance_degs": 2},
+		Units:       map[string]string{"tolerance_degs": "degrees"},
+	},
+	{
+		Command:     "goto_pose",
+		Description: "Move to a named pose from poses or poses_file",
+		Payload:     map[string]interface{}{"command": "goto_pose", "name": "home", "speed_degs_per_sec": 30},
+		Units:       map[string]string{"speed_degs_per_sec": "degrees/second"},
+	},
+	{
+		Command:     "list_poses",
+		Description: "List the named poses and programs from poses and poses_file",
+		Payload:     map[string]interface{}{"command": "list_poses"},
+	},
+	{
+		Command:     "run_program",
+		Description: "Visit the poses of a program from poses_file in order",
+		Payload:     map[string]interface{}{"command": "run_program", "name": "pick"},
+	},
+	{
+		Command:     "import_poses",
+		Description: "Reload poses and programs from poses_file",
+		Payload:     map[string]interface{}{"command": "import_poses"},
+	},
+	{
+		Command:     "export_poses",
+		Description: "Write the named poses and programs to a YAML pose file",
+		Payload:     map[string]interface{}{"command": "export_poses", "path": "/home/user/poses.yaml"},
+	},
+	{
+		Command:     "get_park_pose",
+		Description: "Show the pose used for parking, from park_pose or computed from the kinematic model",
+		Payload:     map[string]interface{}{"command": "get_park_pose"},
+	},
+	{
+		Command:     "maintenance_mode",
+		Description: "Park the arm, disable torque and block motion while servicing tooling",
+		Payload:     map[string]interface{}{"command": "maintenance_mode", "enable": true, "reason": "servicing"},
+	},
+	{
+		Command:     "collision_status",
+		Description: "Show collision detection settings and the last collision",
+		Payload:     map[string]interface{}{"command": "collision_status"},
+	},
+	{
+		Command:     "clear_collision",
+		Description: "Restore torque limits lowered after a collision",
+		Payload:     map[string]interface{}{"command": "clear_collision"},
+	},
+	{
+		Command:     "clear_decalibration",
+		Description: "Clear the possible_decalibration flag once the arm has been checked",
+		Payload:     map[string]interface{}{"command": "clear_decalibration"},
+	},
+	{
+		Command:     "watchdog_status",
+		Description: "Check whether the communication watchdog stopped the arm mid-move",
+		Payload:     map[string]interface{}{"command": "watchdog_status"},
+	},
+	{
+		Command:     "clear_watchdog_fault",
+		Description: "Clear a communication watchdog fault after the bus recovers",
+		Payload:     map[string]interface{}{"command": "clear_watchdog_fault"},
+	},
+	{
+		Command:     "position_cache_status",
+		Description: "Show the background position cache's rate and freshness",
+		Payload:     map[string]interface{}{"command": "position_cache_status"},
+	},
+	{
+		Command:     "joint_staleness",
+		Description: "Show each joint's last good read age when degraded_reads is enabled",
+		Payload:     map[string]interface{}{"command": "joint_staleness"},
+	},
+	{
+		Command:     "bus_stats",
+		Description: "Show the bus timing, error counters and latency percentiles",
+		Payload:     map[string]interface{}{"command": "bus_stats"},
+	},
+	{
+		Command:     "support_bundle",
+		Description: "Write config, calibration, events and a snapshot to one JSON file for issue reports",
+		Payload:     map[string]interface{}{"command": "support_bundle"},
+	},
+	{
+		Command:     "snapshot",
+		Description: "Capture joints, pose, gripper, torque, temperatures and health in one blob",
+		Payload:     map[string]interface{}{"command": "snapshot"},
+	},
+	{
+		Command:     "visualization_state",
+		Description: "Return per-frame poses and link geometries for rendering the arm",
+		Payload:     map[string]interface{}{"command": "visualization_state"},
+		Units:       map[string]string{"x": "millimeters", "theta": "degrees"},
+	},
+	{
+		Command:     "waypoint_filter_status",
+		Description: "Show how many waypoints waypoint_epsilon_degs pruned",
+		Payload:     map[string]interface{}{"command": "waypoint_filter_status"},
+	},
+	{
+		Command:     "execute_trajectory",
+		Description: "Play back a timed trajectory, checking it against the arm's speed first",
+		Payload: map[string]interface{}{
+			"command":        "execute_trajectory",
+			"positions_degs": [][]float64{{0, 0, 0, 0, 0}, {10, -20, 15, 0, 0}},
+			"times_s":        []float64{0, 0.5},
+			"on_infeasible":  "time_scale",
+		},
+		Units: map[string]string{"positions_degs": "degrees", "times_s": "seconds"},
+	},
+	{
+		Command:     "last_trajectory_stats",
+		Description: "Show per-joint velocity, following error and overshoot from the last execute_trajectory",
+		Payload:     map[string]interface{}{"command": "last_trajectory_stats"},
+	},
+	{
+		Command:     "jog_cartesian",
+		Description: "Nudge the end effector along a direction in the base frame",
+		Payload: map[string]interface{}{
+			"command":          "jog_cartesian",
+			"direction":        []float64{0, 0, 1},
+			"distance_mm":      10,
+			"speed_mm_per_sec": 20,
+		},
+		Units: map[string]string{"direction": "unit-less vector, normalized", "distance_mm": "millimeters", "speed_mm_per_sec": "millimeters/second"},
+	},
+	{
+		Command:     "follow",
+		Description: "Start or stop tracking the target published by follow_source",
+		Payload:     map[string]interface{}{"command": "follow", "enable": true},
+	},
+	{
+		Command:     "follow_status",
+		Description: "Show whether the arm is following, its update counts and why it last stopped",
+		Payload:     map[string]interface{}{"command": "follow_status"},
+	},
+	{
+		Command:     "get_identity",
+		Description: "Return the nameplate stored for this physical arm",
+		Payload:     map[string]interface{}{"command": "get_identity"},
+	},
+	{
+		Command:     "set_identity",
+		Description: "Store a nameplate that follows this arm's USB adapter across port changes",
+		Payload:     map[string]interface{}{"command": "set_identity", "name": "left-arm", "serial": "SO101-0042", "assembly_date": "2025-06-01"},
+		Units:       map[string]string{"assembly_date": "YYYY-MM-DD"},
+	},
+	{
+		Command:     "compliance_mode",
+		Description: "Lower torque limit and P gain so the arm can be pushed by hand while holding against gravity",
+		Payload:     map[string]interface{}{"command": "compliance_mode", "enable": true, "torque_limit_percent": 30, "p_gain": 8},
+		Units:       map[string]string{"torque_limit_percent": "percent of stall torque", "p_gain": "servo P coefficient (1-254)"},
+	},
+	{
+		Command:     "set_velocity_mode",
+		Description: "Switch a joint into or out of continuous velocity (wheel) mode",
+		Payload:     map[string]interface{}{"command": "set_velocity_mode", "servo_id": 1, "enable": true},
+	},
+	{
+		Command:     "set_joint_velocity",
+		Description: "Spin a joint in velocity mode at a signed speed, 0 stops it",
+		Payload:     map[string]interface{}{"command": "set_joint_velocity", "servo_id": 1, "degs_per_sec": 30},
+		Units:       map[string]string{"degs_per_sec": "degrees/second"},
+	},
+	{
+		Command:     "set_speed",
+		Description: "Set the default joint speed",
+		Payload:     map[string]interface{}{"set_speed": 50},
+		Units:       map[string]string{"set_speed": "degrees/second"},
+	},
+	{
+		Command:     "set_acceleration",
+		Description: "Set the default joint acceleration",
+		Payload:     map[string]interface{}{"set_acceleration": 100},
+		Units:       map[string]string{"set_acceleration": "degrees/second²"},
+	},
+	{
+		Command:     "get_motion_params",
+		Description: "Return the current speed and acceleration",
+		Payload:     map[string]interface{}{"get_motion_params": true},
+	},
+}
+
+var gripperClientSnippets = []clientSnippet{
+	{
+		Command:     "get_position",
+		Description: "Read the current gripper position",
+		Payload:     map[string]interface{}{"command": "get_position"},
+	},
+	{
+		Command:     "set_position",
+		Description: "Move the gripper to an opening percentage",
+		Payload:     map[string]interface{}{"command": "set_position", "percentage": 50},
+		Units:       map[string]string{"percentage": "percent open (0-100)"},
+	},
+	{
+		Command:     "get_torque",
+		Description: "Read whether the gripper servo has torque enabled",
+		Payload:     map[string]interface{}{"command": "get_torque"},
+	},
+	{
+		Command:     "controller_status",
+		Description: "Show the shared controller status and servo firmware versions",
+		Payload:     map[string]interface{}{"command": "controller_status"},
+	},
+	{
+		Command:     "calibrate_positions",
+		Description: "Set the open and closed positions",
+		Payload:     map[string]interface{}{"command": "calibrate_positions", "open_position": 95, "closed_position": 0},
+		Units:       map[string]string{"open_position": "percent (0-100)", "closed_position": "percent (0-100)"},
+	},
+	{
+		Command:     "set_motion_params",
+		Description: "Set gripper speed and acceleration",
+		Payload:     map[string]interface{}{"command": "set_motion_params", "speed": 30, "acceleration": 50},
+		Units:       map[string]string{"speed": "degrees/second", "acceleration": "degrees/second²"},
+	},
+	{
+		Command:     "get_motion_params",
+		Description: "Return gripper speed and acceleration",
+		Payload:     map[string]interface{}{"command": "get_motion_params"},
+	},
+	{
+		Command:     "emergency_stop",
+		Description: "Disable torque on every servo on the port and block motion until cleared",
+		Payload:     map[string]interface{}{"command": "emergency_stop"},
+	},
+	{
+		Command:     "clear_emergency_stop",
+		Description: "Release the emergency stop latch on the port (torque stays off)",
+		Payload:     map[string]interface{}{"command": "clear_emergency_stop"},
+	},
+	{
+		Command:     "emergency_stop_status",
+		Description: "Show whether the emergency stop is latched, and why",
+		Payload:     map[string]interface{}{"command": "emergency_stop_status"},
+	},
+	{
+		Command:     "auto_calibrate",
+		Description: "Find the gripper's end stops and set open/closed positions from them",
+		Payload:     map[string]interface{}{"command": "auto_calibrate", "save": false},
+	},
+	{
+		Command:     "get_object_width",
+		Description: "Estimate the width of the last grabbed object in millimeters",
+		Payload:     map[string]interface{}{"command": "get_object_width"},
+	},
+	{
+		Command:     "grab_with_force",
+		Description: "Close until the grip load reaches a target, for fragile objects",
+		Payload:     map[string]interface{}{"command": "grab_with_force", "force_percent": 20.0},
+	},
+	{
+		Command:     "characterize_force",
+		Description: "Map grip load to jaw force with a force gauge, one step at a time",
+		Payload:     map[string]interface{}{"command": "characterize_force", "step": "record", "force_n": 3.2},
+		Units:       map[string]string{"force_n": "newtons"},
+	},
+	{
+		Command:     "get_overload_status",
+		Description: "Report whether the gripper has backed off from a sustained overload",
+		Payload:     map[string]interface{}{"command": "get_overload_status"},
+	},
+	{
+		Command:     "lint_config",
+		Description: "Check for common misconfigurations",
+		Payload:     map[string]interface{}{"command": "lint_config"},
+	},
+}
+
+var calibrationClientSnippets = []clientSnippet{
+	{
+		Command:     "start",
+		Description: "Begin the calibration workflow",
+		Payload:     map[string]interface{}{"command": "start"},
+	},
+	{
+		Command:     "calibrate_joint",
+		Description: "Begin the calibration workflow for one joint, leaving the others as they are",
+		Payload:     map[string]interface{}{"command": "calibrate_joint", "servo_id": 3},
+		Units:       map[string]string{"servo_id": "servo ID (1-6)"},
+	},
+	{
+		Command:     "set_homing",
+		Description: "Record the middle of each joint's range as home",
+		Payload:     map[string]interface{}{"command": "set_homing"},
+	},
+	{
+		Command:     "detect_drive_mode",
+		Description: "After set_homing, nudge each joint toward its positive direction, then detect which servos count backwards",
+		Payload:     map[string]interface{}{"command": "detect_drive_mode", "min_move_steps": 100},
+		Units:       map[string]string{"min_move_steps": "raw encoder steps"},
+	},
+	{
+		Command:     "start_range_recording",
+		Description: "Start recording joint ranges",
+		Payload:     map[string]interface{}{"command": "start_range_recording"},
+	},
+	{
+		Command:     "auto_range",
+		Description: "Find joint ranges by driving each joint into its stops, instead of recording them by hand",
+		Payload: map[string]interface{}{
+			"command":        "auto_range",
+			"margin_steps":   20,
+			"torque_percent": 35.0,
+		},
+		Units: map[string]string{"margin_steps": "raw encoder steps", "torque_percent": "percent of stall torque"},
+	},
+	{
+		Command:     "stop_range_recording",
+		Description: "Finish recording joint ranges",
+		Payload:     map[string]interface{}{"command": "stop_range_recording"},
+	},
+	{
+		Command:     "validate_calibration",
+		Description: "Drive each joint to its recorded min, center and max with torque on and check it gets there",
+		Payload: map[string]interface{}{
+			"command":         "validate_calibration",
+			"tolerance_steps": 25,
+			"torque_percent":  50.0,
+		},
+		Units: map[string]string{"tolerance_steps": "raw encoder steps", "torque_percent": "percent of stall torque"},
+	},
+	{
+		Command:     "save_calibration",
+		Description: "Write limits to the servos and save the calibration file",
+		Payload:     map[string]interface{}{"command": "save_calibration"},
+	},
+	{
+		Command:     "retry_failed_writes",
+		Description: "Rewrite limits to servos that failed during save_calibration",
+		Payload:     map[string]interface{}{"command": "retry_failed_writes"},
+	},
+	{
+		Command:     "abort",
+		Description: "Cancel calibration",
+		Payload:     map[string]interface{}{"command": "abort"},
+	},
+	{
+		Command:     "reset",
+		Description: "Reset the workflow after an error",
+		Payload:     map[string]interface{}{"command": "reset"},
+	},
+	{
+		Command:     "get_current_positions",
+		Description: "Read raw servo positions",
+		Payload:     map[string]interface{}{"command": "get_current_positions"},
+	},
+	{
+		Command:     "replay_calibration",
+		Description: "Show the angles a calibration produces for captured raw positions, without hardware",
+		Payload: map[string]interface{}{
+			"command":          "replay_calibration",
+			"calibration_file": "so101_calibration.json",
+			"raw_positions":    map[string]interface{}{"shoulder_pan": []int{2048, 2600}},
+		},
+		Units: map[string]string{"raw_positions": "servo steps (0-4095)"},
+	},
+	{
+		Command:     "get_calibration_json",
+		Description: "Return the calibration in use as calibration file JSON, for backup or cloning",
+		Payload:     map[string]interface{}{"command": "get_calibration_json"},
+	},
+	{
+		Command:     "set_calibration_json",
+		Description: "Save a calibration from get_calibration_json to calibration_file and apply it",
+		Payload: map[string]interface{}{
+			"command":          "set_calibration_json",
+			"calibration_json": `{"shoulder_pan": {"id": 1, "drive_mode": 0, "homing_offset": 0, "range_min": 500, "range_max": 3500}}`,
+		},
+		Units: map[string]string{"calibration_json": "calibration file JSON, ranges in servo steps (0-4095)"},
+	},
+	{
+		Command:     "list_calibration_backups",
+		Description: "List the saved copies of the calibration file, newest first",
+		Payload:     map[string]interface{}{"command": "list_calibration_backups"},
+	},
+	{
+		Command:     "restore_calibration",
+		Description: "Restore a calibration backup to the file and the servos, the newest if backup is omitted",
+		Payload:     map[string]interface{}{"command": "restore_calibration", "backup": "20261016T142530.120Z"},
+		Units:       map[string]string{"backup": "backup id from list_calibration_backups"},
+	},
+	{
+		Command:     "dump_registers",
+		Description: "Read one servo's whole EEPROM and RAM control table by name",
+		Payload:     map[string]interface{}{"command": "dump_registers", "servo_id": 3},
+	},
+	{
+		Command:     "motor_setup_discover",
+		Description: "Discover a single motor connected to the bus",
+		Payload:     map[string]interface{}{"command": "motor_setup_discover", "motor_name": "gripper"},
+	},
+	{
+		Command:     "motor_setup_assign_id",
+		Description: "Assign the target ID and baudrate to a discovered motor",
+		Payload: map[string]interface{}{
+			"command": "motor_setup_assign_id", "motor_name": "gripper",
+			"current_id": 1, "target_id": 6, "current_baudrate": 1000000,
+		},
+		Units: map[string]string{"current_baudrate": "bits/second"},
+	},
+	{
+		Command:     "motor_setup_verify",
+		Description: "Verify all SO-101 motors are configured and report their firmware",
+		Payload:     map[string]interface{}{"command": "motor_setup_verify"},
+	},
+	{
+		Command:     "motor_setup_scan_bus",
+		Description: "Scan the bus for connected servos",
+		Payload:     map[string]interface{}{"command": "motor_setup_scan_bus"},
+	},
+	{
+		Command:     "motor_setup_run_all",
+		Description: "Start the motor setup wizard, which prompts in Readings for each motor in turn",
+		Payload:     map[string]interface{}{"command": "motor_setup_run_all"},
+	},
+	{
+		Command:     "motor_setup_continue",
+		Description: "Set up the motor the wizard asked for, or verify the arm once every motor is done",
+		Payload:     map[string]interface{}{"command": "motor_setup_continue"},
+	},
+	{
+		Command:     "factory_reset_servo",
+		Description: "Factory reset one servo, call once for a token then again with it as confirm",
+		Payload:     map[string]interface{}{"command": "factory_reset_servo", "servo_id": 3},
+	},
+	{
+		Command:     "motor_setup_reset_status",
+		Description: "Reset motor setup status",
+		Payload:     map[string]interface{}{"command": "motor_setup_reset_status"},
+	},
+}
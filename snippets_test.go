@@ -0,0 +1,73 @@
+package so_arm
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doCommandCases returns the commands handled by the top-level switch of a DoCommand method
+func doCommandCases(t *testing.T, file, receiver, method string) []string {
+	t.Helper()
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	require.NoError(t, err)
+
+	var body *ast.BlockStmt
+	for _, decl := range parsed.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != method || fn.Recv == nil {
+			continue
+		}
+		if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok && star.X.(*ast.Ident).Name == receiver {
+			body = fn.Body
+		}
+	}
+	require.NotNil(t, body, "%s.%s not found in %s", receiver, method, file)
+
+	var commands []string
+	for _, stmt := range body.List {
+		sw, ok := stmt.(*ast.SwitchStmt)
+		if !ok {
+			continue
+		}
+		for _, clause := range sw.Body.List {
+			for _, expr := range clause.(*ast.CaseClause).List {
+				if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					command, err := strconv.Unquote(lit.Value)
+					require.NoError(t, err)
+					commands = append(commands, command)
+				}
+			}
+		}
+		break
+	}
+	require.NotEmpty(t, commands)
+	return commands
+}
+
+func TestEveryCommandHasSnippet(t *testing.T) {
+	for _, tc := range []struct {
+		file, receiver, method string
+		snippets               []clientSnippet
+	}{
+		{"arm.go", "so101", "doCommand", armClientSnippets},
+		{"gripper.go", "so101Gripper", "doCommand", gripperClientSnippets},
+		{"calibration.go", "so101CalibrationSensor", "DoCommand", calibrationClientSnippets},
+	} {
+		documented := map[string]bool{}
+		for _, snippet := range tc.snippets {
+			documented[snippet.Command] = true
+		}
+		for _, command := range doCommandCases(t, tc.file, tc.receiver, tc.method) {
+			if command == "client_snippets" {
+				continue
+			}
+			assert.True(t, documented[command], "%s command %q has no client snippet", tc.receiver, command)
+		}
+	}
+}
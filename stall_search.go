@@ -0,0 +1,74 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.viam.com/rdk/logging"
+)
+
+// stallSearch tunes how findStallPosition walks a servo into a mechanical stop. The stop is
+// wherever the load spikes or the servo stops keeping up with its goal.
+type stallSearch struct {
+	StepSteps        int
+	StepInterval     time.Duration
+	LagSteps         int
+	StallLoadPercent float64
+	Timeout          time.Duration
+}
+
+// findStallPosition walks a servo one small step at a time in direction (+1 or -1 in raw
+// steps) until it stalls, then holds it where it stopped and returns that raw position. The
+// end of the encoder range counts as a stop, for joints that turn all the way round. The
+// servo's torque must be enabled.
+func findStallPosition(ctx context.Context, controller *SafeSoArmController, servoID, direction int, search stallSearch, logger logging.Logger) (int, error) {
+	data, err := controller.ReadServoRegister(ctx, servoID, "present_position")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read servo %d position: %w", servoID, err)
+	}
+	proto := controller.protocolFor(servoID)
+	goal := int(proto.DecodeWord(data))
+
+	deadline := time.Now().Add(search.Timeout)
+	for time.Now().Before(deadline) {
+		goal = min(max(goal+direction*search.StepSteps, 0), ServoMaxPosition)
+		if err := controller.WriteServoRegister(ctx, servoID, "goal_position", proto.EncodeWord(uint16(goal))); err != nil {
+			return 0, fmt.Errorf("failed to step servo %d: %w", servoID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(search.StepInterval):
+		}
+
+		data, err := controller.ReadServoRegister(ctx, servoID, "present_position")
+		if err != nil {
+			continue
+		}
+		present := int(proto.DecodeWord(data))
+		lag := goal - present
+		if lag < 0 {
+			lag = -lag
+		}
+
+		stalled := goal == 0 || goal == ServoMaxPosition || lag > search.LagSteps
+		if !stalled {
+			loads, err := controller.GetServoLoads(ctx, []int{servoID})
+			if err != nil {
+				return 0, fmt.Errorf("failed to read servo %d load: %w", servoID, err)
+			}
+			stalled = math.Abs(loads[servoID]) >= search.StallLoadPercent
+		}
+		if stalled {
+			// Stop pushing into the end stop
+			if err := controller.WriteServoRegister(ctx, servoID, "goal_position", proto.EncodeWord(uint16(present))); err != nil {
+				logger.Warnf("Failed to relax servo %d at its stop: %v", servoID, err)
+			}
+			return present, nil
+		}
+	}
+	return 0, fmt.Errorf("no stop reached within %v", search.Timeout)
+}
@@ -0,0 +1,122 @@
+package so_arm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// redactedKeyParts mark config attributes whose values are left out of support bundles
+var redactedKeyParts = []string{"secret", "token", "password", "api_key", "credential"}
+
+// buildInfo describes the running module binary
+func buildInfo() map[string]interface{} {
+	result := map[string]interface{}{"go_version": runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return result
+	}
+	result["module"] = info.Main.Path
+	result["version"] = info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision", "vcs.time", "vcs.modified":
+			result[strings.TrimPrefix(setting.Key, "vcs.")] = setting.Value
+		}
+	}
+	return result
+}
+
+// redactConfig converts a config to a map with secret-looking values replaced
+func redactConfig(cfg interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	redactMap(result)
+	return result, nil
+}
+
+func redactMap(m map[string]interface{}) {
+	for key := range m {
+		lower := strings.ToLower(key)
+		for _, part := range redactedKeyParts {
+			if strings.Contains(lower, part) {
+				m[key] = "<redacted>"
+			}
+		}
+		if nested, ok := m[key].(map[string]interface{}); ok {
+			redactMap(nested)
+		}
+	}
+}
+
+// supportBundle handles the support_bundle DoCommand. Everything needed to diagnose an issue
+// is gathered into one JSON file under the module data directory, and returned as well so
+// clients can save it directly.
+func (s *so101) supportBundle(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	cfg := s.config()
+	config, err := redactConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	refCount, hasController, controllerConfig := GetControllerStatus()
+
+	now := time.Now().UTC()
+	bundle := map[string]interface{}{
+		"generated_at": now.Format(time.RFC3339),
+		"component":    s.name.String(),
+		"build":        buildInfo(),
+		"config":       config,
+		"calibration":  s.controller.GetCalibration(),
+		"calibration_status": map[string]interface{}{
+			"loaded_from_file": s.calibrationLoaded.Load(),
+			"file":             cfg.CalibrationFile,
+		},
+		"controller": map[string]interface{}{
+			"ref_count":      refCount,
+			"has_controller": hasController,
+			"config":         controllerConfig,
+			"consumers":      GetSharedConsumers(cfg.Port),
+		},
+		"bus_stats":      s.controller.BusStats(),
+		"events":         s.events.recent(""),
+		"recent_errors":  s.events.recent(eventError),
+		"emergency_stop": s.controller.EmergencyStopStatus(),
+		"snapshot":       s.snapshot(ctx),
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode support bundle: %w", err)
+	}
+	path := filepath.Join(moduleDataDir(), fmt.Sprintf("so101_support_%s_%s.json", s.name.ShortName(), now.Format("20060102T150405Z")))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	s.logger.Infof("Wrote support bundle to %s", path)
+
+	result := map[string]interface{}{
+		"success": true,
+		"path":    path,
+	}
+	if include, ok := cmd["include_bundle"].(bool); !ok || include {
+		// Round trip through JSON so the response only holds plain values
+		var plain map[string]interface{}
+		if err := json.Unmarshal(data, &plain); err != nil {
+			return nil, err
+		}
+		result["bundle"] = plain
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactConfig(t *testing.T) {
+	cfg := map[string]interface{}{
+		"port":    "/dev/ttyUSB0",
+		"api_key": "abc",
+		"nested":  map[string]interface{}{"auth_token": "xyz", "speed": 30},
+	}
+	redacted, err := redactConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "/dev/ttyUSB0", redacted["port"])
+	assert.Equal(t, "<redacted>", redacted["api_key"])
+	nested := redacted["nested"].(map[string]interface{})
+	assert.Equal(t, "<redacted>", nested["auth_token"])
+	assert.Equal(t, 30.0, nested["speed"])
+}
+
+func TestEventLog(t *testing.T) {
+	var missing *eventLog
+	missing.add(eventInfo, "ignored")
+	assert.Empty(t, missing.recent(""))
+
+	log := &eventLog{}
+	for i := 0; i < maxRecentEvents+5; i++ {
+		log.add(eventWarning, "warning %d", i)
+	}
+	log.recordError("move", errors.New("stalled"))
+	log.recordError("move", fmt.Errorf("wrapped: %w", context.Canceled))
+	log.recordError("move", nil)
+
+	events := log.recent("")
+	assert.Len(t, events, maxRecentEvents)
+	assert.Equal(t, "warning 6", events[0]["message"])
+	errs := log.recent(eventError)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "move: stalled", errs[0]["message"])
+}
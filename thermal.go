@@ -0,0 +1,228 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Thermal protection defaults. The STS3215 cuts its own torque at 70°C, so protection kicks
+// in a little earlier and releases once the joint has cooled past the hysteresis.
+const (
+	defaultMaxTemperatureC     = 65.0
+	thermalHysteresisC         = 5.0
+	thermalPollInterval        = 5 * time.Second
+	thermalReducedSpeedFactor  = 0.5
+	thermalReducedTorqueFactor = 0.5
+)
+
+// What to do with a joint that exceeds max_temperature_c
+const (
+	thermalActionReduce  = "reduce"
+	thermalActionDisable = "disable"
+)
+
+// thermalMonitor tracks servo temperatures and which joints are being protected
+type thermalMonitor struct {
+	maxTempC float64
+	action   string
+
+	mu           sync.Mutex
+	temperatures map[int]int
+	hot          map[int]bool
+	savedLimits  map[int][]byte // torque_limit before it was reduced, keyed by servo ID
+	lastRead     time.Time
+	lastErr      error
+}
+
+func newThermalMonitor(maxTempC float64, action string) *thermalMonitor {
+	if maxTempC == 0 {
+		maxTempC = defaultMaxTemperatureC
+	}
+	if action == "" {
+		action = thermalActionReduce
+	}
+	return &thermalMonitor{
+		maxTempC:     maxTempC,
+		action:       action,
+		temperatures: map[int]int{},
+		hot:          map[int]bool{},
+		savedLimits:  map[int][]byte{},
+	}
+}
+
+// update records a temperature reading and reports whether the servo just crossed into or
+// out of the overheated state
+func (t *thermalMonitor) update(servoID, tempC int) (overheated, cooled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.temperatures[servoID] = tempC
+	switch {
+	case !t.hot[servoID] && float64(tempC) >= t.maxTempC:
+		t.hot[servoID] = true
+		return true, false
+	case t.hot[servoID] && float64(tempC) <= t.maxTempC-thermalHysteresisC:
+		delete(t.hot, servoID)
+		return false, true
+	}
+	return false, false
+}
+
+// reset forgets every servo's temperature and protection, returning the torque limits saved
+// for reduced joints so the caller can restore them
+func (t *thermalMonitor) reset() map[int][]byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	saved := t.savedLimits
+	t.temperatures = map[int]int{}
+	t.hot = map[int]bool{}
+	t.savedLimits = map[int][]byte{}
+	return saved
+}
+
+// speedFactor scales move speed while any joint is overheated in reduce mode
+func (t *thermalMonitor) speedFactor() float64 {
+	if t == nil {
+		return 1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.action == thermalActionReduce && len(t.hot) > 0 {
+		return thermalReducedSpeedFactor
+	}
+	return 1
+}
+
+// status reports the latest temperatures and protected joints
+func (t *thermalMonitor) status() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	temperatures := map[string]interface{}{}
+	for id, temp := range t.temperatures {
+		temperatures[jointNameForServo(id)] = temp
+	}
+	overheated := []string{}
+	for id := range t.hot {
+		overheated = append(overheated, jointNameForServo(id))
+	}
+	sort.Strings(overheated)
+
+	result := map[string]interface{}{
+		"max_temperature_c": t.maxTempC,
+		"thermal_action":    t.action,
+		"temperatures_c":    temperatures,
+		"overheated_joints": overheated,
+	}
+	if !t.lastRead.IsZero() {
+		result["last_read"] = t.lastRead.Format(time.RFC3339)
+	}
+	if t.lastErr != nil {
+		result["last_error"] = t.lastErr.Error()
+	}
+	return result
+}
+
+// monitorTemperatures polls the arm's servo temperatures until ctx is done
+func (s *so101) monitorTemperatures(ctx context.Context) {
+	ticker := time.NewTicker(thermalPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.checkTemperatures(ctx)
+	}
+}
+
+// checkTemperatures reads every arm servo's temperature and protects joints that are too hot
+func (s *so101) checkTemperatures(ctx context.Context) {
+	var readErr error
+	for _, id := range s.servoIDs() {
+		data, err := s.controller.ReadServoRegister(ctx, id, "present_temp")
+		if err != nil || len(data) == 0 {
+			if err == nil {
+				err = fmt.Errorf("empty response")
+			}
+			readErr = fmt.Errorf("servo %d temperature: %w", id, err)
+			s.logs.Warnf(fmt.Sprintf("thermal-read-%d", id), "Failed to read temperature of servo %d: %v", id, err)
+			continue
+		}
+
+		overheated, cooled := s.thermal.update(id, int(data[0]))
+		joint := jointNameForServo(id)
+		if overheated {
+			s.logger.Warnf("Joint %s is at %d°C, above max_temperature_c %.0f°C, applying %q thermal protection",
+				joint, data[0], s.thermal.maxTempC, s.thermal.action)
+			if err := s.protectJoint(ctx, id); err != nil {
+				s.logger.Errorf("Failed to apply thermal protection to joint %s: %v", joint, err)
+			}
+		}
+		if cooled {
+			s.logger.Infof("Joint %s has cooled to %d°C", joint, data[0])
+			if err := s.releaseJoint(ctx, id); err != nil {
+				s.logger.Errorf("Failed to lift thermal protection from joint %s: %v", joint, err)
+			}
+		}
+	}
+
+	s.thermal.mu.Lock()
+	s.thermal.lastRead = time.Now()
+	s.thermal.lastErr = readErr
+	s.thermal.mu.Unlock()
+}
+
+// protectJoint reduces the torque limit of an overheated servo or disables its torque
+func (s *so101) protectJoint(ctx context.Context, servoID int) error {
+	if s.thermal.action == thermalActionDisable {
+		return s.controller.WriteServoRegister(ctx, servoID, "torque_enable", []byte{0})
+	}
+	if s.complianceEnabled() {
+		// Compliance mode already runs with a low torque limit and restores its own on exit
+		return nil
+	}
+
+	current, err := s.controller.ReadServoRegister(ctx, servoID, "torque_limit")
+	if err != nil {
+		return fmt.Errorf("failed to read torque limit: %w", err)
+	}
+	if len(current) < 2 {
+		return fmt.Errorf("short torque limit response")
+	}
+	proto := s.controller.protocolFor(servoID)
+	value := int(math.Round(float64(proto.DecodeWord(current)) * thermalReducedTorqueFactor))
+	if err := s.controller.WriteServoRegister(ctx, servoID, "torque_limit", proto.EncodeWord(uint16(value))); err != nil {
+		return fmt.Errorf("failed to reduce torque limit: %w", err)
+	}
+
+	s.thermal.mu.Lock()
+	s.thermal.savedLimits[servoID] = current
+	s.thermal.mu.Unlock()
+	return nil
+}
+
+// releaseJoint restores a reduced torque limit. Disabled joints stay limp until torque is
+// re-enabled with set_torque so the arm doesn't jump on its own once it cools down.
+func (s *so101) releaseJoint(ctx context.Context, servoID int) error {
+	if s.thermal.action == thermalActionDisable {
+		s.logger.Infof("Joint %s torque is still disabled, re-enable it with the set_torque command", jointNameForServo(servoID))
+		return nil
+	}
+
+	s.thermal.mu.Lock()
+	saved, ok := s.thermal.savedLimits[servoID]
+	delete(s.thermal.savedLimits, servoID)
+	s.thermal.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return s.controller.WriteServoRegister(ctx, servoID, "torque_limit", saved)
+}
@@ -0,0 +1,54 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThermalMonitor(t *testing.T) {
+	monitor := newThermalMonitor(0, "")
+	assert.Equal(t, defaultMaxTemperatureC, monitor.maxTempC)
+	assert.Equal(t, thermalActionReduce, monitor.action)
+	assert.Equal(t, 1.0, monitor.speedFactor())
+
+	overheated, cooled := monitor.update(2, 50)
+	assert.False(t, overheated)
+	assert.False(t, cooled)
+
+	overheated, _ = monitor.update(2, 66)
+	assert.True(t, overheated)
+	assert.Equal(t, thermalReducedSpeedFactor, monitor.speedFactor())
+	assert.Equal(t, []string{"shoulder_lift"}, monitor.status()["overheated_joints"])
+
+	// Still hot inside the hysteresis band
+	overheated, cooled = monitor.update(2, 62)
+	assert.False(t, overheated)
+	assert.False(t, cooled)
+
+	_, cooled = monitor.update(2, 60)
+	assert.True(t, cooled)
+	assert.Equal(t, 1.0, monitor.speedFactor())
+	assert.Equal(t, map[string]interface{}{"shoulder_lift": 60}, monitor.status()["temperatures_c"])
+
+	// Disabling a joint doesn't slow the others down
+	monitor = newThermalMonitor(55, thermalActionDisable)
+	overheated, _ = monitor.update(3, 55)
+	assert.True(t, overheated)
+	assert.Equal(t, 1.0, monitor.speedFactor())
+
+	var nilMonitor *thermalMonitor
+	assert.Equal(t, 1.0, nilMonitor.speedFactor())
+}
+
+func TestThermalMonitorReset(t *testing.T) {
+	monitor := newThermalMonitor(0, "")
+	monitor.update(2, 66)
+	monitor.savedLimits[2] = []byte{0xe8, 0x03}
+
+	saved := monitor.reset()
+	assert.Equal(t, map[int][]byte{2: {0xe8, 0x03}}, saved)
+	assert.Equal(t, 1.0, monitor.speedFactor())
+	assert.Empty(t, monitor.status()["temperatures_c"])
+	assert.Empty(t, monitor.reset())
+}
@@ -0,0 +1,136 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Torque ramp settings. Torque comes on at a low limit and climbs back to each servo's own
+// torque_limit, so a joint that's away from its goal eases in instead of snapping.
+const (
+	torqueRampStartPercent = 10.0
+	torqueRampStep         = 20 * time.Millisecond
+)
+
+// torqueState records whether torque was last enabled on a port. It's shared by every
+// component on the port and cleared by every path that turns torque off, so a reconnect only
+// re-enables torque that was on when the port went away. It also totals how long torque has
+// been on, for the arm's usage tracking.
+type torqueState struct {
+	enabled atomic.Bool
+
+	mu    sync.Mutex
+	since time.Time     // When torque was turned on, zero while it's off
+	total time.Duration // Torque-on time before since
+}
+
+func (t *torqueState) set(enabled bool) {
+	if t == nil {
+		return
+	}
+	t.enabled.Store(enabled)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case enabled && t.since.IsZero():
+		t.since = time.Now()
+	case !enabled && !t.since.IsZero():
+		t.total += time.Since(t.since)
+		t.since = time.Time{}
+	}
+}
+
+// on reports whether torque was last enabled
+func (t *torqueState) on() bool {
+	return t != nil && t.enabled.Load()
+}
+
+// onTime returns how long torque has been on in total since the port was opened
+func (t *torqueState) onTime() time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.since.IsZero() {
+		return t.total
+	}
+	return t.total + time.Since(t.since)
+}
+
+// TorqueEnabled reports whether torque was last enabled on the port and not turned off since
+func (s *SafeSoArmController) TorqueEnabled() bool {
+	return s.torque.on()
+}
+
+// holdPresentPositionsLocked writes each servo's present position as its goal. Servos that
+// don't answer are skipped. The caller must hold s.mu.
+func (s *SafeSoArmController) holdPresentPositionsLocked(ctx context.Context) {
+	ids := make([]int, 0, len(s.calibratedServos))
+	for id := range s.calibratedServos {
+		ids = append(ids, id)
+	}
+	present, _ := s.readPositionsPartialLocked(ctx, ids)
+	if err := s.syncWritePositions(ctx, present); err != nil && s.logs != nil {
+		s.logs.Warnf("hold-present", "Failed to set goal positions to present positions before enabling torque: %v", err)
+	}
+}
+
+// EnableTorqueWithRamp enables torque on every servo, ramping torque_limit from a low value
+// back to each servo's configured limit over ramp. A zero ramp enables torque directly.
+func (s *SafeSoArmController) EnableTorqueWithRamp(ctx context.Context, ramp time.Duration) error {
+	if ramp <= 0 {
+		return s.SetTorqueEnable(ctx, true)
+	}
+
+	limits := map[int]int{}
+	for id := range s.calibratedServos {
+		data, err := s.ReadServoRegister(ctx, id, "torque_limit")
+		if err != nil || len(data) < 2 {
+			continue
+		}
+		limits[id] = int(s.protocolFor(id).DecodeWord(data))
+	}
+	writeLimits := func(ctx context.Context, fraction float64) error {
+		for id, limit := range limits {
+			value := int(math.Round(float64(limit) * fraction))
+			if err := s.WriteServoRegister(ctx, id, "torque_limit", s.protocolFor(id).EncodeWord(uint16(value))); err != nil {
+				return fmt.Errorf("failed to set torque limit of servo %d: %w", id, err)
+			}
+		}
+		return nil
+	}
+
+	start := torqueRampStartPercent / 100
+	if err := writeLimits(ctx, start); err != nil {
+		return err
+	}
+	// Whatever happens below, even a cancelled request, leave the servos with their own limits
+	defer func() {
+		if err := writeLimits(context.WithoutCancel(ctx), 1); err != nil && s.logger != nil {
+			s.logger.Warnf("Failed to restore torque limits after ramp: %v", err)
+		}
+	}()
+
+	if err := s.SetTorqueEnable(ctx, true); err != nil {
+		return err
+	}
+
+	steps := int(ramp / torqueRampStep)
+	for i := 1; i < steps; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(torqueRampStep):
+		}
+		if err := writeLimits(ctx, start+(1-start)*float64(i)/float64(steps)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package so_arm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTorqueRampRestoresTorqueLimit(t *testing.T) {
+	ctx := context.Background()
+	controller, fake := newFakeController(t, 1, 2)
+	fake.setRegister(1, feetech.RegTorqueLimit, []byte{0xE8, 0x03})
+	fake.setRegister(2, feetech.RegTorqueLimit, []byte{0xF4, 0x01})
+
+	assert.NoError(t, controller.EnableTorqueWithRamp(ctx, 3*torqueRampStep))
+	assert.True(t, controller.TorqueEnabled())
+	assert.Equal(t, []byte{1}, fake.register(1, feetech.RegTorqueEnable))
+
+	// Torque comes on at 10% of each servo's own limit and climbs back to it
+	writes := fake.writesTo(1, feetech.RegTorqueLimit)
+	assert.Equal(t, []byte{100, 0}, writes[0])
+	assert.Equal(t, []byte{0xE8, 0x03}, fake.register(1, feetech.RegTorqueLimit))
+	assert.Equal(t, []byte{50, 0}, fake.writesTo(2, feetech.RegTorqueLimit)[0])
+	assert.Equal(t, []byte{0xF4, 0x01}, fake.register(2, feetech.RegTorqueLimit))
+
+	// A refused enable still puts the limits back
+	controller.estop = &emergencyStop{}
+	controller.estop.latch("test")
+	assert.ErrorIs(t, controller.EnableTorqueWithRamp(ctx, 3*torqueRampStep), ErrEmergencyStop)
+	assert.Equal(t, []byte{0xE8, 0x03}, fake.register(1, feetech.RegTorqueLimit))
+	assert.Equal(t, []byte{0xF4, 0x01}, fake.register(2, feetech.RegTorqueLimit))
+}
@@ -0,0 +1,109 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	toggleswitch "go.viam.com/rdk/components/switch"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+var (
+	SO101TorqueSwitchModel = resource.NewModel("devrel", "so101", "torque-switch")
+)
+
+// Torque switch positions
+const (
+	torqueSwitchOff = 0
+	torqueSwitchOn  = 1
+)
+
+func init() {
+	resource.RegisterComponent(toggleswitch.API, SO101TorqueSwitchModel,
+		resource.Registration[toggleswitch.Switch, *SO101ControlConfig]{
+			Constructor: newSO101TorqueSwitch,
+		},
+	)
+}
+
+// so101TorqueSwitch toggles torque on every servo from the app's switch card
+type so101TorqueSwitch struct {
+	resource.AlwaysRebuild
+
+	name       resource.Name
+	logger     logging.Logger
+	controller *SafeSoArmController
+	port       string
+	ramp       time.Duration
+}
+
+func newSO101TorqueSwitch(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (toggleswitch.Switch, error) {
+	cfg, err := resource.NativeConfig[*SO101ControlConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+	controller, err := openControlController(cfg, conf.ResourceName().ShortName(), logger)
+	if err != nil {
+		return nil, err
+	}
+	return &so101TorqueSwitch{
+		name:       conf.ResourceName(),
+		logger:     logger,
+		controller: controller,
+		port:       cfg.Port,
+		ramp:       time.Duration(cfg.TorqueRampMs) * time.Millisecond,
+	}, nil
+}
+
+func (t *so101TorqueSwitch) Name() resource.Name {
+	return t.name
+}
+
+func (t *so101TorqueSwitch) SetPosition(ctx context.Context, position uint32, extra map[string]interface{}) error {
+	switch position {
+	case torqueSwitchOff, torqueSwitchOn:
+	default:
+		return fmt.Errorf("torque switch position must be 0 (off) or 1 (on), got %d", position)
+	}
+	enable := position == torqueSwitchOn
+	t.logger.Debugf("Setting torque enabled=%v from switch", enable)
+	// Both directions go through the port's torque state, which the arm's usage tracking reads
+	if !enable {
+		return t.controller.SetTorqueEnable(ctx, false)
+	}
+	// Checked before the ramp touches torque_limit, like the arm's set_torque
+	if err := t.controller.checkMotion(); err != nil {
+		return err
+	}
+	return t.controller.EnableTorqueWithRamp(ctx, t.ramp)
+}
+
+// GetPosition reports on only when every servo has torque enabled
+func (t *so101TorqueSwitch) GetPosition(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+	states, failed := t.controller.GetTorqueStates(ctx, controlServoIDs)
+	for _, id := range controlServoIDs {
+		if err, ok := failed[id]; ok {
+			return 0, fmt.Errorf("failed to read torque state of servo %d: %w", id, err)
+		}
+		if !states[id] {
+			return torqueSwitchOff, nil
+		}
+	}
+	return torqueSwitchOn, nil
+}
+
+func (t *so101TorqueSwitch) GetNumberOfPositions(ctx context.Context, extra map[string]interface{}) (uint32, []string, error) {
+	return 2, []string{"off", "on"}, nil
+}
+
+func (t *so101TorqueSwitch) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("unknown command: %v", cmd["command"])
+}
+
+func (t *so101TorqueSwitch) Close(ctx context.Context) error {
+	UnregisterSharedConsumer(t.port, t.name.ShortName())
+	ReleaseSharedControllerForPort(t.port, t.controller)
+	return nil
+}
@@ -0,0 +1,40 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTorqueReport(t *testing.T) {
+	ids := []int{1, 2, 3}
+	report := torqueReport(ids, map[int]bool{1: true, 2: true, 3: true}, nil)
+	assert.Equal(t, true, report["all_enabled"])
+	assert.Equal(t, true, report["any_enabled"])
+	assert.Nil(t, report["errors"])
+
+	report = torqueReport(ids, map[int]bool{1: true, 2: false}, map[int]error{3: errors.New("timeout")})
+	assert.Equal(t, false, report["success"])
+	assert.Equal(t, false, report["all_enabled"])
+	assert.Equal(t, true, report["any_enabled"])
+	assert.Equal(t, map[string]interface{}{jointNameForServo(3): "timeout"}, report["errors"])
+	assert.Equal(t, false, report["torque"].(map[string]interface{})[jointNameForServo(2)])
+}
+
+func TestTorqueStateClearedByTorqueOff(t *testing.T) {
+	// Every component on the port shares the record, any of them turning torque off clears it
+	torque := &torqueState{}
+	torque.set(true)
+	arm := &SafeSoArmController{torque: torque, group: feetech.NewServoGroup(nil)}
+	gripper := &SafeSoArmController{torque: torque, group: feetech.NewServoGroup(nil)}
+	assert.True(t, arm.TorqueEnabled())
+
+	// Cleared even when the write fails, the servo may be off anyway
+	assert.Error(t, gripper.WriteServoRegister(context.Background(), 6, "torque_enable", []byte{0}))
+	assert.False(t, arm.TorqueEnabled())
+
+	assert.False(t, (&SafeSoArmController{}).TorqueEnabled())
+}
@@ -0,0 +1,371 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// What execute_trajectory does when a segment needs more speed than the arm allows
+const (
+	onInfeasibleError     = "error"
+	onInfeasibleTimeScale = "time_scale"
+)
+
+// trajectoryViolation is a segment whose required joint speed exceeds the limit
+type trajectoryViolation struct {
+	Segment            int
+	Joint              int
+	RequiredDegsPerSec float64
+}
+
+func (v trajectoryViolation) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"segment":               v.Segment,
+		"joint":                 v.Joint,
+		"required_degs_per_sec": v.RequiredDegsPerSec,
+	}
+}
+
+// checkTrajectory finds every segment that needs more than limitDegsPerSec on any joint.
+// It also returns the factor the trajectory's times must be stretched by to be feasible,
+// 1 if it already is.
+func checkTrajectory(pointsDegs [][]float64, timesSec []float64, limitDegsPerSec float64) ([]trajectoryViolation, float64, error) {
+	if len(pointsDegs) != len(timesSec) {
+		return nil, 0, fmt.Errorf("trajectory has %d points but %d times", len(pointsDegs), len(timesSec))
+	}
+
+	var violations []trajectoryViolation
+	scale := 1.0
+	for i := 1; i < len(pointsDegs); i++ {
+		dt := timesSec[i] - timesSec[i-1]
+		if dt <= 0 {
+			return nil, 0, fmt.Errorf("trajectory times must be increasing, point %d is at %.3fs after %.3fs", i, timesSec[i], timesSec[i-1])
+		}
+		for j := range pointsDegs[i] {
+			required := math.Abs(pointsDegs[i][j]-pointsDegs[i-1][j]) / dt
+			if required > limitDegsPerSec {
+				violations = append(violations, trajectoryViolation{Segment: i - 1, Joint: j, RequiredDegsPerSec: required})
+				scale = math.Max(scale, required/limitDegsPerSec)
+			}
+		}
+	}
+	return violations, scale, nil
+}
+
+// executeTrajectory plays back a timed trajectory in degrees. Each point is commanded at its
+// time with the speed needed to reach it by the next one. The first point is approached at
+// the default speed before timing starts.
+func (s *so101) executeTrajectory(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	points, times, err := s.trajectoryFromCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	smoothing, _ := cmd["smoothing"].(string)
+	cutoffHz := defaultSmoothingCutoffHz
+	if v, ok := cmd["smoothing_cutoff_hz"].(float64); ok {
+		cutoffHz = v
+	}
+	window := defaultSmoothingWindow
+	if v, ok := cmd["smoothing_window"].(float64); ok {
+		window = int(v)
+	}
+	if points, err = smoothTrajectory(points, times, smoothing, cutoffHz, window); err != nil {
+		return nil, err
+	}
+
+	onInfeasible := onInfeasibleError
+	if v, ok := cmd["on_infeasible"].(string); ok {
+		onInfeasible = v
+	}
+	if onInfeasible != onInfeasibleError && onInfeasible != onInfeasibleTimeScale {
+		return nil, fmt.Errorf("on_infeasible must be %q or %q, got %q", onInfeasibleError, onInfeasibleTimeScale, onInfeasible)
+	}
+
+	params := s.defaultMotionParams()
+	violations, scale, err := checkTrajectory(points, times, params.SpeedDegsPerSec)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 && onInfeasible == onInfeasibleError {
+		details := make([]interface{}, len(violations))
+		for i, v := range violations {
+			details[i] = v.toMap()
+		}
+		return map[string]interface{}{
+			"success":                  false,
+			"speed_limit_degs_per_sec": params.SpeedDegsPerSec,
+			"time_scale_required":      scale,
+			"violations":               details,
+			"error": fmt.Sprintf("trajectory exceeds %.1f deg/s in %d segment(s), retry with on_infeasible \"time_scale\" or slow it down by %.2fx",
+				params.SpeedDegsPerSec, len(violations), scale),
+		}, nil
+	}
+
+	loops := 1
+	if v, ok := cmd["loops"].(float64); ok {
+		if v < 0 || v != math.Trunc(v) {
+			return nil, fmt.Errorf("loops must be a whole number, 0 repeats until stopped, got %v", v)
+		}
+		loops = int(v)
+	}
+	abortLoad, _ := cmd["abort_on_load_percent"].(float64)
+	if abortLoad < 0 || abortLoad > 100 {
+		return nil, fmt.Errorf("abort_on_load_percent must be between 0 and 100, got %.1f", abortLoad)
+	}
+	abortOnGrabFailure, _ := cmd["abort_on_grab_failure"].(bool)
+
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	s.isMoving.Store(true)
+	defer s.isMoving.Store(false)
+
+	abort := playbackAbort{
+		loadPercent:   abortLoad,
+		stopCount:     s.stopCount.Load(),
+		onGrabFailure: abortOnGrabFailure,
+		grabFailures:  s.controller.grabFailures.count(),
+	}
+	stats := &playbackStats{}
+	motion := newTrajectoryStats(s.armJointNames())
+	defer func() {
+		motion.completedAt = time.Now()
+		s.lastTrajectoryStats.Store(motion)
+	}()
+	start := time.Now()
+	var abortReason string
+	for loops == 0 || stats.cycles < loops {
+		cycleStart := time.Now()
+		abortReason, err = s.playTrajectory(ctx, points, times, scale, params, abort, motion)
+		if err != nil {
+			// A cancelled request has no one to report to, anything else ends playback with the
+			// cycles completed so far
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			abortReason = err.Error()
+			if errors.Is(err, ErrEmergencyStop) {
+				abortReason = "emergency stopped: " + abortReason
+			}
+		}
+		if abortReason != "" {
+			break
+		}
+		stats.add(time.Since(cycleStart))
+	}
+
+	result := map[string]interface{}{
+		"success":    abortReason == "",
+		"points":     len(points),
+		"time_scale": scale,
+		"smoothing":  smoothing,
+		"duration_s": time.Since(start).Seconds(),
+	}
+	for k, v := range stats.toMap() {
+		result[k] = v
+	}
+	if abortReason != "" {
+		result["aborted"] = true
+		result["error"] = abortReason
+	}
+	return result, nil
+}
+
+// armJointNames returns the joint name of each arm servo
+func (s *so101) armJointNames() []string {
+	servoIDs := s.servoIDs()
+	names := make([]string, len(servoIDs))
+	for i, id := range servoIDs {
+		names[i] = jointNameForServo(id)
+	}
+	return names
+}
+
+// trajectoryFromCommand reads the trajectory from trajectory_file, if given, or from
+// positions_degs and times_s
+func (s *so101) trajectoryFromCommand(cmd map[string]interface{}) ([][]float64, []float64, error) {
+	servoIDs := s.servoIDs()
+	if path, ok := cmd["trajectory_file"].(string); ok {
+		file, err := loadTrajectoryFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		points, err := file.positionsDegs(s.armJointNames())
+		if err != nil {
+			return nil, nil, err
+		}
+		return points, file.times(), nil
+	}
+
+	rawPoints, ok := cmd["positions_degs"].([]interface{})
+	if !ok || len(rawPoints) == 0 {
+		return nil, nil, fmt.Errorf("execute_trajectory requires 'trajectory_file' or 'positions_degs', a list of joint positions")
+	}
+	points := make([][]float64, len(rawPoints))
+	for i, raw := range rawPoints {
+		point, err := floatList(raw, "positions_degs")
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(point) != len(servoIDs) {
+			return nil, nil, fmt.Errorf("trajectory point %d has %d joints, expected %d", i, len(point), len(servoIDs))
+		}
+		points[i] = point
+	}
+	times, err := floatList(cmd["times_s"], "times_s")
+	if err != nil {
+		return nil, nil, err
+	}
+	return points, times, nil
+}
+
+// playbackAbort holds the conditions that end trajectory playback early
+type playbackAbort struct {
+	// Joint load that aborts playback, zero disables the check
+	loadPercent float64
+	// The arm's stop count when playback started, Stop being called changes it
+	stopCount int64
+	// Abort when a grab on the port misses, counted from grabFailures at the start
+	onGrabFailure bool
+	grabFailures  int64
+}
+
+// playTrajectory plays one pass of a trajectory. It returns a reason when an abort condition
+// ends the pass early. Joint readings along the way go into motion.
+func (s *so101) playTrajectory(ctx context.Context, points [][]float64, times []float64, scale float64, params motionParams, abort playbackAbort, motion *trajectoryStats) (string, error) {
+	toRadians := func(degs []float64) []float64 {
+		rads := make([]float64, len(degs))
+		for i, deg := range degs {
+			rads[i] = DegreesToRadians(deg)
+		}
+		return rads
+	}
+
+	if _, err := s.moveToJointPositions(ctx, toRadians(points[0]), params); err != nil {
+		return "", fmt.Errorf("failed to reach trajectory start: %w", err)
+	}
+
+	motion.startPass()
+	if actual := s.readTrajectoryDegs(ctx, motion); actual != nil {
+		motion.sample(points[0], actual, time.Now())
+	}
+	approach := points[0]
+
+	start := time.Now()
+	for i := 1; i < len(points); i++ {
+		if reason := s.playbackAbortReason(ctx, abort); reason != "" {
+			return reason, nil
+		}
+
+		dt := (times[i] - times[i-1]) * scale
+		maxDelta := 0.0
+		for j := range points[i] {
+			maxDelta = math.Max(maxDelta, math.Abs(points[i][j]-points[i-1][j]))
+		}
+		speed := clampFloat(maxDelta/dt, minSpeedDegsPerSec, params.SpeedDegsPerSec)
+		if motion.prev != nil {
+			approach = motion.prev
+		}
+
+		// Each point gets the joint limits and motion checks of a regular move
+		commanded, err := s.commandJointPositions(ctx, toRadians(points[i]), math.Ceil(speed), 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to command trajectory point %d: %w", i, err)
+		}
+		s.usage.addTravel(s.servoIDs(), toRadians(points[i-1]), commanded)
+
+		next := start.Add(time.Duration((times[i] - times[0]) * scale * float64(time.Second)))
+		select {
+		case <-time.After(time.Until(next)):
+		case <-ctx.Done():
+			s.recoverFromCancel(toRadians(points[i-1]))
+			return "", ctx.Err()
+		}
+		if actual := s.readTrajectoryDegs(ctx, motion); actual != nil {
+			motion.sample(points[i], actual, time.Now())
+		}
+	}
+	s.settleTrajectory(ctx, motion, points[len(points)-1], approach)
+	return "", nil
+}
+
+// playbackAbortReason checks the abort conditions between trajectory points
+func (s *so101) playbackAbortReason(ctx context.Context, abort playbackAbort) string {
+	servoIDs := s.servoIDs()
+	if s.stopCount.Load() != abort.stopCount {
+		return "stopped"
+	}
+	if err := s.controller.estop.check(); err != nil {
+		return "emergency stopped: " + err.Error()
+	}
+	if abort.onGrabFailure && s.controller.grabFailures.count() != abort.grabFailures {
+		return "the gripper missed a grab"
+	}
+	abortLoad := abort.loadPercent
+	if abortLoad <= 0 {
+		return ""
+	}
+	loads, err := s.controller.GetServoLoads(ctx, servoIDs)
+	if err != nil {
+		s.logs.Warnf("playback-load", "Failed to read joint loads during playback: %v", err)
+		return ""
+	}
+	for _, id := range servoIDs {
+		if load := math.Abs(loads[id]); load > abortLoad {
+			if err := s.controller.StopServos(ctx, servoIDs); err != nil {
+				s.logger.Warnf("Failed to hold arm after load spike: %v", err)
+			}
+			return fmt.Sprintf("load on %s reached %.0f%%, above abort_on_load_percent %.0f%%", jointNameForServo(id), load, abortLoad)
+		}
+	}
+	return ""
+}
+
+// playbackStats summarizes completed playback cycles
+type playbackStats struct {
+	cycles   int
+	total    time.Duration
+	min, max time.Duration
+}
+
+func (p *playbackStats) add(d time.Duration) {
+	if p.cycles == 0 || d < p.min {
+		p.min = d
+	}
+	if d > p.max {
+		p.max = d
+	}
+	p.cycles++
+	p.total += d
+}
+
+func (p *playbackStats) toMap() map[string]interface{} {
+	result := map[string]interface{}{"cycles_completed": p.cycles}
+	if p.cycles > 0 {
+		result["cycle_min_s"] = p.min.Seconds()
+		result["cycle_max_s"] = p.max.Seconds()
+		result["cycle_avg_s"] = (p.total / time.Duration(p.cycles)).Seconds()
+	}
+	return result
+}
+
+// floatList converts a JSON list of numbers
+func floatList(v interface{}, name string) ([]float64, error) {
+	raw, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of numbers", name)
+	}
+	values := make([]float64, len(raw))
+	for i, item := range raw {
+		f, ok := item.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of numbers", name)
+		}
+		values[i] = f
+	}
+	return values, nil
+}
@@ -0,0 +1,142 @@
+package so_arm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Trajectory files. Recordings are stored in one documented JSON format so they stay playable
+// across module versions: the version is bumped whenever a field changes meaning, and files
+// from a newer module are refused instead of being misread.
+const (
+	trajectoryFileFormat  = "so101-trajectory"
+	trajectoryFileVersion = 1
+	trajectoryUnitsDegs   = "degrees"
+	trajectoryUnitsRads   = "radians"
+)
+
+// TrajectoryFile is a timed recording of joint positions
+type TrajectoryFile struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+
+	// Joint names of each position column, e.g. "shoulder_pan". Columns are matched to the
+	// arm's joints by name, so their order doesn't matter.
+	JointNames []string `json:"joint_names"`
+	// "degrees" (default) or "radians"
+	Units string `json:"units,omitempty"`
+
+	// Sample times in seconds, or a fixed sample rate when times_s is omitted
+	TimesS       []float64 `json:"times_s,omitempty"`
+	SampleRateHz float64   `json:"sample_rate_hz,omitempty"`
+
+	Positions [][]float64 `json:"positions"`
+	// Gripper opening in percent per sample, optional
+	Gripper []float64 `json:"gripper,omitempty"`
+
+	Device     *TrajectoryDevice `json:"device,omitempty"`
+	RecordedAt time.Time         `json:"recorded_at"`
+}
+
+// TrajectoryDevice identifies the arm a trajectory was recorded on
+type TrajectoryDevice struct {
+	Model     string `json:"model,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Serial    string `json:"serial,omitempty"`
+	USBSerial string `json:"usb_serial,omitempty"`
+}
+
+// loadTrajectoryFile reads and validates a trajectory file
+func loadTrajectoryFile(path string) (*TrajectoryFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trajectory file: %w", err)
+	}
+	return parseTrajectoryFile(data)
+}
+
+// parseTrajectoryFile decodes and validates a trajectory
+func parseTrajectoryFile(data []byte) (*TrajectoryFile, error) {
+	var f TrajectoryFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse trajectory file: %w", err)
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (f *TrajectoryFile) validate() error {
+	if f.Format != trajectoryFileFormat {
+		return fmt.Errorf("not a trajectory file, format must be %q, got %q", trajectoryFileFormat, f.Format)
+	}
+	if f.Version < 1 || f.Version > trajectoryFileVersion {
+		return fmt.Errorf("trajectory file version %d is not supported, this module reads up to version %d", f.Version, trajectoryFileVersion)
+	}
+	if f.Units != "" && f.Units != trajectoryUnitsDegs && f.Units != trajectoryUnitsRads {
+		return fmt.Errorf("units must be %q or %q, got %q", trajectoryUnitsDegs, trajectoryUnitsRads, f.Units)
+	}
+	if len(f.Positions) == 0 {
+		return fmt.Errorf("trajectory has no positions")
+	}
+	for i, sample := range f.Positions {
+		if len(sample) != len(f.JointNames) {
+			return fmt.Errorf("sample %d has %d positions, expected one per joint in joint_names (%d)", i, len(sample), len(f.JointNames))
+		}
+	}
+	if len(f.TimesS) == 0 && f.SampleRateHz <= 0 {
+		return fmt.Errorf("trajectory needs times_s or a positive sample_rate_hz")
+	}
+	if len(f.TimesS) > 0 && len(f.TimesS) != len(f.Positions) {
+		return fmt.Errorf("trajectory has %d samples but %d times", len(f.Positions), len(f.TimesS))
+	}
+	if len(f.Gripper) > 0 && len(f.Gripper) != len(f.Positions) {
+		return fmt.Errorf("trajectory has %d samples but %d gripper values", len(f.Positions), len(f.Gripper))
+	}
+	return nil
+}
+
+// times returns the time of each sample in seconds
+func (f *TrajectoryFile) times() []float64 {
+	if len(f.TimesS) > 0 {
+		return f.TimesS
+	}
+	times := make([]float64, len(f.Positions))
+	for i := range times {
+		times[i] = float64(i) / f.SampleRateHz
+	}
+	return times
+}
+
+// positionsDegs returns the samples in degrees with columns in the order of jointNames.
+// Every joint must be in the file, extra columns are ignored.
+func (f *TrajectoryFile) positionsDegs(jointNames []string) ([][]float64, error) {
+	columns := make([]int, len(jointNames))
+	for i, name := range jointNames {
+		columns[i] = -1
+		for j, fileName := range f.JointNames {
+			if fileName == name {
+				columns[i] = j
+			}
+		}
+		if columns[i] < 0 {
+			return nil, fmt.Errorf("trajectory has no %s column", name)
+		}
+	}
+
+	points := make([][]float64, len(f.Positions))
+	for i, sample := range f.Positions {
+		point := make([]float64, len(columns))
+		for k, col := range columns {
+			point[k] = sample[col]
+			if f.Units == trajectoryUnitsRads {
+				point[k] = RadiansToDegrees(point[k])
+			}
+		}
+		points[i] = point
+	}
+	return points, nil
+}
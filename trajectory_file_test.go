@@ -0,0 +1,57 @@
+package so_arm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrajectoryFile(t *testing.T) {
+	data := []byte(`{
+		"format": "so101-trajectory",
+		"version": 1,
+		"joint_names": ["shoulder_lift", "shoulder_pan", "gripper"],
+		"units": "radians",
+		"sample_rate_hz": 10,
+		"positions": [[0, 0, 0], [0.5, -0.25, 1]]
+	}`)
+	f, err := parseTrajectoryFile(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0, 0.1}, f.times())
+
+	points, err := f.positionsDegs([]string{"shoulder_pan", "shoulder_lift"})
+	assert.NoError(t, err)
+	assert.InDelta(t, RadiansToDegrees(-0.25), points[1][0], 1e-9)
+	assert.InDelta(t, RadiansToDegrees(0.5), points[1][1], 1e-9)
+
+	_, err = f.positionsDegs([]string{"shoulder_pan", "elbow_flex"})
+	assert.ErrorContains(t, err, "elbow_flex")
+}
+
+func TestParseTrajectoryFileRejectsInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"format":    `{"format": "other", "version": 1, "joint_names": ["a"], "times_s": [0], "positions": [[0]]}`,
+		"version":   `{"format": "so101-trajectory", "version": 2, "joint_names": ["a"], "times_s": [0], "positions": [[0]]}`,
+		"units":     `{"format": "so101-trajectory", "version": 1, "units": "steps", "joint_names": ["a"], "times_s": [0], "positions": [[0]]}`,
+		"columns":   `{"format": "so101-trajectory", "version": 1, "joint_names": ["a", "b"], "times_s": [0], "positions": [[0]]}`,
+		"no timing": `{"format": "so101-trajectory", "version": 1, "joint_names": ["a"], "positions": [[0]]}`,
+		"times":     `{"format": "so101-trajectory", "version": 1, "joint_names": ["a"], "times_s": [0, 1], "positions": [[0]]}`,
+		"gripper":   `{"format": "so101-trajectory", "version": 1, "joint_names": ["a"], "times_s": [0], "positions": [[0]], "gripper": [0, 1]}`,
+	} {
+		_, err := parseTrajectoryFile([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadTrajectoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pick.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"format": "so101-trajectory", "version": 1, "joint_names": ["a"], "times_s": [0, 0.5], "positions": [[0], [10]]}`), 0o644))
+	f, err := loadTrajectoryFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0, 0.5}, f.times())
+
+	_, err = loadTrajectoryFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package so_arm
+
+import (
+	"fmt"
+	"math"
+)
+
+// Smoothing passes for execute_trajectory. Raw leader recordings carry encoder noise that
+// makes the follower buzz, so they can be filtered offline before playback.
+const (
+	smoothingNone          = "none"
+	smoothingLowPass       = "low_pass"
+	smoothingMovingAverage = "moving_average"
+
+	defaultSmoothingCutoffHz = 5.0
+	defaultSmoothingWindow   = 5
+)
+
+// smoothTrajectory filters each joint of a timed trajectory. low_pass runs a first-order
+// filter forward and then backward so the result has no phase lag, moving_average takes a
+// centered mean over window points. The first and last points are kept exactly.
+func smoothTrajectory(pointsDegs [][]float64, timesSec []float64, method string, cutoffHz float64, window int) ([][]float64, error) {
+	if len(pointsDegs) != len(timesSec) {
+		return nil, fmt.Errorf("trajectory has %d points but %d times", len(pointsDegs), len(timesSec))
+	}
+	smoothed := make([][]float64, len(pointsDegs))
+	for i, point := range pointsDegs {
+		smoothed[i] = append([]float64(nil), point...)
+	}
+	if len(pointsDegs) < 3 {
+		return smoothed, nil
+	}
+
+	switch method {
+	case "", smoothingNone:
+		return smoothed, nil
+	case smoothingLowPass:
+		if cutoffHz <= 0 {
+			return nil, fmt.Errorf("smoothing_cutoff_hz must be positive, got %.2f", cutoffHz)
+		}
+		rc := 1 / (2 * math.Pi * cutoffHz)
+		alpha := func(i, j int) float64 {
+			dt := math.Abs(timesSec[i] - timesSec[j])
+			return dt / (rc + dt)
+		}
+		for i := 1; i < len(smoothed); i++ {
+			a := alpha(i, i-1)
+			for j := range smoothed[i] {
+				smoothed[i][j] = smoothed[i-1][j] + a*(smoothed[i][j]-smoothed[i-1][j])
+			}
+		}
+		for i := len(smoothed) - 2; i >= 0; i-- {
+			a := alpha(i, i+1)
+			for j := range smoothed[i] {
+				smoothed[i][j] = smoothed[i+1][j] + a*(smoothed[i][j]-smoothed[i+1][j])
+			}
+		}
+	case smoothingMovingAverage:
+		if window < 2 {
+			return nil, fmt.Errorf("smoothing_window must be at least 2, got %d", window)
+		}
+		half := window / 2
+		for i := range pointsDegs {
+			lo, hi := max(0, i-half), min(len(pointsDegs)-1, i+half)
+			for j := range pointsDegs[i] {
+				sum := 0.0
+				for k := lo; k <= hi; k++ {
+					sum += pointsDegs[k][j]
+				}
+				smoothed[i][j] = sum / float64(hi-lo+1)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("smoothing must be %q, %q or %q, got %q", smoothingNone, smoothingLowPass, smoothingMovingAverage, method)
+	}
+
+	last := len(pointsDegs) - 1
+	smoothed[0] = append([]float64(nil), pointsDegs[0]...)
+	smoothed[last] = append([]float64(nil), pointsDegs[last]...)
+	return smoothed, nil
+}
@@ -0,0 +1,136 @@
+package so_arm
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// After the last point of a pass the joints are sampled for this long to catch overshoot
+const (
+	trajectorySettleTime     = 300 * time.Millisecond
+	trajectorySettleInterval = 20 * time.Millisecond
+)
+
+// trajectoryStats measures how closely the joints followed an execute_trajectory run, in
+// degrees. Joints are read when each point is due, so the following error is the distance
+// still to go at that moment.
+type trajectoryStats struct {
+	jointNames        []string
+	maxVelocity       []float64
+	maxFollowingError []float64
+	overshoot         []float64
+	samples           int
+	readFailures      int
+	completedAt       time.Time
+
+	prev   []float64
+	prevAt time.Time
+}
+
+func newTrajectoryStats(jointNames []string) *trajectoryStats {
+	return &trajectoryStats{
+		jointNames:        jointNames,
+		maxVelocity:       make([]float64, len(jointNames)),
+		maxFollowingError: make([]float64, len(jointNames)),
+		overshoot:         make([]float64, len(jointNames)),
+	}
+}
+
+// startPass forgets the last reading so the move back to the start isn't counted as velocity
+func (t *trajectoryStats) startPass() {
+	t.prev = nil
+}
+
+// track records a reading for velocity, without a commanded position to compare against
+func (t *trajectoryStats) track(actual []float64, at time.Time) {
+	t.samples++
+	if t.prev != nil {
+		if dt := at.Sub(t.prevAt).Seconds(); dt > 0 {
+			for j := range actual {
+				t.maxVelocity[j] = math.Max(t.maxVelocity[j], math.Abs(actual[j]-t.prev[j])/dt)
+			}
+		}
+	}
+	t.prev = actual
+	t.prevAt = at
+}
+
+// sample records a reading taken when commanded should have been reached
+func (t *trajectoryStats) sample(commanded, actual []float64, at time.Time) {
+	for j := range actual {
+		t.maxFollowingError[j] = math.Max(t.maxFollowingError[j], math.Abs(commanded[j]-actual[j]))
+	}
+	t.track(actual, at)
+}
+
+// settle records a reading after the final point, approach is where the joints were when it
+// was commanded. Overshoot is how far a joint went past the target in the direction it was
+// approaching from.
+func (t *trajectoryStats) settle(target, approach, actual []float64, at time.Time) {
+	for j := range actual {
+		if target[j] == approach[j] {
+			continue
+		}
+		direction := math.Copysign(1, target[j]-approach[j])
+		t.overshoot[j] = math.Max(t.overshoot[j], direction*(actual[j]-target[j]))
+	}
+	t.track(actual, at)
+}
+
+func (t *trajectoryStats) toMap() map[string]interface{} {
+	joints := map[string]interface{}{}
+	for j, name := range t.jointNames {
+		joints[name] = map[string]interface{}{
+			"max_velocity_degs_per_sec": t.maxVelocity[j],
+			"max_following_error_degs":  t.maxFollowingError[j],
+			"overshoot_degs":            t.overshoot[j],
+		}
+	}
+	return map[string]interface{}{
+		"success":       true,
+		"joints":        joints,
+		"samples":       t.samples,
+		"read_failures": t.readFailures,
+		"completed_at":  t.completedAt.Format(time.RFC3339),
+	}
+}
+
+// readTrajectoryDegs reads the arm joints in degrees for trajectory statistics, a failed
+// read is counted and skipped
+func (s *so101) readTrajectoryDegs(ctx context.Context, stats *trajectoryStats) []float64 {
+	positions, err := s.controller.GetJointPositionsForServos(ctx, s.servoIDs())
+	if err != nil {
+		stats.readFailures++
+		return nil
+	}
+	degs := make([]float64, len(positions))
+	for i, rad := range positions {
+		degs[i] = RadiansToDegrees(rad)
+	}
+	return degs
+}
+
+// settleTrajectory samples the joints after the last point of a pass to measure overshoot
+func (s *so101) settleTrajectory(ctx context.Context, stats *trajectoryStats, target, approach []float64) {
+	deadline := time.Now().Add(trajectorySettleTime)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(trajectorySettleInterval):
+		}
+		if actual := s.readTrajectoryDegs(ctx, stats); actual != nil {
+			stats.settle(target, approach, actual, time.Now())
+		}
+	}
+}
+
+// lastTrajectoryStatsResponse handles the last_trajectory_stats DoCommand
+func (s *so101) lastTrajectoryStatsResponse() map[string]interface{} {
+	stats := s.lastTrajectoryStats.Load()
+	if stats == nil {
+		return map[string]interface{}{"success": false, "error": "no trajectory has been executed yet"}
+	}
+	return stats.toMap()
+}
@@ -0,0 +1,37 @@
+package so_arm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrajectoryStats(t *testing.T) {
+	stats := newTrajectoryStats([]string{"shoulder_pan", "elbow_flex"})
+	start := time.Now()
+	stats.sample([]float64{0, 0}, []float64{0, 0}, start)
+	stats.sample([]float64{10, -10}, []float64{8, -10}, start.Add(100*time.Millisecond))
+
+	// Approaching 20 from 8 and -20 from -10, then passing both targets
+	stats.settle([]float64{20, -20}, []float64{8, -10}, []float64{21.5, -19}, start.Add(200*time.Millisecond))
+	stats.settle([]float64{20, -20}, []float64{8, -10}, []float64{20, -20.5}, start.Add(300*time.Millisecond))
+
+	assert.InDelta(t, 135, stats.maxVelocity[0], 1e-6)
+	assert.InDelta(t, 100, stats.maxVelocity[1], 1e-6)
+	assert.InDelta(t, 2, stats.maxFollowingError[0], 1e-9)
+	assert.InDelta(t, 0, stats.maxFollowingError[1], 1e-9)
+	assert.InDelta(t, 1.5, stats.overshoot[0], 1e-9)
+	assert.InDelta(t, 0.5, stats.overshoot[1], 1e-9)
+	assert.Equal(t, 4, stats.samples)
+
+	// The move back to the start of the next pass isn't a velocity sample
+	stats.startPass()
+	stats.sample([]float64{0, 0}, []float64{0, 0}, start.Add(310*time.Millisecond))
+	assert.InDelta(t, 135, stats.maxVelocity[0], 1e-6)
+
+	result := stats.toMap()
+	assert.Equal(t, true, result["success"])
+	joints := result["joints"].(map[string]interface{})
+	assert.Contains(t, joints, "elbow_flex")
+}
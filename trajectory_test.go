@@ -0,0 +1,154 @@
+package so_arm
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/logging"
+)
+
+func TestCheckTrajectory(t *testing.T) {
+	points := [][]float64{
+		{0, 0, 0, 0, 0},
+		{10, 0, 0, 0, 0},
+		{10, 40, 0, 0, 0},
+	}
+
+	// 10°/s then 40°/s against a 50°/s limit
+	violations, scale, err := checkTrajectory(points, []float64{0, 1, 2}, 50)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+	assert.Equal(t, 1.0, scale)
+
+	// The second segment needs 80°/s on joint 1
+	violations, scale, err = checkTrajectory(points, []float64{0, 1, 1.5}, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []trajectoryViolation{{Segment: 1, Joint: 1, RequiredDegsPerSec: 80}}, violations)
+	assert.InDelta(t, 1.6, scale, 1e-9)
+
+	_, _, err = checkTrajectory(points, []float64{0, 1, 1}, 50)
+	assert.Error(t, err)
+
+	_, _, err = checkTrajectory(points, []float64{0, 1}, 50)
+	assert.Error(t, err)
+}
+
+func TestSmoothTrajectory(t *testing.T) {
+	times := []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5}
+	noisy := [][]float64{{0}, {12}, {8}, {12}, {8}, {10}}
+
+	for _, method := range []string{smoothingLowPass, smoothingMovingAverage} {
+		smoothed, err := smoothTrajectory(noisy, times, method, defaultSmoothingCutoffHz, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, noisy[0], smoothed[0])
+		assert.Equal(t, noisy[5], smoothed[5])
+		// The zig-zag in the middle is flattened
+		assert.Less(t, math.Abs(smoothed[2][0]-smoothed[3][0]), 4.0, method)
+	}
+	// The input is left alone
+	assert.Equal(t, 12.0, noisy[1][0])
+
+	smoothed, err := smoothTrajectory(noisy, times, "", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, noisy, smoothed)
+
+	_, err = smoothTrajectory(noisy, times, "spline", 0, 0)
+	assert.Error(t, err)
+	_, err = smoothTrajectory(noisy, times, smoothingLowPass, 0, 0)
+	assert.Error(t, err)
+	_, err = smoothTrajectory(noisy, times[:3], smoothingLowPass, 5, 0)
+	assert.Error(t, err)
+}
+
+func TestPlaybackStats(t *testing.T) {
+	stats := &playbackStats{}
+	assert.Equal(t, map[string]interface{}{"cycles_completed": 0}, stats.toMap())
+
+	stats.add(2 * time.Second)
+	stats.add(4 * time.Second)
+	stats.add(3 * time.Second)
+	result := stats.toMap()
+	assert.Equal(t, 3, result["cycles_completed"])
+	assert.Equal(t, 2.0, result["cycle_min_s"])
+	assert.Equal(t, 4.0, result["cycle_max_s"])
+	assert.Equal(t, 3.0, result["cycle_avg_s"])
+}
+
+func TestPlayTrajectoryAppliesJointLimits(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, fake := newFakeController(t, ids...)
+	s := &so101{
+		cfg: &SO101ArmConfig{JointLimits: map[string]JointLimit{
+			jointNameForServo(1): {MinDegs: -10, MaxDegs: 10},
+		}},
+		controller:  controller,
+		armServoIDs: ids,
+		logger:      logger,
+		logs:        newRateLimitedLogger(logger, 0),
+		events:      &eventLog{},
+		usage:       newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger),
+	}
+	ctx := context.Background()
+	points := [][]float64{{0, 0, 0, 0, 0}, {30, 0, 0, 0, 0}}
+	motion := newTrajectoryStats(s.armJointNames())
+
+	reason, err := s.playTrajectory(ctx, points, []float64{0, 0.05}, 1, motionParams{SpeedDegsPerSec: 100}, playbackAbort{}, motion)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	// The second point was clamped to joint_limits like any other move
+	limit, err := controller.GetCalibration().GetMotorCalibrationByID(1).Denormalize(10)
+	assert.NoError(t, err)
+	goal := feetech.NewProtocol(feetech.ProtocolSTS).DecodeWord(fake.register(1, feetech.RegGoalPosition))
+	assert.Equal(t, limit, int(goal))
+}
+
+func TestPlaybackAbortsWithPartialStats(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ids := []int{1, 2, 3, 4, 5}
+	controller, _ := newFakeController(t, ids...)
+	controller.estop = &emergencyStop{}
+	controller.grabFailures = &grabFailures{}
+	s := &so101{
+		cfg:          &SO101ArmConfig{},
+		controller:   controller,
+		armServoIDs:  ids,
+		logger:       logger,
+		logs:         newRateLimitedLogger(logger, 0),
+		events:       &eventLog{},
+		defaultSpeed: 50,
+		defaultAcc:   100,
+		usage:        newDutyCycleTracker(filepath.Join(t.TempDir(), "usage.json"), 0, 0, logger),
+	}
+	ctx := context.Background()
+
+	// A missed grab on the port only aborts playback that asked for it
+	abort := playbackAbort{onGrabFailure: true, grabFailures: controller.grabFailures.count()}
+	assert.Empty(t, s.playbackAbortReason(ctx, abort))
+	controller.grabFailures.add()
+	assert.Equal(t, "the gripper missed a grab", s.playbackAbortReason(ctx, abort))
+	assert.Empty(t, s.playbackAbortReason(ctx, playbackAbort{}))
+
+	// An emergency stop ends playback with the cycles completed so far instead of an error
+	controller.estop.latch("operator stop")
+	assert.Contains(t, s.playbackAbortReason(ctx, playbackAbort{}), "emergency stopped")
+	result, err := s.executeTrajectory(ctx, map[string]interface{}{
+		"positions_degs": []interface{}{
+			[]interface{}{0.0, 0.0, 0.0, 0.0, 0.0},
+			[]interface{}{5.0, 0.0, 0.0, 0.0, 0.0},
+		},
+		"times_s": []interface{}{0.0, 1.0},
+		"loops":   2.0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, false, result["success"])
+	assert.Equal(t, true, result["aborted"])
+	assert.Equal(t, 0, result["cycles_completed"])
+	assert.Contains(t, result["error"], "emergency stopped")
+}
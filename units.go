@@ -0,0 +1,59 @@
+package so_arm
+
+import "math"
+
+// STS3215 position encoding: 4096 steps per revolution, positions 0-4095
+const (
+	ServoStepsPerRevolution = 4096
+	ServoMaxPosition        = ServoStepsPerRevolution - 1
+)
+
+// RadiansToDegrees converts an angle in radians to degrees
+func RadiansToDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// DegreesToRadians converts an angle in degrees to radians
+func DegreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// StepsToDegrees converts a servo step count (or step offset) to degrees
+func StepsToDegrees(steps float64) float64 {
+	return steps * 360 / ServoStepsPerRevolution
+}
+
+// DegreesToSteps converts degrees to a servo step count (or step offset)
+func DegreesToSteps(degrees float64) float64 {
+	return degrees * ServoStepsPerRevolution / 360
+}
+
+// The acceleration register counts in units of 100 steps/s², 0 leaves acceleration unlimited
+const (
+	servoAccelerationUnit = 100
+	servoMaxAcceleration  = 254
+)
+
+// AccelerationToRegister converts an acceleration in degrees/s² to the acceleration register
+// value. Zero stays zero (unlimited), anything else is at least 1 so it never turns into
+// unlimited by rounding.
+func AccelerationToRegister(degsPerSecPerSec float64) int {
+	if degsPerSecPerSec <= 0 {
+		return 0
+	}
+	value := int(math.Round(DegreesToSteps(degsPerSecPerSec) / servoAccelerationUnit))
+	return max(1, min(servoMaxAcceleration, value))
+}
+
+// The gripper is normalized to 0-100% but reported through the joint API in radians,
+// with 0% at -π and 100% at +π
+
+// GripperPercentToRadians converts a gripper opening percentage to its radian representation
+func GripperPercentToRadians(percent float64) float64 {
+	return (percent/100*2 - 1) * math.Pi
+}
+
+// GripperRadiansToPercent converts the gripper radian representation back to a percentage
+func GripperRadiansToPercent(radians float64) float64 {
+	return (radians/math.Pi + 1) / 2 * 100
+}
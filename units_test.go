@@ -0,0 +1,69 @@
+package so_arm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAngleConversions(t *testing.T) {
+	assert.InDelta(t, 180.0, RadiansToDegrees(math.Pi), 1e-12)
+	assert.InDelta(t, -90.0, RadiansToDegrees(-math.Pi/2), 1e-12)
+	assert.InDelta(t, math.Pi/4, DegreesToRadians(45), 1e-12)
+
+	for _, deg := range []float64{-180, -33.3, 0, 12.5, 179.9} {
+		assert.InDelta(t, deg, RadiansToDegrees(DegreesToRadians(deg)), 1e-9)
+	}
+}
+
+func TestStepConversions(t *testing.T) {
+	// A full revolution is 4096 steps, not 4095
+	assert.InDelta(t, 360.0, StepsToDegrees(ServoStepsPerRevolution), 1e-12)
+	assert.InDelta(t, 90.0, StepsToDegrees(1024), 1e-12)
+	assert.InDelta(t, 2048.0, DegreesToSteps(180), 1e-12)
+
+	for _, steps := range []float64{-2048, -1, 0, 1, 1500} {
+		assert.InDelta(t, steps, DegreesToSteps(StepsToDegrees(steps)), 1e-9)
+	}
+}
+
+func TestGripperConversions(t *testing.T) {
+	assert.InDelta(t, -math.Pi, GripperPercentToRadians(0), 1e-12)
+	assert.InDelta(t, 0.0, GripperPercentToRadians(50), 1e-12)
+	assert.InDelta(t, math.Pi, GripperPercentToRadians(100), 1e-12)
+
+	for _, percent := range []float64{0, 12.5, 50, 95, 100} {
+		assert.InDelta(t, percent, GripperRadiansToPercent(GripperPercentToRadians(percent)), 1e-9)
+	}
+}
+
+func TestDegreesNormalizationRoundTrip(t *testing.T) {
+	cal := &MotorCalibration{ID: 1, RangeMin: 500, RangeMax: 3500, NormMode: NormModeDegrees}
+
+	// Center of the calibrated range is 0°
+	normalized, err := cal.Normalize(2000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0, normalized, 1e-12)
+
+	// 1024 steps from center is a quarter turn
+	normalized, err = cal.Normalize(3024)
+	assert.NoError(t, err)
+	assert.InDelta(t, 90.0, normalized, 1e-12)
+
+	for _, raw := range []int{500, 1234, 2000, 2001, 3500} {
+		normalized, err := cal.Normalize(raw)
+		assert.NoError(t, err)
+		back, err := cal.Denormalize(normalized)
+		assert.NoError(t, err)
+		assert.Equal(t, raw, back)
+	}
+}
+
+func TestAccelerationToRegister(t *testing.T) {
+	assert.Equal(t, 0, AccelerationToRegister(0))
+	// Small accelerations never round down to unlimited
+	assert.Equal(t, 1, AccelerationToRegister(10))
+	assert.Equal(t, 57, AccelerationToRegister(500))
+	assert.Equal(t, servoMaxAcceleration, AccelerationToRegister(1e6))
+}
@@ -0,0 +1,175 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/hipsterbrown/feetech-servo/feetech"
+)
+
+// setVelocityMode switches one arm joint into or out of velocity (wheel) mode, so it can
+// spin continuously like a turntable instead of moving to positions
+func (s *so101) setVelocityMode(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	servoID, err := s.armServoFromCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	enable, ok := cmd["enable"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("set_velocity_mode requires 'enable' boolean parameter")
+	}
+
+	s.moveLock.Lock()
+	defer s.moveLock.Unlock()
+
+	mode := feetech.ModePosition
+	if enable {
+		mode = feetech.ModeVelocity
+	}
+	if err := s.controller.SetServoOperatingMode(ctx, servoID, mode); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if enable {
+		s.velocityServos[servoID] = true
+	} else {
+		delete(s.velocityServos, servoID)
+	}
+	s.mu.Unlock()
+
+	return map[string]interface{}{
+		"success":         true,
+		"servo_id":        servoID,
+		"joint":           jointNameForServo(servoID),
+		"velocity_mode":   enable,
+		"velocity_joints": s.velocityModeServos(),
+	}, nil
+}
+
+// setJointVelocity commands a signed speed to a joint in velocity mode, zero stops it
+func (s *so101) setJointVelocity(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	servoID, err := s.armServoFromCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	velocity, ok := cmd["degs_per_sec"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("set_joint_velocity requires 'degs_per_sec' number parameter")
+	}
+	if math.Abs(velocity) > maxSpeedDegsPerSec {
+		return nil, fmt.Errorf("degs_per_sec must be between -%d and %d, got %.1f", maxSpeedDegsPerSec, maxSpeedDegsPerSec, velocity)
+	}
+
+	if velocity != 0 {
+		if err := s.checkMotionAllowed(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.RLock()
+	inVelocityMode := s.velocityServos[servoID]
+	s.mu.RUnlock()
+	if !inVelocityMode {
+		return nil, fmt.Errorf("servo %d is not in velocity mode, enable it with set_velocity_mode first", servoID)
+	}
+
+	if err := s.controller.SetServoVelocity(ctx, servoID, velocity); err != nil {
+		return nil, fmt.Errorf("failed to set velocity of servo %d: %w", servoID, err)
+	}
+	return map[string]interface{}{"success": true, "servo_id": servoID, "degs_per_sec": velocity}, nil
+}
+
+// armServoFromCommand reads servo_id from a command and checks this arm controls it
+func (s *so101) armServoFromCommand(cmd map[string]interface{}) (int, error) {
+	servoIDs := s.servoIDs()
+	id, ok := cmd["servo_id"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("command requires 'servo_id' number parameter")
+	}
+	servoID := int(id)
+	for _, armID := range servoIDs {
+		if armID == servoID {
+			return servoID, nil
+		}
+	}
+	return 0, fmt.Errorf("servo %d is not one of this arm's joints %v", servoID, servoIDs)
+}
+
+// velocityModeServos returns the joints currently in velocity mode, sorted by servo ID
+func (s *so101) velocityModeServos() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]int, 0, len(s.velocityServos))
+	for id := range s.velocityServos {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// stopVelocityServos sets every joint in velocity mode to zero speed
+func (s *so101) stopVelocityServos(ctx context.Context) error {
+	for _, id := range s.velocityModeServos() {
+		if err := s.controller.SetServoVelocity(ctx, id, 0); err != nil {
+			return fmt.Errorf("failed to stop servo %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// restorePositionMode returns every joint in velocity mode to position mode
+func (s *so101) restorePositionMode(ctx context.Context) {
+	for _, id := range s.velocityModeServos() {
+		if err := s.controller.SetServoOperatingMode(ctx, id, feetech.ModePosition); err != nil {
+			s.logger.Warnf("Failed to restore position mode on servo %d: %v", id, err)
+			continue
+		}
+		s.mu.Lock()
+		delete(s.velocityServos, id)
+		s.mu.Unlock()
+	}
+}
+
+// velocityModes records which servos on a port the module switched into velocity mode. It's
+// shared by every component on the port, so an emergency stop from any of them knows which
+// servos are spinning.
+type velocityModes struct {
+	mu  sync.Mutex
+	ids map[int]bool
+}
+
+func (v *velocityModes) set(servoID int, velocity bool) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.ids == nil {
+		v.ids = map[int]bool{}
+	}
+	if velocity {
+		v.ids[servoID] = true
+	} else {
+		delete(v.ids, servoID)
+	}
+}
+
+// servos returns the servos in velocity mode, sorted by servo ID
+func (v *velocityModes) servos() []int {
+	if v == nil {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	ids := make([]int, 0, len(v.ids))
+	for id := range v.ids {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,104 @@
+package so_arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// visualizationState returns the pose of every kinematic frame and link geometry at the
+// current joint positions, in the arm's base frame, so a web viewer can draw the arm
+// without its own copy of the kinematics
+func (s *so101) visualizationState(ctx context.Context) (map[string]interface{}, error) {
+	inputs, err := s.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := framePoses(s.model, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute frame poses: %w", err)
+	}
+
+	geometries := []interface{}{}
+	gif, err := s.model.Geometries(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute geometries: %w", err)
+	}
+	for _, geometry := range gif.Geometries() {
+		entry := map[string]interface{}{
+			"label": geometry.Label(),
+			"pose":  poseToMap(geometry.Pose()),
+		}
+		if config, err := spatialmath.NewGeometryConfig(geometry); err == nil {
+			entry["type"] = string(config.Type)
+			entry["x"] = config.X
+			entry["y"] = config.Y
+			entry["z"] = config.Z
+			entry["r"] = config.R
+			entry["l"] = config.L
+		}
+		geometries = append(geometries, entry)
+	}
+
+	joints := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		joints[i] = RadiansToDegrees(input)
+	}
+
+	return map[string]interface{}{
+		"timestamp":      time.Now().UTC().Format(time.RFC3339Nano),
+		"joints_degrees": joints,
+		"frames":         frames,
+		"geometries":     geometries,
+	}, nil
+}
+
+// framePoses computes the pose of each frame of a model, from the base outwards. Each pose
+// is where the frame ends up after its own transform, the same convention as the frame system.
+func framePoses(model referenceframe.Model, inputs []referenceframe.Input) ([]interface{}, error) {
+	simple, ok := model.(*referenceframe.SimpleModel)
+	if !ok {
+		return nil, fmt.Errorf("model %T does not expose its frames", model)
+	}
+
+	frames := []interface{}{}
+	composed := spatialmath.NewZeroPose()
+	inputIdx := 0
+	for _, frame := range simple.OrdTransforms() {
+		dof := len(frame.DoF())
+		if inputIdx+dof > len(inputs) {
+			return nil, fmt.Errorf("model needs more than %d inputs", len(inputs))
+		}
+		pose, err := frame.Transform(inputs[inputIdx : inputIdx+dof])
+		if err != nil {
+			return nil, err
+		}
+		inputIdx += dof
+
+		composed = spatialmath.Compose(composed, pose)
+		frames = append(frames, map[string]interface{}{
+			"name": frame.Name(),
+			"pose": poseToMap(composed),
+		})
+	}
+	return frames, nil
+}
+
+// poseToMap converts a pose to millimeters and an orientation vector in degrees
+func poseToMap(pose spatialmath.Pose) map[string]interface{} {
+	point := pose.Point()
+	orientation := pose.Orientation().OrientationVectorDegrees()
+	return map[string]interface{}{
+		"x":     point.X,
+		"y":     point.Y,
+		"z":     point.Z,
+		"o_x":   orientation.OX,
+		"o_y":   orientation.OY,
+		"o_z":   orientation.OZ,
+		"theta": orientation.Theta,
+	}
+}
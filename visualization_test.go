@@ -0,0 +1,29 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/referenceframe"
+)
+
+func TestFramePosesEndAtEndEffector(t *testing.T) {
+	model, err := makeSO101ModelFrame()
+	assert.NoError(t, err)
+
+	inputs := []float64{0.1, -0.4, 0.6, 0.2, -0.3}
+	frames, err := framePoses(model, inputs)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, frames)
+
+	endPose, err := referenceframe.ComputeOOBPosition(model, inputs)
+	assert.NoError(t, err)
+
+	last := frames[len(frames)-1].(map[string]interface{})["pose"].(map[string]interface{})
+	assert.InDelta(t, endPose.Point().X, last["x"], 1e-6)
+	assert.InDelta(t, endPose.Point().Y, last["y"], 1e-6)
+	assert.InDelta(t, endPose.Point().Z, last["z"], 1e-6)
+
+	_, err = framePoses(model, inputs[:2])
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package so_arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Communication watchdog. While the arm is moving the bus is polled, and after enough reads
+// fail in a row the arm is told to stop and hold where it is instead of coasting to its last
+// goal unnoticed.
+const (
+	defaultWatchdogMaxFailures = 3
+	watchdogPollInterval       = 100 * time.Millisecond
+	watchdogStopTimeout        = 2 * time.Second
+)
+
+// ErrWatchdogTripped is returned for moves while a communication watchdog fault is latched
+var ErrWatchdogTripped = errors.New("WATCHDOG_TRIPPED: communication was lost during a move, clear the watchdog fault before moving")
+
+// commWatchdog counts consecutive bus failures and latches a fault when they reach the limit
+type commWatchdog struct {
+	maxFailures int
+
+	mu          sync.Mutex
+	consecutive int
+	tripped     bool
+	trippedAt   time.Time
+	lastErr     error
+}
+
+func newCommWatchdog(maxFailures int) *commWatchdog {
+	if maxFailures == 0 {
+		maxFailures = defaultWatchdogMaxFailures
+	}
+	return &commWatchdog{maxFailures: maxFailures}
+}
+
+// record counts a poll result and reports whether this failure trips the watchdog. Every run
+// of failures that reaches the limit trips it, even if an earlier fault is still latched.
+func (w *commWatchdog) record(err error) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err == nil {
+		w.consecutive = 0
+		return false
+	}
+	w.consecutive++
+	w.lastErr = err
+	if w.consecutive != w.maxFailures {
+		return false
+	}
+	w.tripped = true
+	w.trippedAt = time.Now()
+	return true
+}
+
+// check returns ErrWatchdogTripped while a fault is latched
+func (w *commWatchdog) check() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.tripped {
+		return nil
+	}
+	return fmt.Errorf("%w (last error: %v)", ErrWatchdogTripped, w.lastErr)
+}
+
+func (w *commWatchdog) clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.tripped = false
+	w.consecutive = 0
+	w.lastErr = nil
+}
+
+func (w *commWatchdog) status() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	result := map[string]interface{}{
+		"tripped":              w.tripped,
+		"consecutive_failures": w.consecutive,
+		"max_failures":         w.maxFailures,
+	}
+	if w.tripped {
+		result["tripped_at"] = w.trippedAt.Format(time.RFC3339)
+	}
+	if w.lastErr != nil {
+		result["last_error"] = w.lastErr.Error()
+	}
+	return result
+}
+
+// watchCommunication polls the bus while the arm is moving until ctx is done
+func (s *so101) watchCommunication(ctx context.Context) {
+	ticker := time.NewTicker(watchdogPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !s.isMoving.Load() {
+			continue
+		}
+
+		_, err := s.controller.ServosMoving(ctx, s.servoIDs())
+		if ctx.Err() != nil {
+			return
+		}
+		if !s.watchdog.record(err) {
+			continue
+		}
+
+		s.logger.Errorf("Lost communication with the arm during a move (%d failed reads, last: %v), stopping", s.watchdog.maxFailures, err)
+		s.events.add(eventError, "communication watchdog tripped: %v", err)
+		stopCtx, cancel := context.WithTimeout(ctx, watchdogStopTimeout)
+		if err := s.Stop(stopCtx, nil); err != nil {
+			s.logger.Errorf("Watchdog stop failed, the arm may still be moving to its last goal: %v", err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,65 @@
+package so_arm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommWatchdog(t *testing.T) {
+	w := newCommWatchdog(0)
+	assert.Equal(t, defaultWatchdogMaxFailures, w.maxFailures)
+
+	readErr := errors.New("timeout")
+	assert.False(t, w.record(readErr))
+	assert.False(t, w.record(nil))
+
+	// Failures must be consecutive
+	assert.False(t, w.record(readErr))
+	assert.False(t, w.record(readErr))
+	assert.True(t, w.record(readErr))
+	assert.False(t, w.record(readErr), "a run of failures trips once")
+	assert.ErrorIs(t, w.check(), ErrWatchdogTripped)
+
+	status := w.status()
+	assert.Equal(t, true, status["tripped"])
+	assert.Equal(t, "timeout", status["last_error"])
+
+	// Communication recovers, then is lost again before anyone clears the fault
+	assert.False(t, w.record(nil))
+	assert.False(t, w.record(readErr))
+	assert.False(t, w.record(readErr))
+	assert.True(t, w.record(readErr), "a second loss trips again")
+
+	w.clear()
+	assert.NoError(t, w.check())
+	status = w.status()
+	assert.Equal(t, false, status["tripped"])
+	assert.Equal(t, 0, status["consecutive_failures"])
+
+	// After a clear the next loss trips as well
+	for i := 0; i < defaultWatchdogMaxFailures-1; i++ {
+		assert.False(t, w.record(readErr))
+	}
+	assert.True(t, w.record(readErr))
+
+	var missing *commWatchdog
+	assert.NoError(t, missing.check())
+}
+
+func TestWatchdogFaultBlocksMotion(t *testing.T) {
+	s := &so101{
+		controller:  &SafeSoArmController{calibration: DefaultSO101FullCalibration},
+		maintenance: &maintenanceMode{},
+		watchdog:    newCommWatchdog(1),
+		cfg:         &SO101ArmConfig{},
+	}
+	assert.NoError(t, s.checkMotionAllowed())
+
+	s.watchdog.record(errors.New("timeout"))
+	assert.ErrorIs(t, s.checkMotionAllowed(), ErrWatchdogTripped)
+
+	s.watchdog.clear()
+	assert.NoError(t, s.checkMotionAllowed())
+}
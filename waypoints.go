@@ -0,0 +1,72 @@
+package so_arm
+
+import (
+	"math"
+	"sync"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+// dedupWaypoints drops waypoints where no joint moves more than epsilon radians from the
+// last waypoint kept. The final waypoint is always the trajectory's end, so a pruned tail
+// replaces the last kept intermediate waypoint instead of being lost.
+func dedupWaypoints(positions [][]referenceframe.Input, epsilon float64) ([][]referenceframe.Input, int) {
+	if epsilon <= 0 || len(positions) < 2 {
+		return positions, 0
+	}
+
+	kept := [][]referenceframe.Input{positions[0]}
+	lastKept := 0
+	for i := 1; i < len(positions); i++ {
+		if maxJointDelta(kept[len(kept)-1], positions[i]) > epsilon {
+			kept = append(kept, positions[i])
+			lastKept = i
+		}
+	}
+	if end := len(positions) - 1; lastKept != end {
+		if len(kept) > 1 {
+			kept[len(kept)-1] = positions[end]
+		} else {
+			kept = append(kept, positions[end])
+		}
+	}
+	return kept, len(positions) - len(kept)
+}
+
+func maxJointDelta(a, b []referenceframe.Input) float64 {
+	if len(a) != len(b) {
+		return math.Inf(1)
+	}
+	delta := 0.0
+	for i := range a {
+		delta = math.Max(delta, math.Abs(b[i]-a[i]))
+	}
+	return delta
+}
+
+// waypointFilterStats counts what dedupWaypoints removed, for waypoint_filter_status
+type waypointFilterStats struct {
+	mu           sync.Mutex
+	lastReceived int
+	lastPruned   int
+	totalPruned  int
+}
+
+func (w *waypointFilterStats) record(received, pruned int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastReceived = received
+	w.lastPruned = pruned
+	w.totalPruned += pruned
+}
+
+func (w *waypointFilterStats) status(epsilonDegs float64) map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return map[string]interface{}{
+		"epsilon_degs":  epsilonDegs,
+		"last_received": w.lastReceived,
+		"last_pruned":   w.lastPruned,
+		"total_pruned":  w.totalPruned,
+	}
+}
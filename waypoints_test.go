@@ -0,0 +1,45 @@
+package so_arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/referenceframe"
+)
+
+func TestDedupWaypoints(t *testing.T) {
+	epsilon := DegreesToRadians(1)
+	small := DegreesToRadians(0.5)
+	big := DegreesToRadians(5)
+	positions := [][]referenceframe.Input{
+		{0, 0},
+		{small, 0},
+		{big, 0},
+		{big, small},
+		{big, small * 1.5},
+	}
+
+	kept, pruned := dedupWaypoints(positions, epsilon)
+	assert.Equal(t, 3, pruned)
+	assert.Len(t, kept, 2)
+	assert.Equal(t, positions[0], kept[0])
+	// The tail is within epsilon of the last kept waypoint, which it replaces so the
+	// trajectory still ends where it was asked to
+	assert.Equal(t, positions[4], kept[1])
+
+	// Everything within epsilon still moves to the end
+	kept, pruned = dedupWaypoints(positions[:2], epsilon)
+	assert.Equal(t, 0, pruned)
+	assert.Len(t, kept, 2)
+
+	kept, pruned = dedupWaypoints(positions, 0)
+	assert.Equal(t, 0, pruned)
+	assert.Len(t, kept, 5)
+
+	stats := &waypointFilterStats{}
+	stats.record(5, 2)
+	stats.record(3, 1)
+	status := stats.status(1)
+	assert.Equal(t, 1, status["last_pruned"])
+	assert.Equal(t, 3, status["total_pruned"])
+}